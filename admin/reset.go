@@ -0,0 +1,328 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package admin the authenticated admin http api of npu-exporter, which is served on a separate listener
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"huawei.com/npu-exporter/v5/collector/container"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+const (
+	// ChipResetPath resets a chip which is not used by any process or container
+	ChipResetPath = "/admin/v1/chip/reset"
+	// DefaultBootTimeout the default time to wait for the chip to finish booting after reset
+	DefaultBootTimeout = 60 * time.Second
+	// MaxBootTimeout the max time to wait for the chip to finish booting after reset
+	MaxBootTimeout = 300 * time.Second
+
+	bootPollInterval = time.Second
+	opReset          = "reset chip"
+)
+
+var (
+	resetLabels = []string{"id"}
+
+	npuChipResetTotal = prometheus.NewDesc("npu_chip_reset_total",
+		"the number of the resets issued to the npu by the admin api or the reset command", resetLabels, nil)
+	npuChipLastResetResult = prometheus.NewDesc("npu_chip_last_reset_result",
+		"the result of the last reset of the npu, 1 means the npu finished booting in time, 0 means failed",
+		resetLabels, nil)
+	npuChipLastResetTimestamp = prometheus.NewDesc("npu_chip_last_reset_timestamp_seconds",
+		"the unix time of the last reset of the npu", resetLabels, nil)
+)
+
+// ContainerDevicesFunc returns the npu devices used by the containers, which is usually DevicesParser.Parse
+type ContainerDevicesFunc func() (container.DevicesInfos, error)
+
+// ResetRequest the request to reset a chip
+type ResetRequest struct {
+	LogicID int32 `json:"logic_id"`
+	// TimeoutSeconds the seconds to wait for the chip to finish booting, 0 means DefaultBootTimeout
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+	// DryRun only checks the chip is not used by any process or container
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// ResetResponse the result of resetting a chip
+type ResetResponse struct {
+	LogicID    int32 `json:"logic_id"`
+	PhyID      int32 `json:"phy_id"`
+	BootStatus int   `json:"boot_status,omitempty"`
+	DryRun     bool  `json:"dry_run,omitempty"`
+}
+
+// resetStat the reset count and the last reset result of a chip
+type resetStat struct {
+	count     uint64
+	succeeded bool
+	time      time.Time
+}
+
+// Resetter resets the chips after checking they are not in use, and exports the reset count and the last reset
+// result of each chip as a prometheus collector
+type Resetter struct {
+	dmgr             devmanager.DeviceInterface
+	containerDevices ContainerDevicesFunc
+	pollInterval     time.Duration
+	// lock allows only one reset at a time, so that the checks are not invalidated by another reset
+	lock      sync.Mutex
+	statsLock sync.RWMutex
+	// stats keyed by the physical id of the chip
+	stats map[int32]*resetStat
+}
+
+// NewResetter returns the resetter, containerDevices is required to refuse resetting the chips used by containers
+func NewResetter(dmgr devmanager.DeviceInterface, containerDevices ContainerDevicesFunc) (*Resetter, error) {
+	if dmgr == nil {
+		return nil, errors.New("device manager is nil")
+	}
+	if containerDevices == nil {
+		return nil, errors.New("the devices of containers are required to reset chip")
+	}
+	return &Resetter{dmgr: dmgr, containerDevices: containerDevices, pollInterval: bootPollInterval,
+		stats: make(map[int32]*resetStat)}, nil
+}
+
+// Reset resets the chip and waits for it to finish booting, the chip is refused to be reset when it is used by any
+// process or container, or its usage can not be checked
+func (rs *Resetter) Reset(req ResetRequest) (ResetResponse, error) {
+	bootTimeout := time.Duration(req.TimeoutSeconds) * time.Second
+	if req.TimeoutSeconds == 0 {
+		bootTimeout = DefaultBootTimeout
+	}
+	if bootTimeout <= 0 || bootTimeout > MaxBootTimeout {
+		return ResetResponse{}, newStatusError(http.StatusBadRequest, "timeout_seconds should be in [1, %d]",
+			int(MaxBootTimeout/time.Second))
+	}
+	if !rs.lock.TryLock() {
+		return ResetResponse{}, newStatusError(http.StatusConflict, "another reset is in progress")
+	}
+	defer rs.lock.Unlock()
+	if err := checkLogicID(rs.dmgr, req.LogicID); err != nil {
+		return ResetResponse{}, err
+	}
+	phyID, err := rs.dmgr.GetPhysicIDFromLogicID(req.LogicID)
+	if err != nil {
+		return ResetResponse{}, newStatusError(http.StatusInternalServerError,
+			"get physic id of npu %d failed: %v", req.LogicID, err)
+	}
+	cardID, deviceID, err := rs.dmgr.GetCardIDDeviceID(req.LogicID)
+	if err != nil {
+		return ResetResponse{}, newStatusError(http.StatusInternalServerError,
+			"get card id and device id of npu %d failed: %v", req.LogicID, err)
+	}
+	chips, err := rs.resetChips(cardID, phyID)
+	if err != nil {
+		return ResetResponse{}, err
+	}
+	for _, chip := range chips {
+		if err := rs.checkProcesses(chip.logicID); err != nil {
+			return ResetResponse{}, err
+		}
+		if err := rs.checkContainers(chip.logicID, chip.phyID); err != nil {
+			return ResetResponse{}, err
+		}
+	}
+	resp := ResetResponse{LogicID: req.LogicID, PhyID: phyID}
+	if req.DryRun {
+		resp.DryRun = true
+		return resp, nil
+	}
+	if err := rs.dmgr.SetDeviceReset(cardID, deviceID); err != nil {
+		rs.record(phyID, false)
+		return ResetResponse{}, newStatusError(http.StatusInternalServerError, "reset npu %d failed: %v",
+			req.LogicID, err)
+	}
+	resp.BootStatus, err = rs.waitBoot(req.LogicID, bootTimeout)
+	rs.record(phyID, err == nil)
+	if err != nil {
+		return ResetResponse{}, err
+	}
+	return resp, nil
+}
+
+// resetChip the ids of a chip which is reset
+type resetChip struct {
+	logicID int32
+	phyID   int32
+}
+
+// resetChips returns the chips which are reset together with the requested one, resetting a chip resets all chips
+// on the same card, e.g. the two chips of Atlas 300I Duo, and the chips full meshed by HCCS on Ascend910 boards
+func (rs *Resetter) resetChips(cardID, phyID int32) ([]resetChip, error) {
+	_, logicIDs, err := rs.dmgr.GetDeviceList()
+	if err != nil {
+		return nil, newStatusError(http.StatusInternalServerError, "get npu list failed: %v", err)
+	}
+	hccsGroup := rs.dmgr.GetCardType(cardID) == common.Ascend910
+	var chips []resetChip
+	for _, logicID := range logicIDs {
+		chipPhyID, err := rs.dmgr.GetPhysicIDFromLogicID(logicID)
+		if err != nil {
+			return nil, newStatusError(http.StatusInternalServerError,
+				"get physic id of npu %d failed: %v", logicID, err)
+		}
+		chipCardID, _, err := rs.dmgr.GetCardIDDeviceID(logicID)
+		if err != nil {
+			return nil, newStatusError(http.StatusInternalServerError,
+				"get card id and device id of npu %d failed: %v", logicID, err)
+		}
+		if chipCardID == cardID || chipPhyID == phyID ||
+			(hccsGroup && chipPhyID/common.Hccs910DomainSize == phyID/common.Hccs910DomainSize) {
+			chips = append(chips, resetChip{logicID: logicID, phyID: chipPhyID})
+		}
+	}
+	return chips, nil
+}
+
+// checkProcesses refuses the reset when there are processes running on the chip
+func (rs *Resetter) checkProcesses(logicID int32) error {
+	procInfo, err := rs.dmgr.GetDevProcessInfo(logicID)
+	if err != nil {
+		return newStatusError(http.StatusInternalServerError, "get processes of npu %d failed: %v", logicID, err)
+	}
+	if procInfo == nil || procInfo.ProcNum <= 0 {
+		return nil
+	}
+	pids := make([]string, 0, len(procInfo.DevProcArray))
+	for i := 0; i < int(procInfo.ProcNum) && i < len(procInfo.DevProcArray); i++ {
+		pids = append(pids, strconv.Itoa(int(procInfo.DevProcArray[i].Pid)))
+	}
+	return newStatusError(http.StatusConflict, "npu %d is used by %d processes, pids: %s", logicID,
+		procInfo.ProcNum, strings.Join(pids, ","))
+}
+
+// checkContainers refuses the reset when the chip or a vNPU on it is mounted into a container. The chip of a vNPU is
+// found by the virtual device info of chips, the vNPU whose chip still can not be found is regarded as on the chip
+func (rs *Resetter) checkContainers(logicID, phyID int32) error {
+	devicesInfos, err := rs.containerDevices()
+	if err != nil {
+		return newStatusError(http.StatusServiceUnavailable, "get devices of containers failed: %v", err)
+	}
+	var names []string
+	for _, info := range devicesInfos {
+		for _, dev := range info.Devices {
+			if dev.PhyID == int(phyID) || (dev.IsVirtual() && dev.PhyID < 0) {
+				names = append(names, info.Name)
+				break
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	return newStatusError(http.StatusConflict, "npu %d is used by containers: %s", logicID,
+		strings.Join(names, ","))
+}
+
+// waitBoot polls the boot status until the chip finishes booting, the failures of querying are tolerated because
+// the chip may be unavailable during booting
+func (rs *Resetter) waitBoot(logicID int32, bootTimeout time.Duration) (int, error) {
+	deadline := time.Now().Add(bootTimeout)
+	status := -1
+	var err error
+	for {
+		time.Sleep(rs.pollInterval)
+		status, err = rs.dmgr.GetDeviceBootStatus(logicID)
+		if err == nil && status == common.BootStartFinish {
+			return status, nil
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+	}
+	if err != nil {
+		return status, newStatusError(http.StatusGatewayTimeout,
+			"npu %d does not finish booting in %v, the last error is: %v", logicID, bootTimeout, err)
+	}
+	return status, newStatusError(http.StatusGatewayTimeout,
+		"npu %d does not finish booting in %v, the last boot status is %d", logicID, bootTimeout, status)
+}
+
+func (rs *Resetter) record(phyID int32, succeeded bool) {
+	rs.statsLock.Lock()
+	defer rs.statsLock.Unlock()
+	stat, ok := rs.stats[phyID]
+	if !ok {
+		stat = &resetStat{}
+		rs.stats[phyID] = stat
+	}
+	stat.count++
+	stat.succeeded = succeeded
+	stat.time = time.Now()
+}
+
+// Describe implements prometheus.Collector
+func (rs *Resetter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- npuChipResetTotal
+	ch <- npuChipLastResetResult
+	ch <- npuChipLastResetTimestamp
+}
+
+// Collect implements prometheus.Collector, only the chips which have been reset are exported
+func (rs *Resetter) Collect(ch chan<- prometheus.Metric) {
+	rs.statsLock.RLock()
+	defer rs.statsLock.RUnlock()
+	for phyID, stat := range rs.stats {
+		id := strconv.Itoa(int(phyID))
+		result := 0.0
+		if stat.succeeded {
+			result = 1
+		}
+		ch <- prometheus.MustNewConstMetric(npuChipResetTotal, prometheus.CounterValue, float64(stat.count), id)
+		ch <- prometheus.MustNewConstMetric(npuChipLastResetResult, prometheus.GaugeValue, result, id)
+		ch <- prometheus.MustNewConstMetric(npuChipLastResetTimestamp, prometheus.GaugeValue,
+			float64(stat.time.Unix()), id)
+	}
+}
+
+type resetHandler struct {
+	resetter *Resetter
+}
+
+func (h *resetHandler) register(mux *http.ServeMux) {
+	mux.HandleFunc(ChipResetPath, h.handleReset)
+}
+
+func (h *resetHandler) handleReset(w http.ResponseWriter, r *http.Request) {
+	var req ResetRequest
+	if err := decodeRequest(r, &req); err != nil {
+		auditf(r, opReset, "", err)
+		writeStatusError(w, err)
+		return
+	}
+	detail := fmt.Sprintf("logic_id=%d timeout_seconds=%d dry_run=%v", req.LogicID, req.TimeoutSeconds, req.DryRun)
+	resp, err := h.resetter.Reset(req)
+	auditf(r, opReset, detail, err)
+	if err != nil {
+		writeStatusError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
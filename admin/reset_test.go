@@ -0,0 +1,248 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package admin the authenticated admin http api of npu-exporter, which is served on a separate listener
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/collector/container"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+// resetDeviceManager a mock of the device manager which records the resets, the chip 0 has the physic id 1
+type resetDeviceManager struct {
+	devmanager.DeviceManagerMock
+	procNum    int32
+	bootStatus int
+	resets     int
+}
+
+func (d *resetDeviceManager) GetDevProcessInfo(logicID int32) (*common.DevProcessInfo, error) {
+	procs := make([]common.DevProcInfo, d.procNum)
+	for i := range procs {
+		procs[i].Pid = int32(i + 1)
+	}
+	return &common.DevProcessInfo{DevProcArray: procs, ProcNum: d.procNum}, nil
+}
+
+func (d *resetDeviceManager) SetDeviceReset(cardID, deviceID int32) error {
+	d.resets++
+	return nil
+}
+
+func (d *resetDeviceManager) GetDeviceBootStatus(logicID int32) (int, error) {
+	return d.bootStatus, nil
+}
+
+func newTestResetter(t *testing.T, dmgr devmanager.DeviceInterface, devices container.DevicesInfos,
+	devicesErr error) *Resetter {
+	resetter, err := NewResetter(dmgr, func() (container.DevicesInfos, error) {
+		return devices, devicesErr
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resetter.pollInterval = time.Millisecond
+	return resetter
+}
+
+// TestResetterReset test the interlocks of resetting a chip
+func TestResetterReset(t *testing.T) {
+	idle := container.DevicesInfos{"c1": {ID: "c1", Name: "default_pod1_c1",
+		Devices: []container.NpuDevice{{PhyID: 0, VDevID: container.NoVDevID}}}}
+	tests := []struct {
+		name      string
+		procNum   int32
+		devices   container.DevicesInfos
+		devErr    error
+		req       ResetRequest
+		status    int
+		errSubstr string
+		resets    int
+	}{
+		{name: "should reset chip when it is not used", devices: idle, req: ResetRequest{LogicID: 0}, resets: 1},
+		{name: "should not reset chip when dry run", devices: idle, req: ResetRequest{LogicID: 0, DryRun: true}},
+		{name: "should refuse reset when processes are running on chip", procNum: 2, devices: idle,
+			status: http.StatusConflict, errSubstr: "pids: 1,2"},
+		{name: "should refuse reset when chip is mounted into container",
+			devices: container.DevicesInfos{"c2": {ID: "c2", Name: "default_pod2_c2",
+				Devices: []container.NpuDevice{{PhyID: 1, VDevID: container.NoVDevID}}}},
+			status: http.StatusConflict, errSubstr: "default_pod2_c2"},
+		{name: "should refuse reset when chip of vnpu in container is unknown",
+			devices: container.DevicesInfos{"c3": {ID: "c3", Name: "default_pod3_c3",
+				Devices: []container.NpuDevice{{PhyID: -1, VDevID: common.MinVDevID}}}},
+			status: http.StatusConflict, errSubstr: "default_pod3_c3"},
+		{name: "should refuse reset when devices of containers are unknown", devErr: errors.New("runtime down"),
+			status: http.StatusServiceUnavailable},
+		{name: "should refuse reset when chip is not found", req: ResetRequest{LogicID: 3},
+			status: http.StatusNotFound},
+		{name: "should refuse reset when timeout is out of range", req: ResetRequest{TimeoutSeconds: 301},
+			status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dmgr := &resetDeviceManager{procNum: tt.procNum, bootStatus: common.BootStartFinish}
+			resp, err := newTestResetter(t, dmgr, tt.devices, tt.devErr).Reset(tt.req)
+			assert.Equal(t, tt.resets, dmgr.resets)
+			if tt.status == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, int32(1), resp.PhyID)
+				return
+			}
+			statusErr, ok := err.(*statusError)
+			if assert.True(t, ok, "error should be a status error") {
+				assert.Equal(t, tt.status, statusErr.status)
+				assert.Contains(t, statusErr.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+// multiChipDeviceManager a mock of 8 chips whose logic id is the physic id, the card type and the number of chips
+// on a card are configurable, the processes run on the busy chip only
+type multiChipDeviceManager struct {
+	resetDeviceManager
+	cardType    string
+	chipsOnCard int32
+	busyLogicID int32
+}
+
+func (d *multiChipDeviceManager) GetDeviceList() (int32, []int32, error) {
+	return 8, []int32{0, 1, 2, 3, 4, 5, 6, 7}, nil
+}
+
+func (d *multiChipDeviceManager) GetPhysicIDFromLogicID(logicID int32) (int32, error) {
+	return logicID, nil
+}
+
+func (d *multiChipDeviceManager) GetCardIDDeviceID(logicID int32) (int32, int32, error) {
+	return logicID / d.chipsOnCard, logicID % d.chipsOnCard, nil
+}
+
+func (d *multiChipDeviceManager) GetCardType(cardID int32) string {
+	return d.cardType
+}
+
+func (d *multiChipDeviceManager) GetDevProcessInfo(logicID int32) (*common.DevProcessInfo, error) {
+	if logicID != d.busyLogicID {
+		return &common.DevProcessInfo{}, nil
+	}
+	return d.resetDeviceManager.GetDevProcessInfo(logicID)
+}
+
+// TestResetterResetChipsTogether test the chips reset together with the requested one are checked as well
+func TestResetterResetChipsTogether(t *testing.T) {
+	usedBy := func(phyID int) container.DevicesInfos {
+		return container.DevicesInfos{"c1": {ID: "c1", Name: "default_pod1_c1",
+			Devices: []container.NpuDevice{{PhyID: phyID, VDevID: container.NoVDevID}}}}
+	}
+	tests := []struct {
+		name        string
+		cardType    string
+		chipsOnCard int32
+		busyLogicID int32
+		devices     container.DevicesInfos
+		status      int
+	}{
+		{name: "should refuse reset when processes are running on other chip of card", cardType: common.Ascend310P,
+			chipsOnCard: 2, busyLogicID: 1, status: http.StatusConflict},
+		{name: "should refuse reset when other chip of card is mounted into container", cardType: common.Ascend310P,
+			chipsOnCard: 2, busyLogicID: -1, devices: usedBy(1), status: http.StatusConflict},
+		{name: "should reset chip when chip of other card is used", cardType: common.Ascend310P, chipsOnCard: 2,
+			busyLogicID: 2, devices: usedBy(3)},
+		{name: "should refuse reset when chip in same hccs group of 910 is used", cardType: common.Ascend910,
+			chipsOnCard: 1, busyLogicID: -1, devices: usedBy(3), status: http.StatusConflict},
+		{name: "should reset chip when chip in other hccs group of 910 is used", cardType: common.Ascend910,
+			chipsOnCard: 1, busyLogicID: 4, devices: usedBy(4)},
+		{name: "should reset chip when other chip of 910B is used", cardType: common.Ascend910B, chipsOnCard: 1,
+			busyLogicID: 1, devices: usedBy(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dmgr := &multiChipDeviceManager{resetDeviceManager: resetDeviceManager{procNum: 1,
+				bootStatus: common.BootStartFinish}, cardType: tt.cardType, chipsOnCard: tt.chipsOnCard,
+				busyLogicID: tt.busyLogicID}
+			_, err := newTestResetter(t, dmgr, tt.devices, nil).Reset(ResetRequest{LogicID: 0})
+			if tt.status == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, dmgr.resets)
+				return
+			}
+			assert.Equal(t, 0, dmgr.resets)
+			statusErr, ok := err.(*statusError)
+			if assert.True(t, ok, "error should be a status error") {
+				assert.Equal(t, tt.status, statusErr.status)
+			}
+		})
+	}
+}
+
+// TestResetterMetrics test the reset count and the last reset result are exported
+func TestResetterMetrics(t *testing.T) {
+	dmgr := &resetDeviceManager{bootStatus: common.BootStartFinish}
+	resetter := newTestResetter(t, dmgr, nil, nil)
+	assert.Equal(t, 0, testutil.CollectAndCount(resetter))
+	_, err := resetter.Reset(ResetRequest{LogicID: 0})
+	assert.NoError(t, err)
+	t.Run("should record failure when chip does not finish booting in time", func(t *testing.T) {
+		dmgr.bootStatus = common.BootStartFinish - 1
+		_, err := resetter.Reset(ResetRequest{LogicID: 0, TimeoutSeconds: 1})
+		statusErr, ok := err.(*statusError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusGatewayTimeout, statusErr.status)
+		}
+	})
+	t.Run("should export reset count and last result of chip", func(t *testing.T) {
+		expected := `
+# HELP npu_chip_last_reset_result the result of the last reset of the npu, 1 means the npu finished booting in time, 0 means failed
+# TYPE npu_chip_last_reset_result gauge
+npu_chip_last_reset_result{id="1"} 0
+# HELP npu_chip_reset_total the number of the resets issued to the npu by the admin api or the reset command
+# TYPE npu_chip_reset_total counter
+npu_chip_reset_total{id="1"} 2
+`
+		assert.NoError(t, testutil.CollectAndCompare(resetter, strings.NewReader(expected),
+			"npu_chip_reset_total", "npu_chip_last_reset_result"))
+	})
+}
+
+// TestResetAPI test the chip reset api is served only when it is enabled
+func TestResetAPI(t *testing.T) {
+	dmgr := &resetDeviceManager{bootStatus: common.BootStartFinish}
+	s, err := NewServer(dmgr, []byte(testToken))
+	assert.NoError(t, err)
+	t.Run("should not serve reset api when it is not enabled", func(t *testing.T) {
+		rec := serve(s, http.MethodPost, ChipResetPath, testToken, `{"logic_id":0}`)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+	t.Run("should reset chip when reset api is enabled", func(t *testing.T) {
+		assert.NoError(t, s.EnableReset(newTestResetter(t, dmgr, nil, nil)))
+		rec := serve(s, http.MethodPost, ChipResetPath, testToken, `{"logic_id":0}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 1, dmgr.resets)
+		rec = serve(s, http.MethodPost, ChipResetPath, "", `{"logic_id":0}`)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, 1, dmgr.resets)
+	})
+}
@@ -0,0 +1,163 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package admin the authenticated admin http api of npu-exporter, which is served on a separate listener
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/common-utils/limiter"
+	"huawei.com/npu-exporter/v5/common-utils/utils"
+	"huawei.com/npu-exporter/v5/devmanager"
+)
+
+const (
+	// MinTokenLen the min length of the admin token
+	MinTokenLen = 16
+	maxTokenLen = 256
+
+	bearerPrefix   = "Bearer "
+	maxBodyBytes   = 4 * 1024
+	timeout        = 10 * time.Second
+	maxHeaderBytes = 1024
+	maxConn        = 8
+	maxConnPerIP   = 2
+)
+
+// Config the config of the admin api
+type Config struct {
+	// IP the listen ip
+	IP string
+	// Port the listen port
+	Port int
+	// TokenFile the file of the bearer token which the requests are authenticated with
+	TokenFile string
+	// EnableReset serves the chip reset api
+	EnableReset bool
+}
+
+// Server the admin api server
+type Server struct {
+	token        []byte
+	mux          *http.ServeMux
+	handler      http.Handler
+	writeTimeout time.Duration
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// LoadToken reads the admin token from the file, the blank characters around it are trimmed
+func LoadToken(tokenFile string) ([]byte, error) {
+	content, err := utils.ReadLimitBytes(tokenFile, maxTokenLen+1)
+	if err != nil {
+		return nil, fmt.Errorf("read admin token file failed: %v", err)
+	}
+	token := []byte(strings.TrimSpace(string(content)))
+	if len(token) < MinTokenLen || len(token) > maxTokenLen {
+		return nil, fmt.Errorf("the length of admin token should be in [%d, %d]", MinTokenLen, maxTokenLen)
+	}
+	return token, nil
+}
+
+// NewServer returns the admin api server which authenticates the requests with the token
+func NewServer(dmgr devmanager.DeviceInterface, token []byte) (*Server, error) {
+	if dmgr == nil {
+		return nil, errors.New("device manager is nil")
+	}
+	if len(token) < MinTokenLen {
+		return nil, errors.New("admin token is too short")
+	}
+	s := &Server{token: token, mux: http.NewServeMux(), writeTimeout: timeout}
+	(&vnpuHandler{dmgr: dmgr}).register(s.mux)
+	s.handler = s.authenticate(s.mux)
+	return s, nil
+}
+
+// EnableReset serves the chip reset api, which is disabled by default, it should be called before ListenAndServe
+func (s *Server) EnableReset(resetter *Resetter) error {
+	if resetter == nil {
+		return errors.New("resetter is nil")
+	}
+	(&resetHandler{resetter: resetter}).register(s.mux)
+	// the response is written after the chip finishes booting
+	s.writeTimeout = timeout + MaxBootTimeout
+	return nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// authenticate rejects the requests without the bearer token, the rejection is recorded in the operate log
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, bearerPrefix) ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, bearerPrefix)), s.token) != 1 {
+			hwlog.OpLog.Warnf("%s %s %s: unauthorized", r.RemoteAddr, r.Method, r.URL.Path)
+			writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ListenAndServe serves the admin api on the ip and port of the config until the server fails
+func (s *Server) ListenAndServe(conf Config) error {
+	server := &http.Server{
+		Addr:           net.JoinHostPort(conf.IP, strconv.Itoa(conf.Port)),
+		Handler:        s,
+		ReadTimeout:    timeout,
+		WriteTimeout:   s.writeTimeout,
+		MaxHeaderBytes: maxHeaderBytes,
+		ErrorLog:       log.New(&hwlog.SelfLogWriter{}, "", log.Lshortfile),
+	}
+	ln, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return fmt.Errorf("admin api listen on %s failed: %v", server.Addr, err)
+	}
+	limitLs, err := limiter.LimitListener(ln, maxConn, maxConnPerIP, limiter.DefaultCacheSize)
+	if err != nil {
+		return err
+	}
+	hwlog.RunLog.Infof("admin api listen on: %s", server.Addr)
+	return server.Serve(limitLs)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		hwlog.RunLog.Errorf("write admin api response failed: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,310 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package admin the authenticated admin http api of npu-exporter, which is served on a separate listener
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+const (
+	// VNPUChipsPath lists the total and free resources and the vNPUs of each chip
+	VNPUChipsPath = "/admin/v1/vnpu/chips"
+	// VNPUCreatePath creates a vNPU from a template
+	VNPUCreatePath = "/admin/v1/vnpu/create"
+	// VNPUDestroyPath destroys a vNPU
+	VNPUDestroyPath = "/admin/v1/vnpu/destroy"
+
+	opCreate  = "create vnpu"
+	opDestroy = "destroy vnpu"
+)
+
+// VNPU the vNPU on a chip
+type VNPU struct {
+	VDevID   uint32  `json:"vdev_id"`
+	Template string  `json:"template"`
+	AiCore   float32 `json:"aicore"`
+}
+
+// ChipResource the total and free resources and the vNPUs of a chip
+type ChipResource struct {
+	LogicID     int32   `json:"logic_id"`
+	PhyID       int32   `json:"phy_id"`
+	TotalAiCore float32 `json:"total_aicore"`
+	FreeAiCore  float32 `json:"free_aicore"`
+	VNPUs       []VNPU  `json:"vnpus"`
+}
+
+// CreateRequest the request to create a vNPU, the vdevice id is allocated by the driver when VDevID is 0
+type CreateRequest struct {
+	LogicID  int32  `json:"logic_id"`
+	Template string `json:"template"`
+	VDevID   uint32 `json:"vdev_id,omitempty"`
+	// DryRun only validates the request against the template and the free resource of the chip
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// DestroyRequest the request to destroy a vNPU
+type DestroyRequest struct {
+	LogicID int32  `json:"logic_id"`
+	VDevID  uint32 `json:"vdev_id"`
+	// DryRun only validates the vNPU exists on the chip
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// OperateResponse the result of creating or destroying a vNPU
+type OperateResponse struct {
+	VDevID uint32 `json:"vdev_id,omitempty"`
+	DryRun bool   `json:"dry_run,omitempty"`
+}
+
+// statusError an error with the http status code
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func newStatusError(status int, format string, args ...interface{}) *statusError {
+	return &statusError{status: status, err: fmt.Errorf(format, args...)}
+}
+
+type vnpuHandler struct {
+	dmgr devmanager.DeviceInterface
+	// lock serializes the creation and destruction, so that the validation is not invalidated by another request
+	lock sync.Mutex
+}
+
+func (h *vnpuHandler) register(mux *http.ServeMux) {
+	mux.HandleFunc(VNPUChipsPath, h.handleChips)
+	mux.HandleFunc(VNPUCreatePath, h.handleCreate)
+	mux.HandleFunc(VNPUDestroyPath, h.handleDestroy)
+}
+
+func (h *vnpuHandler) handleChips(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+	chips, err := h.listChips()
+	if err != nil {
+		writeStatusError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, chips)
+}
+
+func (h *vnpuHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if err := decodeRequest(r, &req); err != nil {
+		auditf(r, opCreate, "", err)
+		writeStatusError(w, err)
+		return
+	}
+	detail := fmt.Sprintf("logic_id=%d template=%s vdev_id=%d dry_run=%v", req.LogicID, req.Template, req.VDevID,
+		req.DryRun)
+	resp, err := h.create(req)
+	auditf(r, opCreate, detail, err)
+	if err != nil {
+		writeStatusError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *vnpuHandler) handleDestroy(w http.ResponseWriter, r *http.Request) {
+	var req DestroyRequest
+	if err := decodeRequest(r, &req); err != nil {
+		auditf(r, opDestroy, "", err)
+		writeStatusError(w, err)
+		return
+	}
+	detail := fmt.Sprintf("logic_id=%d vdev_id=%d dry_run=%v", req.LogicID, req.VDevID, req.DryRun)
+	resp, err := h.destroy(req)
+	auditf(r, opDestroy, detail, err)
+	if err != nil {
+		writeStatusError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *vnpuHandler) listChips() ([]ChipResource, error) {
+	_, logicIDs, err := h.dmgr.GetDeviceList()
+	if err != nil {
+		return nil, newStatusError(http.StatusInternalServerError, "get npu list failed: %v", err)
+	}
+	chips := make([]ChipResource, 0, len(logicIDs))
+	for _, logicID := range logicIDs {
+		chip, err := h.getChipResource(logicID)
+		if err != nil {
+			return nil, err
+		}
+		chips = append(chips, chip)
+	}
+	return chips, nil
+}
+
+func (h *vnpuHandler) getChipResource(logicID int32) (ChipResource, error) {
+	phyID, err := h.dmgr.GetPhysicIDFromLogicID(logicID)
+	if err != nil {
+		return ChipResource{}, newStatusError(http.StatusInternalServerError,
+			"get physic id of npu %d failed: %v", logicID, err)
+	}
+	info, err := h.dmgr.GetVirtualDeviceInfo(logicID)
+	if err != nil {
+		return ChipResource{}, newStatusError(http.StatusInternalServerError,
+			"get virtual device info of npu %d failed: %v", logicID, err)
+	}
+	chip := ChipResource{LogicID: logicID, PhyID: phyID, TotalAiCore: info.TotalResource.Computing.Aic,
+		FreeAiCore: info.FreeResource.Computing.Aic, VNPUs: make([]VNPU, 0, len(info.VDevInfo))}
+	for _, vDev := range info.VDevInfo {
+		chip.VNPUs = append(chip.VNPUs, VNPU{VDevID: vDev.VDevID, Template: vDev.QueryInfo.Name,
+			AiCore: vDev.QueryInfo.Computing.Aic})
+	}
+	return chip, nil
+}
+
+// checkLogicID checks the chip is managed by the device manager
+func checkLogicID(dmgr devmanager.DeviceInterface, logicID int32) error {
+	_, logicIDs, err := dmgr.GetDeviceList()
+	if err != nil {
+		return newStatusError(http.StatusInternalServerError, "get npu list failed: %v", err)
+	}
+	for _, id := range logicIDs {
+		if id == logicID {
+			return nil
+		}
+	}
+	return newStatusError(http.StatusNotFound, "npu %d is not found", logicID)
+}
+
+// cardTypeOf return the device type of the card which the chip belongs to, the templates differ between the types
+func (h *vnpuHandler) cardTypeOf(logicID int32) string {
+	cardID, _, err := h.dmgr.GetCardIDDeviceID(logicID)
+	if err != nil {
+		return h.dmgr.GetDevType()
+	}
+	return h.dmgr.GetCardType(cardID)
+}
+
+func (h *vnpuHandler) create(req CreateRequest) (OperateResponse, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if req.VDevID != 0 && !common.IsValidVDevID(req.VDevID) {
+		return OperateResponse{}, newStatusError(http.StatusBadRequest, "vdev_id should be 0 or in [%d, %d)",
+			common.MinVDevID, common.MaxVDevID)
+	}
+	if err := checkLogicID(h.dmgr, req.LogicID); err != nil {
+		return OperateResponse{}, err
+	}
+	need, err := common.GetTemplateResource(h.cardTypeOf(req.LogicID), req.Template)
+	if err != nil {
+		return OperateResponse{}, &statusError{status: http.StatusBadRequest, err: err}
+	}
+	info, err := h.dmgr.GetVirtualDeviceInfo(req.LogicID)
+	if err != nil {
+		return OperateResponse{}, newStatusError(http.StatusInternalServerError,
+			"get virtual device info of npu %d failed: %v", req.LogicID, err)
+	}
+	// the media resources are not encoded in the template name, they are checked by the driver
+	if common.TemplateCapacity(need, info) < 1 {
+		free := info.FreeResource.Computing
+		return OperateResponse{}, newStatusError(http.StatusConflict, "template %s needs %+v, but npu %d has "+
+			"%v aicore, %v aicpu and %v MB memory free", req.Template, need, req.LogicID, free.Aic, free.DeviceAicpu,
+			free.MemorySize)
+	}
+	if req.DryRun {
+		return OperateResponse{DryRun: true}, nil
+	}
+	out, err := h.dmgr.CreateVirtualDevice(req.LogicID, common.CgoCreateVDevRes{VDevID: req.VDevID,
+		TemplateName: req.Template})
+	if err != nil {
+		return OperateResponse{}, newStatusError(http.StatusInternalServerError,
+			"create vnpu on npu %d failed: %v", req.LogicID, err)
+	}
+	return OperateResponse{VDevID: out.VDevID}, nil
+}
+
+func (h *vnpuHandler) destroy(req DestroyRequest) (OperateResponse, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if err := checkLogicID(h.dmgr, req.LogicID); err != nil {
+		return OperateResponse{}, err
+	}
+	info, err := h.dmgr.GetVirtualDeviceInfo(req.LogicID)
+	if err != nil {
+		return OperateResponse{}, newStatusError(http.StatusInternalServerError,
+			"get virtual device info of npu %d failed: %v", req.LogicID, err)
+	}
+	found := false
+	for _, vDev := range info.VDevInfo {
+		if vDev.VDevID == req.VDevID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return OperateResponse{}, newStatusError(http.StatusNotFound, "vnpu %d is not found on npu %d",
+			req.VDevID, req.LogicID)
+	}
+	if req.DryRun {
+		return OperateResponse{VDevID: req.VDevID, DryRun: true}, nil
+	}
+	if err := h.dmgr.DestroyVirtualDevice(req.LogicID, req.VDevID); err != nil {
+		return OperateResponse{}, newStatusError(http.StatusInternalServerError,
+			"destroy vnpu %d on npu %d failed: %v", req.VDevID, req.LogicID, err)
+	}
+	return OperateResponse{VDevID: req.VDevID}, nil
+}
+
+func decodeRequest(r *http.Request, req interface{}) error {
+	if r.Method != http.MethodPost {
+		return newStatusError(http.StatusMethodNotAllowed, "method %s is not allowed", r.Method)
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(req); err != nil {
+		return newStatusError(http.StatusBadRequest, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+// auditf records the operation and its result in the operate log
+func auditf(r *http.Request, op, detail string, err error) {
+	if err != nil {
+		hwlog.OpLog.Warnf("%s %s %s: %s failed, %s: %v", r.RemoteAddr, r.Method, r.URL.Path, op, detail, err)
+		return
+	}
+	hwlog.OpLog.Infof("%s %s %s: %s succeeded, %s", r.RemoteAddr, r.Method, r.URL.Path, op, detail)
+}
+
+func writeStatusError(w http.ResponseWriter, err error) {
+	if statusErr, ok := err.(*statusError); ok {
+		writeError(w, statusErr.status, statusErr.err)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err)
+}
@@ -0,0 +1,184 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package admin the authenticated admin http api of npu-exporter, which is served on a separate listener
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+const testToken = "0123456789abcdef"
+
+func init() {
+	config := hwlog.LogConfig{
+		OnlyToStdout: true,
+	}
+	hwlog.InitRunLogger(&config, nil)
+	hwlog.InitOperateLogger(&config, nil)
+}
+
+// vnpuDeviceManager a mock of the device manager which keeps the vNPUs of the chip 0 with 8 aicore
+type vnpuDeviceManager struct {
+	devmanager.DeviceManagerMock
+	vDevs     []common.CgoVDevQueryStru
+	destroyed []uint32
+}
+
+func (d *vnpuDeviceManager) GetVirtualDeviceInfo(logicID int32) (common.VirtualDevInfo, error) {
+	const totalAiCore = 8
+	free := float32(totalAiCore)
+	for _, vDev := range d.vDevs {
+		free -= vDev.QueryInfo.Computing.Aic
+	}
+	return common.VirtualDevInfo{
+		TotalResource: common.CgoSocTotalResource{Computing: common.CgoComputingResource{Aic: totalAiCore}},
+		FreeResource:  common.CgoSocFreeResource{Computing: common.CgoComputingResource{Aic: free}},
+		VDevInfo:      d.vDevs,
+	}, nil
+}
+
+func (d *vnpuDeviceManager) CreateVirtualDevice(logicID int32, vDevInfo common.CgoCreateVDevRes) (common.
+	CgoCreateVDevOut, error) {
+	aiCore, err := common.GetTemplateAiCore(d.GetDevType(), vDevInfo.TemplateName)
+	if err != nil {
+		return common.CgoCreateVDevOut{}, err
+	}
+	vDevID := uint32(common.MinVDevID + len(d.vDevs))
+	d.vDevs = append(d.vDevs, common.CgoVDevQueryStru{VDevID: vDevID, QueryInfo: common.CgoVDevQueryInfo{
+		Name: vDevInfo.TemplateName, Computing: common.CgoComputingResource{Aic: aiCore}}})
+	return common.CgoCreateVDevOut{VDevID: vDevID}, nil
+}
+
+func (d *vnpuDeviceManager) DestroyVirtualDevice(logicID int32, vDevID uint32) error {
+	d.destroyed = append(d.destroyed, vDevID)
+	return nil
+}
+
+// vnpu310PDeviceManager a mock of the device manager whose chip 0 is Ascend310P with 7 aicpu and none is free
+type vnpu310PDeviceManager struct {
+	vnpuDeviceManager
+}
+
+func (d *vnpu310PDeviceManager) GetCardType(int32) string {
+	return common.Ascend310P
+}
+
+func (d *vnpu310PDeviceManager) GetVirtualDeviceInfo(logicID int32) (common.VirtualDevInfo, error) {
+	const totalAiCPU = 7
+	info, err := d.vnpuDeviceManager.GetVirtualDeviceInfo(logicID)
+	info.TotalResource.Computing.DeviceAicpu = totalAiCPU
+	return info, err
+}
+
+func serve(s *Server, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", bearerPrefix+token)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestVNPUAPI test the vNPU lifecycle management api with the mock device manager
+func TestVNPUAPI(t *testing.T) {
+	dmgr := &vnpuDeviceManager{}
+	s, err := NewServer(dmgr, []byte(testToken))
+	assert.NoError(t, err)
+	t.Run("should reject request when token is wrong", func(t *testing.T) {
+		rec := serve(s, http.MethodGet, VNPUChipsPath, "wrong-token-0123456", "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		rec = serve(s, http.MethodGet, VNPUChipsPath, "", "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+	t.Run("should not create vnpu when dry run", func(t *testing.T) {
+		rec := serve(s, http.MethodPost, VNPUCreatePath, testToken,
+			`{"logic_id":0,"template":"vir04","dry_run":true}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, dmgr.vDevs)
+	})
+	t.Run("should create vnpu when free resource is enough", func(t *testing.T) {
+		rec := serve(s, http.MethodPost, VNPUCreatePath, testToken, `{"logic_id":0,"template":"vir04"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		resp := OperateResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, uint32(common.MinVDevID), resp.VDevID)
+	})
+	t.Run("should reject creation when request is invalid", func(t *testing.T) {
+		tests := []struct {
+			body   string
+			status int
+		}{
+			{body: `{"logic_id":0,"template":"vir08","dry_run":true}`, status: http.StatusConflict},
+			{body: `{"logic_id":0,"template":"vir03"}`, status: http.StatusBadRequest},
+			{body: `{"logic_id":0,"template":"vir02","vdev_id":1}`, status: http.StatusBadRequest},
+			{body: `{"logic_id":0,"template":"vir02","vdev_id":1124}`, status: http.StatusBadRequest},
+			{body: `{"logic_id":3,"template":"vir02"}`, status: http.StatusNotFound},
+			{body: `{"logic_id":0,"unknown":1}`, status: http.StatusBadRequest},
+		}
+		for _, tt := range tests {
+			rec := serve(s, http.MethodPost, VNPUCreatePath, testToken, tt.body)
+			assert.Equal(t, tt.status, rec.Code, tt.body)
+		}
+		assert.Len(t, dmgr.vDevs, 1)
+	})
+	t.Run("should list total and free resource of chips", func(t *testing.T) {
+		rec := serve(s, http.MethodGet, VNPUChipsPath, testToken, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var chips []ChipResource
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &chips))
+		assert.Equal(t, []ChipResource{{LogicID: 0, PhyID: 1, TotalAiCore: 8, FreeAiCore: 4,
+			VNPUs: []VNPU{{VDevID: common.MinVDevID, Template: "vir04", AiCore: 4}}}}, chips)
+	})
+	t.Run("should destroy vnpu only when it exists and not dry run", func(t *testing.T) {
+		rec := serve(s, http.MethodPost, VNPUDestroyPath, testToken, `{"logic_id":0,"vdev_id":101}`)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		rec = serve(s, http.MethodPost, VNPUDestroyPath, testToken, `{"logic_id":0,"vdev_id":100,"dry_run":true}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, dmgr.destroyed)
+		rec = serve(s, http.MethodPost, VNPUDestroyPath, testToken, `{"logic_id":0,"vdev_id":100}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []uint32{common.MinVDevID}, dmgr.destroyed)
+	})
+	t.Run("should reject request when method is not allowed", func(t *testing.T) {
+		rec := serve(s, http.MethodGet, VNPUCreatePath, testToken, "")
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+// TestVNPUCreateCheckAllResources test the creation is refused when any resource of the template is not free
+func TestVNPUCreateCheckAllResources(t *testing.T) {
+	s, err := NewServer(&vnpu310PDeviceManager{}, []byte(testToken))
+	assert.NoError(t, err)
+	t.Run("should reject creation when free aicpu is not enough", func(t *testing.T) {
+		rec := serve(s, http.MethodPost, VNPUCreatePath, testToken,
+			`{"logic_id":0,"template":"vir02_1c","dry_run":true}`)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+	t.Run("should accept creation when template does not need aicpu", func(t *testing.T) {
+		rec := serve(s, http.MethodPost, VNPUCreatePath, testToken, `{"logic_id":0,"template":"vir02","dry_run":true}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
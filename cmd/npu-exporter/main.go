@@ -21,9 +21,11 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"math"
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -33,10 +35,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"huawei.com/npu-exporter/v5/admin"
 	"huawei.com/npu-exporter/v5/collector"
 	"huawei.com/npu-exporter/v5/collector/container"
 	"huawei.com/npu-exporter/v5/common-utils/hwlog"
 	"huawei.com/npu-exporter/v5/common-utils/limiter"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/hccn"
 	_ "huawei.com/npu-exporter/v5/plugins/inputs/npu"
 	"huawei.com/npu-exporter/v5/versions"
 )
@@ -56,6 +61,18 @@ var (
 	limitTotalConn int
 	cacheSize      int
 	pollInterval   time.Duration
+	filterOpts     container.FilterOpts
+	cntFilter      *container.ContainerFilter
+	recordFile     string
+	simulateOpts   collector.SimulateOpts
+	hccnToolPath   string
+	adminConfig    admin.Config
+	adminOpLogFile string
+	// inventoryTime the interval of refreshing the chip inventory, unit is second
+	inventoryTime int
+	// suppressUnbooted only exports the boot status of the chip which is booting
+	suppressUnbooted bool
+	processOpts      collector.ProcessOpts
 )
 
 const (
@@ -67,9 +84,12 @@ const (
 	oneMinute               = 60
 	defaultConcurrency      = 5
 	defaultLogFile          = "/var/log/mindx-dl/npu-exporter/npu-exporter.log"
+	defaultOpLogFile        = "/var/log/mindx-dl/npu-exporter/npu-exporter-operate.log"
+	defaultAdminIP          = "127.0.0.1"
 	containerModeDocker     = "docker"
 	containerModeContainerd = "containerd"
 	containerModeIsula      = "isula"
+	containerModeDockerAPI  = "docker-api"
 	unixPre                 = "unix://"
 	timeout                 = 10
 	maxHeaderBytes          = 1024
@@ -78,6 +98,12 @@ const (
 	maxIPConnLimit    = 128
 	maxConcurrency    = 512
 	defaultConnection = 20
+	oneDay            = 24 * 60 * 60
+	maxProcessTopN    = 1024
+	// resetCmd the sub command which resets a chip on the host
+	resetCmd = "reset"
+	// containerParseTimeout the time to wait for the devices of containers before resetting a chip
+	containerParseTimeout = 10 * time.Second
 )
 
 const (
@@ -93,6 +119,9 @@ var hwLogConfig = &hwlog.LogConfig{LogFileName: defaultLogFile, ExpiredTime: hwl
 	CacheSize: hwlog.DefaultCacheSize, MaxLineLength: maxLogLineLength}
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == resetCmd {
+		os.Exit(resetProcess(os.Args[2:]))
+	}
 	flag.Parse()
 	if version {
 		fmt.Printf("NPU-exporter version: %s \n", versions.BuildVersion)
@@ -150,7 +179,7 @@ func newServerAndListener(conf *limiter.HandlerConfig) (*http.Server, net.Listen
 }
 
 func readCntMonitoringFlags() container.CntNpuMonitorOpts {
-	opts := container.CntNpuMonitorOpts{UserBackUp: true}
+	opts := container.CntNpuMonitorOpts{UserBackUp: true, Filter: cntFilter}
 	switch containerMode {
 	case containerModeDocker:
 		opts.EndpointType = container.EndpointTypeDockerd
@@ -164,6 +193,10 @@ func readCntMonitoringFlags() container.CntNpuMonitorOpts {
 		opts.EndpointType = container.EndpointTypeIsula
 		opts.OciEndpoint = container.DefaultIsuladAddr
 		opts.CriEndpoint = container.DefaultIsuladAddr
+	case containerModeDockerAPI:
+		opts.EndpointType = container.EndpointTypeDockerAPI
+		opts.OciEndpoint = container.DefaultDockerEngineAddr
+		opts.CriEndpoint = container.DefaultDockerEngineAddr
 	default:
 		hwlog.RunLog.Error("invalid container mode setting,reset to docker")
 		opts.EndpointType = container.EndpointTypeDockerd
@@ -182,10 +215,8 @@ func readCntMonitoringFlags() container.CntNpuMonitorOpts {
 }
 
 func regPrometheus(opts container.CntNpuMonitorOpts) (*prometheus.Registry, error) {
-	deviceParser := container.MakeDevicesParser(opts)
 	reg := prometheus.NewRegistry()
-	c, err := collector.NewNpuCollector(context.Background(), cacheTime, time.Duration(updateTime)*time.Second,
-		deviceParser)
+	c, err := newCollector(opts)
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +224,30 @@ func regPrometheus(opts container.CntNpuMonitorOpts) (*prometheus.Registry, erro
 	return reg, nil
 }
 
+func newCollector(opts container.CntNpuMonitorOpts) (prometheus.Collector, error) {
+	collectorOpts := collector.NpuCollectorOpts{
+		SuppressUnbooted:    suppressUnbooted,
+		InventoryUpdateTime: time.Duration(inventoryTime) * time.Second,
+		Process:             processOpts,
+	}
+	if simulateOpts.File != "" {
+		hwlog.RunLog.Warnf("simulate mode, the metrics are replayed from %s", simulateOpts.File)
+		simulateOpts.CacheTime = cacheTime
+		simulateOpts.UpdateTime = time.Duration(updateTime) * time.Second
+		simulateOpts.Collector = collectorOpts
+		return collector.NewSimulateCollector(context.Background(), simulateOpts)
+	}
+	hccn.SetTool(hccn.NewTool(context.Background(), hccn.NewCmdRunner(hccnToolPath, hccn.DefaultTimeout)))
+	deviceParser := container.MakeDevicesParser(opts)
+	if recordFile != "" {
+		hwlog.RunLog.Infof("record mode, the snapshots are recorded into %s", recordFile)
+		return collector.NewNpuCollectorWithRecord(context.Background(), cacheTime,
+			time.Duration(updateTime)*time.Second, deviceParser, collectorOpts, recordFile)
+	}
+	return collector.NewNpuCollector(context.Background(), cacheTime, time.Duration(updateTime)*time.Second,
+		deviceParser, collectorOpts)
+}
+
 func paramValidInPrometheus() error {
 	if port < portLeft || port > portRight {
 		return errors.New("the port is invalid")
@@ -206,9 +261,31 @@ func paramValidInPrometheus() error {
 	if updateTime > oneMinute || updateTime < 1 {
 		return errors.New("the updateTime is invalid")
 	}
+	if inventoryTime > oneDay || inventoryTime < oneMinute {
+		return errors.New("the inventoryUpdateTime is invalid")
+	}
 	if err := containerSockCheck(); err != nil {
 		return err
 	}
+	if err := simulateParamCheck(); err != nil {
+		return err
+	}
+	if processOpts.TopN < 0 || processOpts.TopN > maxProcessTopN {
+		return errors.New("the processTopN is invalid")
+	}
+	if !filepath.IsAbs(processOpts.ProcRoot) {
+		return errors.New("procRoot should be an absolute path")
+	}
+	if hccnToolPath != "" && !filepath.IsAbs(hccnToolPath) {
+		return errors.New("hccnToolPath should be an absolute path")
+	}
+	if err := adminParamCheck(); err != nil {
+		return err
+	}
+	var err error
+	if cntFilter, err = container.NewContainerFilter(filterOpts); err != nil {
+		return err
+	}
 	reg := regexp.MustCompile(limiter.IPReqLimitReg)
 	if !reg.Match([]byte(limitIPReq)) {
 		return errors.New("limitIPReq format error")
@@ -232,6 +309,163 @@ func paramValidInPrometheus() error {
 	return nil
 }
 
+func simulateParamCheck() error {
+	if simulateOpts.File != "" && recordFile != "" {
+		return errors.New("simulate and record can not be used together")
+	}
+	if simulateOpts.Speed <= 0 || simulateOpts.Speed > collector.MaxSimulateSpeed {
+		return fmt.Errorf("simulateSpeed should be in (0, %d]", collector.MaxSimulateSpeed)
+	}
+	return nil
+}
+
+func adminParamCheck() error {
+	if adminConfig.Port == 0 {
+		return nil
+	}
+	if adminConfig.Port < portLeft || adminConfig.Port > portRight || adminConfig.Port == port {
+		return errors.New("the adminPort is invalid or same as the port")
+	}
+	parsedIP := net.ParseIP(adminConfig.IP)
+	if parsedIP == nil {
+		return errors.New("the adminIP is invalid")
+	}
+	adminConfig.IP = parsedIP.String()
+	if adminConfig.TokenFile == "" {
+		return errors.New("adminTokenFile is required when the admin api is enabled")
+	}
+	if simulateOpts.File != "" {
+		return errors.New("the admin api can not be used in simulate mode")
+	}
+	return nil
+}
+
+// startAdminServer serves the admin api on a separate listener when it is enabled by -adminPort
+func startAdminServer(reg *prometheus.Registry, opts container.CntNpuMonitorOpts) error {
+	if adminConfig.Port == 0 {
+		return nil
+	}
+	if err := initOpLogger(); err != nil {
+		return err
+	}
+	token, err := admin.LoadToken(adminConfig.TokenFile)
+	if err != nil {
+		return err
+	}
+	dmgr, err := devmanager.AutoInit("")
+	if err != nil {
+		return fmt.Errorf("init dev manager for admin api failed: %v", err)
+	}
+	server, err := admin.NewServer(dmgr, token)
+	if err != nil {
+		return err
+	}
+	if adminConfig.EnableReset {
+		resetter, err := newResetter(dmgr, opts)
+		if err != nil {
+			return fmt.Errorf("init chip resetter failed: %v", err)
+		}
+		if err := server.EnableReset(resetter); err != nil {
+			return err
+		}
+		reg.MustRegister(resetter)
+		hwlog.RunLog.Warn("enable chip reset in admin api")
+	}
+	hwlog.RunLog.Warn("enable admin api, which can create and destroy vnpu")
+	go func() {
+		if err := server.ListenAndServe(adminConfig); err != nil {
+			hwlog.RunLog.Errorf("admin api server error: %v and stopped", err)
+		}
+	}()
+	return nil
+}
+
+func initOpLogger() error {
+	opLogConfig := *hwLogConfig
+	opLogConfig.LogFileName = adminOpLogFile
+	if err := hwlog.InitOperateLogger(&opLogConfig, context.Background()); err != nil {
+		return fmt.Errorf("init operate logger failed: %v", err)
+	}
+	return nil
+}
+
+// newResetter returns the resetter which checks the devices of all the containers, the container filter is not
+// applied so that a chip used by an unmonitored container is not reset
+func newResetter(dmgr devmanager.DeviceInterface, opts container.CntNpuMonitorOpts) (*admin.Resetter, error) {
+	opts.Filter = nil
+	parser := container.MakeDevicesParser(opts)
+	parser.IDConverter = dmgr
+	if err := parser.Init(); err != nil {
+		return nil, err
+	}
+	return admin.NewResetter(dmgr, func() (container.DevicesInfos, error) {
+		return parser.Parse(containerParseTimeout)
+	})
+}
+
+// resetProcess resets a chip on the host with the same checks as the admin api, it returns the exit code
+func resetProcess(args []string) int {
+	const exitUsage = 2
+	var logicID int
+	req := admin.ResetRequest{}
+	flagSet := flag.NewFlagSet(resetCmd, flag.ContinueOnError)
+	flagSet.IntVar(&logicID, "logicID", -1, "The logic id of the chip to reset")
+	flagSet.IntVar(&req.TimeoutSeconds, "timeout", int(admin.DefaultBootTimeout/time.Second),
+		"The seconds to wait for the chip to finish booting after reset")
+	flagSet.BoolVar(&req.DryRun, "dryRun", false,
+		"If true, only check the chip is not used by any process or container")
+	flagSet.StringVar(&containerMode, "containerMode", containerModeDocker,
+		"The container runtime, which is checked for the containers using the chip, "+
+			"'docker', 'containerd', 'isula' or 'docker-api'")
+	flagSet.StringVar(&containerd, "containerd", "", "The endpoint of containerd")
+	flagSet.StringVar(&endpoint, "endpoint", "", "The endpoint of the CRI server")
+	flagSet.StringVar(&hwLogConfig.LogFileName, "logFile", defaultLogFile, "Log file path")
+	flagSet.StringVar(&adminOpLogFile, "opLogFile", defaultOpLogFile, "The operate log file which audits the reset")
+	if err := flagSet.Parse(args); err != nil {
+		return exitUsage
+	}
+	if logicID < 0 || logicID > math.MaxInt32 {
+		fmt.Fprintln(os.Stderr, "the logicID of the chip to reset is required")
+		return exitUsage
+	}
+	req.LogicID = int32(logicID)
+	if err := containerSockCheck(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+	if err := initHwLogger(); err != nil {
+		return 1
+	}
+	if err := initOpLogger(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	dmgr, err := devmanager.AutoInit("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "init dev manager failed: %v\n", err)
+		return 1
+	}
+	resetter, err := newResetter(dmgr, readCntMonitoringFlags())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "init chip resetter failed: %v\n", err)
+		return 1
+	}
+	detail := fmt.Sprintf("logic_id=%d timeout_seconds=%d dry_run=%v", req.LogicID, req.TimeoutSeconds, req.DryRun)
+	resp, err := resetter.Reset(req)
+	if err != nil {
+		hwlog.OpLog.Warnf("uid %d %s: reset chip failed, %s: %v", os.Getuid(), resetCmd, detail, err)
+		fmt.Fprintf(os.Stderr, "reset npu %d failed: %v\n", req.LogicID, err)
+		return 1
+	}
+	hwlog.OpLog.Infof("uid %d %s: reset chip succeeded, %s", os.Getuid(), resetCmd, detail)
+	if resp.DryRun {
+		fmt.Printf("npu %d (physic id %d) is not in use and can be reset\n", resp.LogicID, resp.PhyID)
+		return 0
+	}
+	fmt.Printf("npu %d (physic id %d) is reset and finishes booting\n", resp.LogicID, resp.PhyID)
+	return 0
+}
+
 func containerSockCheck() error {
 	if endpoint != "" && !strings.Contains(endpoint, ".sock") {
 		return errors.New("endpoint file is not sock address")
@@ -258,11 +492,60 @@ func init() {
 	flag.BoolVar(&version, "version", false,
 		"If true,query the version of the program (default false)")
 	flag.StringVar(&containerMode, "containerMode", containerModeDocker,
-		"Set 'docker' for monitoring docker containers or 'containerd' for CRI & containerd")
+		"Set 'docker' for monitoring docker containers or 'containerd' for CRI & containerd, "+
+			"'isula' for isulad or 'docker-api' for Docker Engine API without CRI")
 	flag.StringVar(&containerd, "containerd", "",
 		"The endpoint of containerd used for listening containers' events")
 	flag.StringVar(&endpoint, "endpoint", "",
 		"The endpoint of the CRI  server to which will be connected")
+	flag.StringVar(&filterOpts.IncludeNamespaces, "includeNamespaces", "",
+		"Comma separated k8s namespaces, only the containers in these namespaces are monitored")
+	flag.StringVar(&filterOpts.ExcludeNamespaces, "excludeNamespaces", "",
+		"Comma separated k8s namespaces, the containers in these namespaces are not monitored")
+	flag.StringVar(&filterOpts.IncludePodRegex, "includePodRegex", "",
+		"Only the containers whose k8s pod name matches the regex are monitored")
+	flag.StringVar(&filterOpts.ExcludePodRegex, "excludePodRegex", "",
+		"The containers whose k8s pod name matches the regex are not monitored")
+	flag.StringVar(&filterOpts.IncludeLabels, "includeLabels", "",
+		"Comma separated container labels in the form of key or key=value, only the containers with all of "+
+			"these labels are monitored")
+	flag.StringVar(&filterOpts.ExcludeLabels, "excludeLabels", "",
+		"Comma separated container labels in the form of key or key=value, the containers with any of these "+
+			"labels are not monitored")
+	flag.StringVar(&recordFile, "record", "",
+		"The file to record the npu, network and container information in every update cycle, "+
+			"which can be replayed by -simulate")
+	flag.StringVar(&simulateOpts.File, "simulate", "",
+		"The file recorded by -record, the metrics are replayed from it in a loop in place of dcmi, "+
+			"hccn_tool and container runtime")
+	flag.Float64Var(&simulateOpts.Speed, "simulateSpeed", 1,
+		"The time scaling of -simulate, 2 means the recorded timeline is replayed twice as fast, range (0, 1000]")
+	flag.StringVar(&hccnToolPath, "hccnToolPath", "",
+		"The path of hccn_tool which gets the npu network information, the default is tools/hccn_tool under "+
+			"the environment variable ASCEND_DRIVER_PATH or /usr/local/Ascend/driver")
+	flag.IntVar(&adminConfig.Port, "adminPort", 0,
+		"The port of the admin api which manages the vnpu, range[1025-40000], 0 means the admin api is disabled")
+	flag.StringVar(&adminConfig.IP, "adminIP", defaultAdminIP, "The listen ip of the admin api")
+	flag.StringVar(&adminConfig.TokenFile, "adminTokenFile", "",
+		"The file of the bearer token which authenticates the requests of the admin api, "+
+			"the token length range is [16-256]")
+	flag.BoolVar(&adminConfig.EnableReset, "adminEnableReset", false,
+		"If true, the admin api can reset the chip which is not used by any process or container")
+	flag.StringVar(&adminOpLogFile, "adminOpLogFile", defaultOpLogFile,
+		"The operate log file which audits the requests of the admin api")
+	flag.IntVar(&inventoryTime, "inventoryUpdateTime", int(collector.DefaultInventoryUpdateTime/time.Second),
+		"Interval (seconds) to refresh the chip inventory, which rarely changes, range [60-86400]")
+	flag.BoolVar(&suppressUnbooted, "suppressUnbootedMetrics", false,
+		"If true, only the boot status is exported for the chip which is booting, the other chip metrics are "+
+			"suppressed until it finishes booting, the chip whose boot status fails to be queried is not suppressed")
+	flag.StringVar(&processOpts.ProcRoot, "procRoot", collector.DefaultProcRoot,
+		"The procfs of the host which the name, command line, user and start time of the npu processes are read from, "+
+			"the user names are resolved by the etc/passwd next to it, e.g. /host/etc/passwd for /host/proc")
+	flag.IntVar(&processOpts.TopN, "processTopN", collector.DefaultProcessTopN,
+		"The max number of the processes with the most memory usage exported for each chip, range [0-1024], "+
+			"0 means no limit")
+	flag.BoolVar(&processOpts.DropEnded, "dropEndedProcesses", false,
+		"If true, the processes which are still reported by the chip but have ended on the host are not exported")
 	flag.IntVar(&concurrency, "concurrency", defaultConcurrency,
 		"The max concurrency of the http server, range is [1-512]")
 	// hwlog configuration
@@ -330,12 +613,17 @@ func prometheusProcess() {
 		return
 	}
 	http.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}))
+	http.Handle(collector.InventoryPath, http.HandlerFunc(collector.InventoryHandler))
 	http.Handle("/", http.HandlerFunc(indexHandler))
 	conf := initConfig()
 	s, limitLs := newServerAndListener(conf)
 	if s == nil || limitLs == nil {
 		return
 	}
+	if err := startAdminServer(reg, opts); err != nil {
+		hwlog.RunLog.Errorf("start admin api failed: %v", err)
+		return
+	}
 	hwlog.RunLog.Warn("enable unsafe http server")
 	if err := s.Serve(limitLs); err != nil {
 		hwlog.RunLog.Error("Http server error: %v and stopped", err)
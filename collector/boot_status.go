@@ -0,0 +1,111 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+var (
+	npuChipBootStatus = prometheus.NewDesc("npu_chip_boot_status",
+		"the boot status of the npu, -1: unknown, 0: uninitialized, 1: starting bios, 2: starting os, "+
+			"3: boot finished", chipLabels, nil)
+	machineNPUReadyNums = prometheus.NewDesc("machine_npu_ready_nums",
+		"Amount of npu which finished booting on the machine.", nil, nil)
+	machineNPUReady = prometheus.NewDesc("machine_npu_ready",
+		"Whether all the npu on the machine finished booting, 1 means ready, 0 means not ready or no npu.", nil, nil)
+)
+
+// isBooting returns whether the chip is in an intermediate state of booting, the chip whose boot status fails to be
+// queried is not regarded as booting, because the query may be not supported by the driver
+func isBooting(status int) bool {
+	return status >= common.BootStatusUninit && status < common.BootStartFinish
+}
+
+func setBootStatus(logicID int32, dmgr devmanager.DeviceInterface, hwChip *HuaWeiAIChip) {
+	status, err := dmgr.GetDeviceBootStatus(logicID)
+	if err != nil {
+		hwlog.RunLog.Debugf("get boot status of logic id %d failed: %v", logicID, err)
+		status = common.BootStatusUnknown
+	}
+	hwChip.BootStatus = status
+}
+
+func describeBootStatusInfo(ch chan<- *prometheus.Desc) {
+	ch <- npuChipBootStatus
+	ch <- machineNPUReadyNums
+	ch <- machineNPUReady
+}
+
+func updateBootStatusInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
+	if !validate(ch, npu, chip, chip.ChipIfo) {
+		hwlog.RunLog.Error("Invalid param in function updateBootStatusInfo")
+		return
+	}
+	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipBootStatus,
+		prometheus.GaugeValue, float64(chip.BootStatus), chipLabelValues(chip)...))
+}
+
+// readinessRollup counts the chips which finished booting, the vNPUs of a chip are counted once, the chips whose boot
+// status is unknown are left out
+type readinessRollup struct {
+	chips map[int]int
+}
+
+func newReadinessRollup() *readinessRollup {
+	return &readinessRollup{chips: make(map[int]int, initSize)}
+}
+
+// add records the boot status of the chip, it returns false if the chip has been recorded by another vNPU on it
+func (r *readinessRollup) add(chip *HuaWeiAIChip) bool {
+	if chip == nil {
+		return false
+	}
+	if _, ok := r.chips[chip.DeviceID]; ok {
+		return false
+	}
+	r.chips[chip.DeviceID] = chip.BootStatus
+	return true
+}
+
+// ready returns the number of chips which finished booting and whether all the chips finished booting
+func (r *readinessRollup) ready() (int, bool) {
+	readyNum, knownNum := 0, 0
+	for _, status := range r.chips {
+		if status == common.BootStatusUnknown {
+			continue
+		}
+		knownNum++
+		if status == common.BootStartFinish {
+			readyNum++
+		}
+	}
+	return readyNum, knownNum > 0 && readyNum == knownNum
+}
+
+func (r *readinessRollup) collect(ch chan<- prometheus.Metric) {
+	readyNum, allReady := r.ready()
+	allReadyValue := 0.0
+	if allReady {
+		allReadyValue = 1
+	}
+	ch <- prometheus.MustNewConstMetric(machineNPUReadyNums, prometheus.GaugeValue, float64(readyNum))
+	ch <- prometheus.MustNewConstMetric(machineNPUReady, prometheus.GaugeValue, allReadyValue)
+}
@@ -0,0 +1,116 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/collector/container"
+	"huawei.com/npu-exporter/v5/common-utils/cache"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+func mockBootChip(phyID, bootStatus int) *HuaWeiAIChip {
+	chip := mock910Chip(phyID, 0, 0)
+	chip.BootStatus = bootStatus
+	chip.DevProcessInfo = &common.DevProcessInfo{}
+	return chip
+}
+
+// TestSetBootStatus test setBootStatus
+func TestSetBootStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		dmgr devmanager.DeviceInterface
+		want int
+	}{
+		{name: "should set boot status when dcmi works normally", dmgr: &devmanager.DeviceManagerMock{},
+			want: common.BootStartFinish},
+		{name: "should set unknown when boot status fails to be queried", dmgr: &devmanager.DeviceManagerMockErr{},
+			want: common.BootStatusUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chip := &HuaWeiAIChip{}
+			setBootStatus(0, tt.dmgr, chip)
+			assert.Equal(t, tt.want, chip.BootStatus)
+		})
+	}
+}
+
+// TestReadinessRollup test the chips which finished booting are counted once per chip
+func TestReadinessRollup(t *testing.T) {
+	vNPU := mock310PVNPU(1, common.MinVDevID, 0)
+	vNPU.BootStatus = common.BootStartFinish
+	tests := []struct {
+		name     string
+		chips    []*HuaWeiAIChip
+		readyNum int
+		allReady bool
+	}{
+		{name: "should be ready when all chips finished booting",
+			chips: []*HuaWeiAIChip{mockBootChip(0, common.BootStartFinish), vNPU, vNPU}, readyNum: 2, allReady: true},
+		{name: "should not be ready when a chip is booting",
+			chips:    []*HuaWeiAIChip{mockBootChip(0, common.BootStartFinish), mockBootChip(1, common.BootStatusOS)},
+			readyNum: 1},
+		{name: "should leave out chip whose boot status is unknown",
+			chips: []*HuaWeiAIChip{mockBootChip(0, common.BootStartFinish),
+				mockBootChip(1, common.BootStatusUnknown)}, readyNum: 1, allReady: true},
+		{name: "should not be ready when boot status of all chips is unknown",
+			chips: []*HuaWeiAIChip{mockBootChip(0, common.BootStatusUnknown)}},
+		{name: "should not be ready when there is no chip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newReadinessRollup()
+			for _, chip := range tt.chips {
+				r.add(chip)
+			}
+			readyNum, allReady := r.ready()
+			assert.Equal(t, tt.readyNum, readyNum)
+			assert.Equal(t, tt.allReady, allReady)
+		})
+	}
+}
+
+// TestSuppressUnbooted test the chip metrics except the boot status are suppressed until the chip finished booting
+func TestSuppressUnbooted(t *testing.T) {
+	n := &npuCollector{cache: cache.New(cacheSize), cacheTime: time.Minute, devicesParser: makeMockDevicesParser()}
+	assert.Nil(t, n.cache.Set(npuListCacheKey, []HuaWeiNPUCard{{CardID: 0, DeviceList: []*HuaWeiAIChip{
+		mockBootChip(0, common.BootStartFinish), mockBootChip(1, common.BootStatusBIOS),
+		mockBootChip(2, common.BootStatusUnknown)}}}, n.cacheTime))
+	assert.Nil(t, n.cache.Set(containersDevicesCacheKey, container.DevicesInfos{}, n.cacheTime))
+	tests := []struct {
+		name     string
+		suppress bool
+		want     int
+	}{
+		{name: "should export metrics of all chips when suppression is disabled", want: 3},
+		{name: "should only suppress metrics of booting chip when suppression is enabled", suppress: true, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n.opts.SuppressUnbooted = tt.suppress
+			assert.Equal(t, tt.want, testutil.CollectAndCount(n, "npu_chip_info_temperature"))
+			assert.Equal(t, 3, testutil.CollectAndCount(n, "npu_chip_boot_status"))
+		})
+	}
+}
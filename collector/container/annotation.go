@@ -0,0 +1,179 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container for monitoring containers' npu allocation
+package container
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+const (
+	// AnnotationAscendReal the real devices allocated to the pod by Ascend device plugin,
+	// e.g. "Ascend910-0,Ascend910-1" or "Ascend310P-vir02-100-0"
+	AnnotationAscendReal = "huawei.com/AscendReal"
+	// Annotation910Config the rank table of the pod written by Ascend device plugin
+	Annotation910Config = "ascend.kubectl.kubernetes.io/ascend-910-configuration"
+
+	maxAnnotationLen = 65535
+	physicalDevPart  = 2
+	virtualDevPart   = 4
+	vDevTemplateIdx  = 1
+	vDevIDIdx        = 2
+	vDevPhyIDIdx     = 3
+	annoSeparator    = ","
+	annoDevSeparator = "-"
+	ascendPrefix     = "Ascend"
+)
+
+// short template names used by the Ascend device plugin resources, such as huawei.com/Ascend310P-2c
+var shortTemplateNames = map[string]string{
+	"1c": "vir01", "2c": "vir02", "4c": "vir04", "8c": "vir08", "16c": "vir16",
+	"2c.1cpu": "vir02_1c", "4c.3cpu": "vir04_3c", "4c.3cpu.ndvpp": "vir04_3c_ndvpp",
+	"4c.4cpu.dvpp": "vir04_4c_dvpp",
+}
+
+// rankTable the content of annotation ascend.kubectl.kubernetes.io/ascend-910-configuration
+type rankTable struct {
+	Devices []rankTableDevice `json:"devices"`
+}
+
+type rankTableDevice struct {
+	DeviceID string `json:"device_id"`
+}
+
+// getDevicesFromAnnotations get devices of a container from the pod annotations written by Ascend device
+// plugin. huawei.com/AscendReal takes precedence over ascend.kubectl.kubernetes.io/ascend-910-configuration
+func getDevicesFromAnnotations(annotations map[string]string) ([]NpuDevice, error) {
+	if ascendReal, ok := annotations[AnnotationAscendReal]; ok && ascendReal != "" {
+		return parseAscendReal(ascendReal)
+	}
+	if config, ok := annotations[Annotation910Config]; ok && config != "" {
+		return parse910Config(config)
+	}
+	return nil, nil
+}
+
+func parseAscendReal(value string) ([]NpuDevice, error) {
+	if len(value) > maxAnnotationLen {
+		return nil, fmt.Errorf("the length of annotation %s is too long", AnnotationAscendReal)
+	}
+	devices := make([]NpuDevice, 0, sliceLen8)
+	for _, dev := range strings.Split(value, annoSeparator) {
+		device, err := parseAscendRealDevice(strings.TrimSpace(dev))
+		if err != nil {
+			hwlog.RunLog.Warnf("skip device (%s) in annotation %s: %v", dev, AnnotationAscendReal, err)
+			continue
+		}
+		devices = append(devices, device)
+	}
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no valid device in annotation %s", AnnotationAscendReal)
+	}
+	return devices, nil
+}
+
+// parseAscendRealDevice parse one device in annotation huawei.com/AscendReal, the supported formats are:
+// physical device: <chip>-<physical ID>, e.g. Ascend910-0
+// virtual device: <chip>-<template>-<virtual device ID>-<physical ID>, e.g. Ascend310P-vir02-100-0
+func parseAscendRealDevice(dev string) (NpuDevice, error) {
+	parts := strings.Split(dev, annoDevSeparator)
+	switch len(parts) {
+	case physicalDevPart:
+		phyID, err := parseDeviceID(parts[physicalDevPart-1])
+		if err != nil {
+			return NpuDevice{}, err
+		}
+		return NpuDevice{PhyID: phyID, VDevID: NoVDevID}, nil
+	case virtualDevPart:
+		if _, err := resolveTemplateName(parts[0], parts[vDevTemplateIdx]); err != nil {
+			return NpuDevice{}, err
+		}
+		vDevID, err := parseDeviceID(parts[vDevIDIdx])
+		if err != nil {
+			return NpuDevice{}, err
+		}
+		if !common.IsValidVDevID(uint32(vDevID)) {
+			return NpuDevice{}, fmt.Errorf("invalid virtual device ID (%d)", vDevID)
+		}
+		phyID, err := parseDeviceID(parts[vDevPhyIDIdx])
+		if err != nil {
+			return NpuDevice{}, err
+		}
+		return NpuDevice{PhyID: phyID, VDevID: vDevID}, nil
+	default:
+		return NpuDevice{}, errors.New("unknown device format")
+	}
+}
+
+// resolveTemplateName resolve the vNPU template in annotation to the template name of dcmi, both the dcmi name
+// (e.g. vir02) and the short name of device plugin resource (e.g. 2c) are accepted
+func resolveTemplateName(chip, template string) (string, error) {
+	if name, ok := shortTemplateNames[template]; ok {
+		template = name
+	}
+	devTypes := []string{common.GetDeviceTypeByChipName(strings.TrimPrefix(chip, ascendPrefix))}
+	// Ascend910B shares the resource name Ascend910 with Ascend910 in device plugin
+	if devTypes[0] == common.Ascend910 {
+		devTypes = append(devTypes, common.Ascend910B)
+	}
+	for _, devType := range devTypes {
+		if common.IsValidTemplateName(devType, template) {
+			return template, nil
+		}
+	}
+	return "", fmt.Errorf("invalid template (%s) of chip (%s)", template, chip)
+}
+
+func parse910Config(value string) ([]NpuDevice, error) {
+	if len(value) > maxAnnotationLen {
+		return nil, fmt.Errorf("the length of annotation %s is too long", Annotation910Config)
+	}
+	var table rankTable
+	if err := json.Unmarshal([]byte(value), &table); err != nil {
+		return nil, fmt.Errorf("unmarshal annotation %s failed: %v", Annotation910Config, err)
+	}
+	devices := make([]NpuDevice, 0, len(table.Devices))
+	for _, dev := range table.Devices {
+		id, err := parseDeviceID(dev.DeviceID)
+		if err != nil {
+			hwlog.RunLog.Warnf("skip device (%s) in annotation %s: %v", dev.DeviceID, Annotation910Config, err)
+			continue
+		}
+		devices = append(devices, NpuDevice{PhyID: id, VDevID: NoVDevID})
+	}
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no valid device in annotation %s", Annotation910Config)
+	}
+	return devices, nil
+}
+
+func parseDeviceID(idStr string) (int, error) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return -1, fmt.Errorf("unexpected device ID (%s)", idStr)
+	}
+	if id < 0 || id > math.MaxInt32 {
+		return -1, fmt.Errorf("get wrong device ID (%d)", id)
+	}
+	return id, nil
+}
@@ -0,0 +1,91 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container for monitoring containers' npu allocation
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+)
+
+func init() {
+	hwLogConfig := hwlog.LogConfig{OnlyToStdout: true}
+	if err := hwlog.InitRunLogger(&hwLogConfig, context.Background()); err != nil {
+		panic(err)
+	}
+}
+
+// TestGetDevicesFromAnnotations test getDevicesFromAnnotations
+func TestGetDevicesFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []NpuDevice
+		wantErr     bool
+	}{
+		{
+			name:        "should return nil when annotations is empty",
+			annotations: nil,
+			want:        nil,
+		},
+		{
+			name:        "should return physical IDs when given physical devices in AscendReal",
+			annotations: map[string]string{AnnotationAscendReal: "Ascend910-0,Ascend910-3"},
+			want:        []NpuDevice{{PhyID: 0, VDevID: NoVDevID}, {PhyID: 3, VDevID: NoVDevID}},
+		},
+		{
+			name:        "should return vNPU with physical ID when given vNPU in AscendReal",
+			annotations: map[string]string{AnnotationAscendReal: "Ascend310P-vir02-100-0,Ascend310P-2c-101-1"},
+			want:        []NpuDevice{{PhyID: 0, VDevID: 100}, {PhyID: 1, VDevID: 101}},
+		},
+		{
+			name:        "should skip device with invalid template when given vNPU in AscendReal",
+			annotations: map[string]string{AnnotationAscendReal: "Ascend310P-vir99-100-0,Ascend310P-vir04-102-1"},
+			want:        []NpuDevice{{PhyID: 1, VDevID: 102}},
+		},
+		{
+			name:        "should return error when no valid device in AscendReal",
+			annotations: map[string]string{AnnotationAscendReal: "Ascend910-x"},
+			wantErr:     true,
+		},
+		{
+			name: "should use AscendReal when both annotations exist",
+			annotations: map[string]string{AnnotationAscendReal: "Ascend910-1",
+				Annotation910Config: `{"devices":[{"device_id":"2"}]}`},
+			want: []NpuDevice{{PhyID: 1, VDevID: NoVDevID}},
+		},
+		{
+			name:        "should return device IDs when given 910 configuration",
+			annotations: map[string]string{Annotation910Config: `{"devices":[{"device_id":"2"},{"device_id":"5"}]}`},
+			want:        []NpuDevice{{PhyID: 2, VDevID: NoVDevID}, {PhyID: 5, VDevID: NoVDevID}},
+		},
+		{
+			name:        "should return error when 910 configuration is not json",
+			annotations: map[string]string{Annotation910Config: "devices"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getDevicesFromAnnotations(tt.annotations)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container for monitoring containers' npu allocation
+package container
+
+import (
+	"math"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+// NoVDevID the VDevID of a npu device which is a physical chip
+const NoVDevID = -1
+
+// idSource the source of the device IDs of container
+type idSource int
+
+const (
+	// sourcePhysical the IDs are physical IDs, such as the annotations, the minor numbers of cgroup device rules and
+	// the device paths /dev/davinci<phy>
+	sourcePhysical idSource = iota
+	// sourceLogic the IDs are logic IDs, such as the env ASCEND_VISIBLE_DEVICES
+	sourceLogic
+)
+
+// DeviceIDConverter converts the ID of npu chip between logic ID and physical ID, and finds the chip of vNPU by the
+// virtual device info of chips, it is implemented by devmanager.DeviceInterface
+type DeviceIDConverter interface {
+	GetPhysicIDFromLogicID(logicID int32) (int32, error)
+	GetLogicIDFromPhysicID(physicID int32) (int32, error)
+	GetDeviceList() (int32, []int32, error)
+	GetVirtualDeviceInfo(logicID int32) (common.VirtualDevInfo, error)
+}
+
+// NpuDevice the npu device used by container, a physical chip or a vNPU on the chip
+type NpuDevice struct {
+	// physical ID of the npu chip, it is -1 if the chip of a vNPU is unknown
+	PhyID int
+	// virtual device ID of the vNPU, it is NoVDevID if the device is a physical chip
+	VDevID int
+}
+
+// IsVirtual returns whether the device is a vNPU
+func (d NpuDevice) IsVirtual() bool {
+	return d.VDevID != NoVDevID
+}
+
+// Key returns the ID to match the npu chips collected from the driver, which is the virtual device ID of a vNPU
+// and the physical ID of a physical chip
+func (d NpuDevice) Key() int {
+	if d.IsVirtual() {
+		return d.VDevID
+	}
+	return d.PhyID
+}
+
+func newNpuDevices(ids []int) []NpuDevice {
+	devices := make([]NpuDevice, 0, len(ids))
+	for _, id := range ids {
+		devices = append(devices, NpuDevice{PhyID: id, VDevID: NoVDevID})
+	}
+	return devices
+}
+
+// normalizeDevices normalizes the devices from the given source into physical IDs and virtual device IDs, and
+// returns the number of devices which can not be matched with any npu chip on the host. An ID is resolved by the ID
+// space of its source, it is never guessed because a logic ID may equal the physical ID of another chip. The chip of
+// a vNPU is found by the virtual device info of chips. All IDs are trusted when the converter is not set
+func (dp *DevicesParser) normalizeDevices(devices []NpuDevice, source idSource) ([]NpuDevice, int) {
+	normalized := make([]NpuDevice, 0, len(devices))
+	mismatchNum := 0
+	var vDevChips map[int]int
+	for _, dev := range devices {
+		if !dev.IsVirtual() && dev.PhyID >= 0 && dev.PhyID <= math.MaxInt32 && common.IsValidVDevID(uint32(dev.PhyID)) {
+			dev = NpuDevice{PhyID: -1, VDevID: dev.PhyID}
+		}
+		if dp.IDConverter == nil || (dev.IsVirtual() && dev.PhyID >= 0) {
+			normalized = append(normalized, dev)
+			continue
+		}
+		if dev.IsVirtual() {
+			if vDevChips == nil {
+				vDevChips = dp.getVDevChips()
+			}
+			phyID, ok := vDevChips[dev.VDevID]
+			if !ok {
+				hwlog.RunLog.Warnf("virtual device ID (%d) does not match any vNPU on the host", dev.VDevID)
+				mismatchNum++
+				phyID = -1
+			}
+			normalized = append(normalized, NpuDevice{PhyID: phyID, VDevID: dev.VDevID})
+			continue
+		}
+		phyID, ok := dp.resolvePhyID(dev.PhyID, source)
+		if !ok {
+			hwlog.RunLog.Warnf("device ID (%d) does not match any npu chip on the host", dev.PhyID)
+			mismatchNum++
+			phyID = dev.PhyID
+		}
+		normalized = append(normalized, NpuDevice{PhyID: phyID, VDevID: NoVDevID})
+	}
+	return normalized, mismatchNum
+}
+
+// getVDevChips returns the physical IDs of chips by the IDs of vNPUs created on them, the chips which do not
+// support vNPU are skipped
+func (dp *DevicesParser) getVDevChips() map[int]int {
+	vDevChips := make(map[int]int)
+	_, logicIDs, err := dp.IDConverter.GetDeviceList()
+	if err != nil {
+		hwlog.RunLog.Warnf("get device list failed, the chips of vNPUs are unknown, err: %v", err)
+		return vDevChips
+	}
+	for _, logicID := range logicIDs {
+		vDevInfo, err := dp.IDConverter.GetVirtualDeviceInfo(logicID)
+		if err != nil {
+			hwlog.RunLog.Debugf("get virtual device info of logic ID (%d) failed, err: %v", logicID, err)
+			continue
+		}
+		phyID, err := dp.IDConverter.GetPhysicIDFromLogicID(logicID)
+		if err != nil {
+			hwlog.RunLog.Warnf("get physical ID of logic ID (%d) failed, err: %v", logicID, err)
+			continue
+		}
+		for _, vDev := range vDevInfo.VDevInfo {
+			vDevChips[int(vDev.VDevID)] = int(phyID)
+		}
+	}
+	return vDevChips
+}
+
+// resolvePhyID returns the physical ID of the chip, a physical ID is checked to be on the host and a logic ID is
+// converted to the physical ID
+func (dp *DevicesParser) resolvePhyID(id int, source idSource) (int, bool) {
+	if id < 0 || id > math.MaxInt32 {
+		return -1, false
+	}
+	if source == sourcePhysical {
+		if _, err := dp.IDConverter.GetLogicIDFromPhysicID(int32(id)); err != nil {
+			return -1, false
+		}
+		return id, true
+	}
+	phyID, err := dp.IDConverter.GetPhysicIDFromLogicID(int32(id))
+	if err != nil {
+		return -1, false
+	}
+	hwlog.RunLog.Debugf("convert logic ID (%d) to physical ID (%d)", id, phyID)
+	return int(phyID), true
+}
+
+// matchAnnotationDevices returns the cgroup devices of a container, a cgroup device is replaced by the annotation
+// device with the same key, which carries the physical ID of vNPU. The annotations of pod sandbox are merged into
+// every container of the pod, so the annotation devices which are not in the cgroup belong to the other containers
+// and are ignored. The number of cgroup devices which are not in the annotations is returned too
+func matchAnnotationDevices(cgroupDevs, annoDevs []NpuDevice) ([]NpuDevice, int) {
+	annoKeys := make(map[int]NpuDevice, len(annoDevs))
+	for _, dev := range annoDevs {
+		annoKeys[dev.Key()] = dev
+	}
+	devices := make([]NpuDevice, 0, len(cgroupDevs))
+	diff := 0
+	for _, dev := range cgroupDevs {
+		annoDev, ok := annoKeys[dev.Key()]
+		if !ok {
+			diff++
+			devices = append(devices, dev)
+			continue
+		}
+		devices = append(devices, annoDev)
+	}
+	return devices, diff
+}
@@ -0,0 +1,255 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container for monitoring containers' npu allocation
+package container
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+// mockIDConverter converts IDs by the logic ID to physical ID table, and finds vNPUs by the logic ID to vNPU table
+type mockIDConverter struct {
+	logicToPhy map[int32]int32
+	vDevs      map[int32][]uint32
+}
+
+// GetPhysicIDFromLogicID implements DeviceIDConverter
+func (m *mockIDConverter) GetPhysicIDFromLogicID(logicID int32) (int32, error) {
+	if phyID, ok := m.logicToPhy[logicID]; ok {
+		return phyID, nil
+	}
+	return -1, errors.New("invalid logic ID")
+}
+
+// GetLogicIDFromPhysicID implements DeviceIDConverter
+func (m *mockIDConverter) GetLogicIDFromPhysicID(physicID int32) (int32, error) {
+	for logicID, phyID := range m.logicToPhy {
+		if phyID == physicID {
+			return logicID, nil
+		}
+	}
+	return -1, errors.New("invalid physical ID")
+}
+
+// GetDeviceList implements DeviceIDConverter
+func (m *mockIDConverter) GetDeviceList() (int32, []int32, error) {
+	logicIDs := make([]int32, 0, len(m.logicToPhy))
+	for logicID := range m.logicToPhy {
+		logicIDs = append(logicIDs, logicID)
+	}
+	return int32(len(logicIDs)), logicIDs, nil
+}
+
+// GetVirtualDeviceInfo implements DeviceIDConverter
+func (m *mockIDConverter) GetVirtualDeviceInfo(logicID int32) (common.VirtualDevInfo, error) {
+	vDevIDs, ok := m.vDevs[logicID]
+	if !ok {
+		return common.VirtualDevInfo{}, errors.New("not supported")
+	}
+	info := common.VirtualDevInfo{}
+	for _, vDevID := range vDevIDs {
+		info.VDevInfo = append(info.VDevInfo, common.CgoVDevQueryStru{VDevID: vDevID})
+	}
+	return info, nil
+}
+
+// Ascend910 server with 8 chips whose physical chip 3 is not in place, logic ID 3~6 are physical ID 4~7
+var mock910Converter = &mockIDConverter{logicToPhy: map[int32]int32{0: 0, 1: 1, 2: 2, 3: 4, 4: 5, 5: 6, 6: 7}}
+
+// Ascend910 server whose logic IDs are not the physical IDs after a chip is isolated, logic ID 0 is physical ID 1
+// and logic ID 1 is physical ID 2
+var mockShiftedConverter = &mockIDConverter{logicToPhy: map[int32]int32{0: 1, 1: 2}}
+
+// Ascend310P Duo card with 2 chips, vNPU 100 is created on chip 1
+var mock310PConverter = &mockIDConverter{logicToPhy: map[int32]int32{0: 0, 1: 1},
+	vDevs: map[int32][]uint32{0: {}, 1: {100}}}
+
+// TestNormalizeDevices test normalizeDevices
+func TestNormalizeDevices(t *testing.T) {
+	tests := []struct {
+		name         string
+		converter    DeviceIDConverter
+		ids          []int
+		source       idSource
+		want         []NpuDevice
+		wantMismatch int
+	}{
+		{
+			name:      "should trust all IDs when converter is not set",
+			converter: nil,
+			ids:       []int{3, 9},
+			source:    sourceLogic,
+			want:      []NpuDevice{{PhyID: 3, VDevID: NoVDevID}, {PhyID: 9, VDevID: NoVDevID}},
+		},
+		{
+			name:      "should keep physical IDs when given physical IDs of 910 multi-chip server",
+			converter: mock910Converter,
+			ids:       []int{4, 5, 6, 7},
+			source:    sourcePhysical,
+			want: []NpuDevice{{PhyID: 4, VDevID: NoVDevID}, {PhyID: 5, VDevID: NoVDevID},
+				{PhyID: 6, VDevID: NoVDevID}, {PhyID: 7, VDevID: NoVDevID}},
+		},
+		{
+			name:         "should flag mismatch when given physical ID not in place of 910 multi-chip server",
+			converter:    mock910Converter,
+			ids:          []int{2, 3},
+			source:       sourcePhysical,
+			want:         []NpuDevice{{PhyID: 2, VDevID: NoVDevID}, {PhyID: 3, VDevID: NoVDevID}},
+			wantMismatch: 1,
+		},
+		{
+			name:      "should convert logic IDs when given logic IDs of 910 multi-chip server",
+			converter: mock910Converter,
+			ids:       []int{2, 3},
+			source:    sourceLogic,
+			want:      []NpuDevice{{PhyID: 2, VDevID: NoVDevID}, {PhyID: 4, VDevID: NoVDevID}},
+		},
+		{
+			name:      "should convert logic ID when it equals physical ID of another chip",
+			converter: mockShiftedConverter,
+			ids:       []int{1},
+			source:    sourceLogic,
+			want:      []NpuDevice{{PhyID: 2, VDevID: NoVDevID}},
+		},
+		{
+			name:      "should keep physical ID when it equals logic ID of another chip",
+			converter: mockShiftedConverter,
+			ids:       []int{1},
+			source:    sourcePhysical,
+			want:      []NpuDevice{{PhyID: 1, VDevID: NoVDevID}},
+		},
+		{
+			name:         "should flag mismatch when physical ID is only a logic ID",
+			converter:    mockShiftedConverter,
+			ids:          []int{0},
+			source:       sourcePhysical,
+			want:         []NpuDevice{{PhyID: 0, VDevID: NoVDevID}},
+			wantMismatch: 1,
+		},
+		{
+			name:         "should flag mismatch when logic ID is not on the host",
+			converter:    mock910Converter,
+			ids:          []int{8},
+			source:       sourceLogic,
+			want:         []NpuDevice{{PhyID: 8, VDevID: NoVDevID}},
+			wantMismatch: 1,
+		},
+		{
+			name:      "should return vNPU with physical ID when given virtual device IDs of 310P",
+			converter: mock310PConverter,
+			ids:       []int{100, 1},
+			source:    sourcePhysical,
+			want:      []NpuDevice{{PhyID: 1, VDevID: 100}, {PhyID: 1, VDevID: NoVDevID}},
+		},
+		{
+			name:         "should flag mismatch when given virtual device ID not created on any chip",
+			converter:    mock310PConverter,
+			ids:          []int{101},
+			source:       sourcePhysical,
+			want:         []NpuDevice{{PhyID: -1, VDevID: 101}},
+			wantMismatch: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := &DevicesParser{IDConverter: tt.converter}
+			got, mismatchNum := dp.normalizeDevices(newNpuDevices(tt.ids), tt.source)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantMismatch, mismatchNum)
+		})
+	}
+}
+
+// TestSelectDevices test selectDevices
+func TestSelectDevices(t *testing.T) {
+	tests := []struct {
+		name         string
+		converter    DeviceIDConverter
+		c            *CommonContainer
+		cgroupIDs    []int
+		want         []NpuDevice
+		wantMismatch int
+	}{
+		{
+			name:      "should return cgroup devices when container has no annotation",
+			converter: mock910Converter,
+			c:         &CommonContainer{Id: "1"},
+			cgroupIDs: []int{0, 1},
+			want:      []NpuDevice{{PhyID: 0, VDevID: NoVDevID}, {PhyID: 1, VDevID: NoVDevID}},
+		},
+		{
+			name:      "should return annotation devices when annotation matches cgroup of 910 multi-chip server",
+			converter: mock910Converter,
+			c: &CommonContainer{Id: "1",
+				Annotations: map[string]string{AnnotationAscendReal: "Ascend910-4,Ascend910-5"}},
+			cgroupIDs: []int{4, 5},
+			want:      []NpuDevice{{PhyID: 4, VDevID: NoVDevID}, {PhyID: 5, VDevID: NoVDevID}},
+		},
+		{
+			name:      "should flag mismatch when cgroup device is not in annotation",
+			converter: mock910Converter,
+			c: &CommonContainer{Id: "1",
+				Annotations: map[string]string{AnnotationAscendReal: "Ascend910-4,Ascend910-5"}},
+			cgroupIDs:    []int{4, 7},
+			want:         []NpuDevice{{PhyID: 4, VDevID: NoVDevID}, {PhyID: 7, VDevID: NoVDevID}},
+			wantMismatch: 1,
+		},
+		{
+			name:      "should count mismatches of both cgroup and annotation",
+			converter: mock910Converter,
+			c: &CommonContainer{Id: "1",
+				Annotations: map[string]string{AnnotationAscendReal: "Ascend910-3,Ascend910-4"}},
+			cgroupIDs:    []int{8, 4},
+			want:         []NpuDevice{{PhyID: 8, VDevID: NoVDevID}, {PhyID: 4, VDevID: NoVDevID}},
+			wantMismatch: 3,
+		},
+		{
+			name:      "should only return devices in cgroup when pod annotation is shared by containers",
+			converter: mock910Converter,
+			c: &CommonContainer{Id: "1",
+				Annotations: map[string]string{AnnotationAscendReal: "Ascend910-4,Ascend910-5"}},
+			cgroupIDs: []int{5},
+			want:      []NpuDevice{{PhyID: 5, VDevID: NoVDevID}},
+		},
+		{
+			name:      "should return vNPU with physical ID when given 310P vNPU annotation",
+			converter: mock310PConverter,
+			c: &CommonContainer{Id: "1",
+				Annotations: map[string]string{AnnotationAscendReal: "Ascend310P-vir02-100-1"}},
+			cgroupIDs: []int{100},
+			want:      []NpuDevice{{PhyID: 1, VDevID: 100}},
+		},
+		{
+			name:      "should return cgroup devices when annotation is invalid",
+			converter: mock910Converter,
+			c:         &CommonContainer{Id: "1", Annotations: map[string]string{Annotation910Config: "{"}},
+			cgroupIDs: []int{0, 1},
+			want:      []NpuDevice{{PhyID: 0, VDevID: NoVDevID}, {PhyID: 1, VDevID: NoVDevID}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := &DevicesParser{IDConverter: tt.converter}
+			got, mismatchNum := dp.selectDevices(tt.c, tt.cgroupIDs)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantMismatch, mismatchNum)
+		})
+	}
+}
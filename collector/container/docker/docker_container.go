@@ -0,0 +1,51 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package docker the types of Docker Engine API used by npu-exporter
+package docker
+
+// Container the item of the response of Docker Engine API GET /containers/json
+type Container struct {
+	ID     string            `json:"Id"`
+	Names  []string          `json:"Names,omitempty"`
+	Labels map[string]string `json:"Labels,omitempty"`
+	State  string            `json:"State,omitempty"`
+}
+
+// Config the config of container
+type Config struct {
+	Env    []string          `json:"Env,omitempty"`
+	Labels map[string]string `json:"Labels,omitempty"`
+}
+
+// DeviceMapping the device mapped into container
+type DeviceMapping struct {
+	PathOnHost        string `json:"PathOnHost,omitempty"`
+	PathInContainer   string `json:"PathInContainer,omitempty"`
+	CgroupPermissions string `json:"CgroupPermissions,omitempty"`
+}
+
+// HostConfig the host config of container
+type HostConfig struct {
+	Devices    []DeviceMapping `json:"Devices,omitempty"`
+	Privileged bool            `json:"Privileged,omitempty"`
+}
+
+// ContainerJSON the response of Docker Engine API GET /containers/{id}/json
+type ContainerJSON struct {
+	ID         string      `json:"Id"`
+	Name       string      `json:"Name,omitempty"`
+	Config     *Config     `json:"Config,omitempty"`
+	HostConfig *HostConfig `json:"HostConfig,omitempty"`
+}
@@ -0,0 +1,177 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container for monitoring containers' npu allocation
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"huawei.com/npu-exporter/v5/collector/container/docker"
+	"huawei.com/npu-exporter/v5/collector/container/isula"
+	"huawei.com/npu-exporter/v5/collector/container/v1"
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/common-utils/utils"
+)
+
+const (
+	// DefaultDockerEngineAddr default docker engine sock address
+	DefaultDockerEngineAddr = "unix:///var/run/docker.sock"
+	// DockerAPIContainer the container type of docker engine api
+	DockerAPIContainer = "docker-api"
+
+	// the host in url is ignored when requesting by unix socket
+	dockerAPIHost       = "http://docker"
+	dockerListPath      = "/containers/json"
+	dockerInspectPath   = "/containers/%s/json"
+	dockerRunningFilter = `{"status":["running"]}`
+	maxDockerRespBytes  = 20 * 1024 * 1024
+	dockerIDPattern     = `^[a-f0-9]{64}$`
+)
+
+// DockerAPIOperator implements RuntimeOperator interface by the Docker Engine API, it works without CRI, such as
+// docker on the host without K8S
+type DockerAPIOperator struct {
+	client *http.Client
+	// Endpoint docker engine endpoint
+	Endpoint string
+}
+
+// Init initializes the http client of docker engine api
+func (operator *DockerAPIOperator) Init() error {
+	prefix, addr, err := parseSocketEndpoint(operator.Endpoint)
+	if err != nil {
+		return err
+	}
+	if prefix != unixPrefix {
+		return errors.New("only support unix socket")
+	}
+	if _, err := utils.CheckPath(addr); err != nil {
+		hwlog.RunLog.Error("check socket path failed")
+		return err
+	}
+	operator.client = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dial(ctx, addr)
+			},
+		},
+		Timeout: defaultTimeout,
+	}
+	return nil
+}
+
+// Close closes the idle connections of docker engine api
+func (operator *DockerAPIOperator) Close() error {
+	if operator.client != nil {
+		operator.client.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetContainers returns all running containers
+func (operator *DockerAPIOperator) GetContainers(ctx context.Context) ([]*CommonContainer, error) {
+	query := url.Values{}
+	query.Set("filters", dockerRunningFilter)
+	var containers []docker.Container
+	if err := operator.get(ctx, dockerListPath+"?"+query.Encode(), &containers); err != nil {
+		hwlog.RunLog.Error(err)
+		return nil, err
+	}
+	allContainers := make([]*CommonContainer, 0, len(containers))
+	for _, container := range containers {
+		name := ""
+		if len(container.Names) > 0 {
+			name = strings.TrimPrefix(container.Names[0], "/")
+		}
+		allContainers = append(allContainers, &CommonContainer{
+			Id:     container.ID,
+			Name:   name,
+			Labels: container.Labels,
+		})
+	}
+	return allContainers, nil
+}
+
+// GetContainerInfoByID is not supported by docker engine api
+func (operator *DockerAPIOperator) GetContainerInfoByID(_ context.Context, _ string) (v1.Spec, error) {
+	return v1.Spec{}, errors.New("unexpected docker engine client")
+}
+
+// GetIsulaContainerInfoByID is not supported by docker engine api
+func (operator *DockerAPIOperator) GetIsulaContainerInfoByID(_ context.Context, _ string) (isula.ContainerJson,
+	error) {
+	return isula.ContainerJson{}, errors.New("unexpected docker engine client")
+}
+
+// GetDockerContainerInfoByID use docker engine api to inspect container
+func (operator *DockerAPIOperator) GetDockerContainerInfoByID(ctx context.Context,
+	id string) (docker.ContainerJSON, error) {
+	if match, err := regexp.MatchString(dockerIDPattern, id); err != nil || !match {
+		return docker.ContainerJSON{}, fmt.Errorf("invalid docker container id (%s)", id)
+	}
+	containerJSON := docker.ContainerJSON{}
+	if err := operator.get(ctx, fmt.Sprintf(dockerInspectPath, id), &containerJSON); err != nil {
+		hwlog.RunLog.Error("call docker engine inspect api failed")
+		return docker.ContainerJSON{}, err
+	}
+	return containerJSON, nil
+}
+
+// GetContainerType returns the container type of docker engine api
+func (operator *DockerAPIOperator) GetContainerType() string {
+	return DockerAPIContainer
+}
+
+func (operator *DockerAPIOperator) get(ctx context.Context, path string, result interface{}) error {
+	if operator.client == nil {
+		return errors.New("docker engine client is empty")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dockerAPIHost+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := operator.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			hwlog.RunLog.Error(err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("docker engine api returns unexpected status code %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDockerRespBytes+1))
+	if err != nil {
+		return err
+	}
+	if len(body) > maxDockerRespBytes {
+		return errors.New("the response of docker engine api is too large")
+	}
+	if err = json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("unmarshal docker engine api response failed: %v", err)
+	}
+	return nil
+}
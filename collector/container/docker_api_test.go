@@ -0,0 +1,122 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container for monitoring containers' npu allocation
+package container
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mockDockerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func newMockDockerEngine(t *testing.T) *DockerAPIOperator {
+	sock := filepath.Join(t.TempDir(), "docker.sock")
+	ln, err := net.Listen(unixPrefix, sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(dockerListPath, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, dockerRunningFilter, r.URL.Query().Get("filters"))
+		_, _ = w.Write([]byte(`[{"Id":"` + mockDockerID + `","Names":["/train_worker_1"],` +
+			`"Labels":{"com.docker.compose.project":"train","com.docker.compose.service":"worker"}}]`))
+	})
+	mux.HandleFunc("/containers/"+mockDockerID+"/json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"Id":"` + mockDockerID + `","Config":{"Env":["PATH=/usr/bin"]},` +
+			`"HostConfig":{"Devices":[{"PathOnHost":"/dev/davinci1","PathInContainer":"/dev/davinci1"},` +
+			`{"PathOnHost":"/dev/davinci_manager","PathInContainer":"/dev/davinci_manager"}]}}`))
+	})
+	server := httptest.NewUnstartedServer(mux)
+	server.Listener = ln
+	server.Start()
+	t.Cleanup(server.Close)
+
+	operator := &DockerAPIOperator{Endpoint: unixPre + sock}
+	if err := operator.Init(); err != nil {
+		t.Fatal(err)
+	}
+	return operator
+}
+
+// TestDockerAPIOperator test the docker engine api operator
+func TestDockerAPIOperator(t *testing.T) {
+	operator := newMockDockerEngine(t)
+	defer operator.Close()
+
+	t.Run("should return running containers with docker name", func(t *testing.T) {
+		containers, err := operator.GetContainers(context.Background())
+		assert.Nil(t, err)
+		if assert.Len(t, containers, 1) {
+			assert.Equal(t, mockDockerID, containers[0].Id)
+			assert.Equal(t, "train_worker_1", containers[0].Name)
+		}
+	})
+	t.Run("should return error when container id is invalid", func(t *testing.T) {
+		_, err := operator.GetDockerContainerInfoByID(context.Background(), "../info")
+		assert.NotNil(t, err)
+	})
+	t.Run("should parse npu devices when container has davinci devices", func(t *testing.T) {
+		dp := &DevicesParser{RuntimeOperator: operator}
+		rs := make(chan DevicesInfo, 1)
+		c := &CommonContainer{Id: mockDockerID, Name: "train_worker_1",
+			Labels: map[string]string{labelComposeProject: "train", labelComposeService: "worker"}}
+		assert.Nil(t, dp.parseDevices(context.Background(), c, rs))
+		info := <-rs
+		assert.Equal(t, "train_worker_train-worker-1", info.Name)
+		assert.Equal(t, []NpuDevice{{PhyID: 1, VDevID: NoVDevID}}, info.Devices)
+	})
+}
+
+// TestMakeUpDockerDeviceInfo test makeUpDeviceInfo for containers without K8S labels
+func TestMakeUpDockerDeviceInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        *CommonContainer
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name:     "should use docker name when container has no compose labels",
+			c:        &CommonContainer{Id: "1", Name: "/bench"},
+			wantName: "_bench_bench",
+		},
+		{
+			name: "should use compose project and service when container has compose labels",
+			c: &CommonContainer{Id: "1", Name: "proj-svc-1",
+				Labels: map[string]string{labelComposeProject: "proj", labelComposeService: "svc"}},
+			wantName: "proj_svc_proj-svc-1",
+		},
+		{
+			name:    "should return error when docker name is invalid",
+			c:       &CommonContainer{Id: "1", Name: "/" + strings.Repeat("a", maxLenDockerName+1)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := makeUpDeviceInfo(tt.c)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantName, info.Name)
+		})
+	}
+}
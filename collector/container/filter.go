@@ -0,0 +1,192 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container for monitoring containers' npu allocation
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	filterSeparator      = ","
+	labelValueSeparator  = "="
+	maxFilterLen         = 1024
+	maxFilterItemNum     = 128
+	labelWithValueLength = 2
+)
+
+// FilterOpts the include and exclude settings of containers, the include settings are ANDed and a container
+// matching any exclude setting is dropped
+type FilterOpts struct {
+	// IncludeNamespaces comma separated k8s namespaces of the containers to monitor
+	IncludeNamespaces string
+	// ExcludeNamespaces comma separated k8s namespaces of the containers not to monitor
+	ExcludeNamespaces string
+	// IncludePodRegex regex of the k8s pod names of the containers to monitor
+	IncludePodRegex string
+	// ExcludePodRegex regex of the k8s pod names of the containers not to monitor
+	ExcludePodRegex string
+	// IncludeLabels comma separated labels that the containers to monitor must have, in the form of key or
+	// key=value
+	IncludeLabels string
+	// ExcludeLabels comma separated labels of the containers not to monitor, in the form of key or key=value
+	ExcludeLabels string
+}
+
+// ContainerFilter filters the containers listed by the runtime before parsing their devices
+type ContainerFilter struct {
+	includeNamespaces map[string]struct{}
+	excludeNamespaces map[string]struct{}
+	includePod        *regexp.Regexp
+	excludePod        *regexp.Regexp
+	includeLabels     map[string]string
+	excludeLabels     map[string]string
+}
+
+// NewContainerFilter creates a filter by the options, nil is returned if no filter is set
+func NewContainerFilter(opts FilterOpts) (*ContainerFilter, error) {
+	if opts == (FilterOpts{}) {
+		return nil, nil
+	}
+	for _, v := range []string{opts.IncludeNamespaces, opts.ExcludeNamespaces, opts.IncludePodRegex,
+		opts.ExcludePodRegex, opts.IncludeLabels, opts.ExcludeLabels} {
+		if len(v) > maxFilterLen {
+			return nil, fmt.Errorf("the length of container filter is more than %d", maxFilterLen)
+		}
+	}
+	f := &ContainerFilter{}
+	var err error
+	if f.includeNamespaces, err = parseNamespaces(opts.IncludeNamespaces); err != nil {
+		return nil, err
+	}
+	if f.excludeNamespaces, err = parseNamespaces(opts.ExcludeNamespaces); err != nil {
+		return nil, err
+	}
+	if f.includePod, err = compileRegex(opts.IncludePodRegex); err != nil {
+		return nil, err
+	}
+	if f.excludePod, err = compileRegex(opts.ExcludePodRegex); err != nil {
+		return nil, err
+	}
+	if f.includeLabels, err = parseLabels(opts.IncludeLabels); err != nil {
+		return nil, err
+	}
+	if f.excludeLabels, err = parseLabels(opts.ExcludeLabels); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Match returns whether the container should be monitored, a nil filter matches all containers
+func (f *ContainerFilter) Match(c *CommonContainer) bool {
+	if f == nil {
+		return true
+	}
+	ns, nsOK := c.Labels[labelK8sPodNamespace]
+	if len(f.includeNamespaces) != 0 {
+		if _, ok := f.includeNamespaces[ns]; !ok || !nsOK {
+			return false
+		}
+	}
+	if _, ok := f.excludeNamespaces[ns]; ok && nsOK {
+		return false
+	}
+	pod, podOK := c.Labels[labelK8sPodName]
+	if f.includePod != nil && (!podOK || !f.includePod.MatchString(pod)) {
+		return false
+	}
+	if f.excludePod != nil && podOK && f.excludePod.MatchString(pod) {
+		return false
+	}
+	for k, v := range f.includeLabels {
+		if !hasLabel(c.Labels, k, v) {
+			return false
+		}
+	}
+	for k, v := range f.excludeLabels {
+		if hasLabel(c.Labels, k, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasLabel returns whether the labels contain the key, and the value is equal if the given value is not empty
+func hasLabel(labels map[string]string, key, value string) bool {
+	v, ok := labels[key]
+	return ok && (value == "" || v == value)
+}
+
+func splitFilter(value string) ([]string, error) {
+	var items []string
+	for _, item := range strings.Split(value, filterSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	if len(items) > maxFilterItemNum {
+		return nil, fmt.Errorf("the number of container filter items is more than %d", maxFilterItemNum)
+	}
+	return items, nil
+}
+
+func parseNamespaces(value string) (map[string]struct{}, error) {
+	items, err := splitFilter(value)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make(map[string]struct{}, len(items))
+	for _, ns := range items {
+		if err := validDNSRe(ns); err != nil {
+			return nil, fmt.Errorf("invalid namespace (%s) in container filter: %v", ns, err)
+		}
+		namespaces[ns] = struct{}{}
+	}
+	return namespaces, nil
+}
+
+func compileRegex(value string) (*regexp.Regexp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod regex (%s) in container filter: %v", value, err)
+	}
+	return re, nil
+}
+
+func parseLabels(value string) (map[string]string, error) {
+	items, err := splitFilter(value)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]string, len(items))
+	for _, item := range items {
+		kv := strings.SplitN(item, labelValueSeparator, labelWithValueLength)
+		key := strings.TrimSpace(kv[0])
+		if key == "" {
+			return nil, fmt.Errorf("invalid label (%s) in container filter", item)
+		}
+		if len(kv) == labelWithValueLength {
+			labels[key] = strings.TrimSpace(kv[1])
+			continue
+		}
+		labels[key] = ""
+	}
+	return labels, nil
+}
@@ -0,0 +1,135 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container for monitoring containers' npu allocation
+package container
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mockK8sContainer(ns, pod string, labels map[string]string) *CommonContainer {
+	c := &CommonContainer{Id: ns + pod, Labels: map[string]string{labelK8sPodNamespace: ns, labelK8sPodName: pod}}
+	for k, v := range labels {
+		c.Labels[k] = v
+	}
+	return c
+}
+
+// TestContainerFilterMatch test ContainerFilter.Match
+func TestContainerFilterMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		opts FilterOpts
+		c    *CommonContainer
+		want bool
+	}{
+		{
+			name: "should match when filter is not set",
+			c:    mockK8sContainer("kube-system", "coredns", nil),
+			want: true,
+		},
+		{
+			name: "should not match when namespace is not included",
+			opts: FilterOpts{IncludeNamespaces: "train,infer"},
+			c:    mockK8sContainer("kube-system", "coredns", nil),
+			want: false,
+		},
+		{
+			name: "should match when namespace is included",
+			opts: FilterOpts{IncludeNamespaces: "train, infer"},
+			c:    mockK8sContainer("infer", "server", nil),
+			want: true,
+		},
+		{
+			name: "should not match when namespace is excluded",
+			opts: FilterOpts{ExcludeNamespaces: "kube-system"},
+			c:    mockK8sContainer("kube-system", "coredns", nil),
+			want: false,
+		},
+		{
+			name: "should not match when container without k8s labels and namespace is included",
+			opts: FilterOpts{IncludeNamespaces: "train"},
+			c:    &CommonContainer{Id: "1", Name: "bench"},
+			want: false,
+		},
+		{
+			name: "should match when container without k8s labels and namespace is excluded",
+			opts: FilterOpts{ExcludeNamespaces: "train"},
+			c:    &CommonContainer{Id: "1", Name: "bench"},
+			want: true,
+		},
+		{
+			name: "should not match when pod name does not match include regex",
+			opts: FilterOpts{IncludePodRegex: "^job-.*"},
+			c:    mockK8sContainer("train", "debug", nil),
+			want: false,
+		},
+		{
+			name: "should not match when pod name matches exclude regex",
+			opts: FilterOpts{IncludePodRegex: "^job-.*", ExcludePodRegex: "-canary$"},
+			c:    mockK8sContainer("train", "job-1-canary", nil),
+			want: false,
+		},
+		{
+			name: "should match when container has all include labels",
+			opts: FilterOpts{IncludeLabels: "app=mindspore,team"},
+			c:    mockK8sContainer("train", "job-1", map[string]string{"app": "mindspore", "team": "nlp"}),
+			want: true,
+		},
+		{
+			name: "should not match when label value is different",
+			opts: FilterOpts{IncludeLabels: "app=mindspore"},
+			c:    mockK8sContainer("train", "job-1", map[string]string{"app": "pytorch"}),
+			want: false,
+		},
+		{
+			name: "should not match when container has exclude label",
+			opts: FilterOpts{ExcludeLabels: "npu-exporter/ignore"},
+			c:    mockK8sContainer("train", "job-1", map[string]string{"npu-exporter/ignore": "true"}),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewContainerFilter(tt.opts)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, f.Match(tt.c))
+		})
+	}
+}
+
+// TestNewContainerFilter test NewContainerFilter with invalid options
+func TestNewContainerFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		opts FilterOpts
+	}{
+		{name: "should return error when regex is invalid", opts: FilterOpts{IncludePodRegex: "job-(["}},
+		{name: "should return error when namespace is invalid", opts: FilterOpts{ExcludeNamespaces: "Kube_System"}},
+		{name: "should return error when label key is empty", opts: FilterOpts{IncludeLabels: "=value"}},
+		{name: "should return error when filter is too long",
+			opts: FilterOpts{ExcludeLabels: strings.Repeat("a", maxFilterLen+1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewContainerFilter(tt.opts)
+			assert.NotNil(t, err)
+			assert.Nil(t, f)
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"sync"
 	"time"
 
+	"huawei.com/npu-exporter/v5/collector/container/docker"
 	"huawei.com/npu-exporter/v5/collector/container/isula"
 	"huawei.com/npu-exporter/v5/collector/container/v1"
 	"huawei.com/npu-exporter/v5/common-utils/hwlog"
@@ -51,6 +52,8 @@ const (
 	// EndpointTypeDockerd Docker with or without K8S
 	EndpointTypeDockerd
 	EndpointTypeIsula = 2
+	// EndpointTypeDockerAPI Docker Engine API without CRI
+	EndpointTypeDockerAPI = 3
 )
 
 var (
@@ -64,16 +67,17 @@ var (
 
 // CntNpuMonitorOpts contains setting options for monitoring containers
 type CntNpuMonitorOpts struct {
-	CriEndpoint  string // CRI server address
-	EndpointType int    // containerd or docker
-	OciEndpoint  string // OCI server, now is containerd address
-	UserBackUp   bool   // whether try to use backup address
+	CriEndpoint  string           // CRI server address
+	EndpointType int              // containerd or docker
+	OciEndpoint  string           // OCI server, now is containerd address
+	UserBackUp   bool             // whether try to use backup address
+	Filter       *ContainerFilter // filter of the containers to monitor, nil means all containers
 }
 
 // MakeDevicesParser evaluates option settings and make an instance according to it
 func MakeDevicesParser(opts CntNpuMonitorOpts) *DevicesParser {
 	runtimeOperator := &RuntimeOperatorTool{UseBackup: opts.UserBackUp}
-	parser := &DevicesParser{}
+	parser := &DevicesParser{Filter: opts.Filter}
 
 	switch opts.EndpointType {
 	case EndpointTypeContainerd:
@@ -91,6 +95,8 @@ func MakeDevicesParser(opts CntNpuMonitorOpts) *DevicesParser {
 		parser.RuntimeOperator = runtimeOperator
 		runtimeOperator.CriEndpoint = opts.CriEndpoint
 		runtimeOperator.OciEndpoint = opts.OciEndpoint
+	case EndpointTypeDockerAPI:
+		parser.RuntimeOperator = &DockerAPIOperator{Endpoint: opts.CriEndpoint}
 	default:
 		hwlog.RunLog.Errorf("Invalid type value %d", opts.EndpointType)
 	}
@@ -103,8 +109,11 @@ type DevicesInfo struct {
 	// container id
 	ID string
 	// container name, the format is: PodNameSpace_PodName_ContainerName
-	Name    string
-	Devices []int
+	Name string
+	// npu devices of container, normalized to physical IDs and virtual device IDs
+	Devices []NpuDevice
+	// number of devices which can not be matched with npu chips on the host or differ between device sources
+	MismatchNum int
 }
 
 // DevicesInfos the device information storage map
@@ -118,6 +127,8 @@ type DevicesParser struct {
 	// configuration
 	RuntimeOperator RuntimeOperator
 	Timeout         time.Duration
+	IDConverter     DeviceIDConverter
+	Filter          *ContainerFilter
 }
 
 // Init initializes connection to containerd daemon and to CRI server or dockerd daemon based on name fetcher setting
@@ -145,12 +156,20 @@ func (dp *DevicesParser) Close() {
 	_ = dp.RuntimeOperator.Close()
 }
 
+// parseDevices parse the npu devices of container, the sources of device IDs in order of precedence are:
+// 1. the env ASCEND_VISIBLE_DEVICES injected by Ascend Docker runtime, which carries logic IDs
+// 2. the pod annotations written by Ascend device plugin, huawei.com/AscendReal or
+// ascend.kubectl.kubernetes.io/ascend-910-configuration, only for container which has npu devices in its cgroup
+// 3. the char devices in cgroup device rules whose major ID is the one of devdrv-cdev in /proc/devices
 func (dp *DevicesParser) parseDevices(ctx context.Context, c *CommonContainer, rs chan<- DevicesInfo) error {
-	if dp.RuntimeOperator.GetContainerType() == IsulaContainer {
+	switch dp.RuntimeOperator.GetContainerType() {
+	case IsulaContainer:
 		return dp.parseDeviceInIsula(ctx, c, rs)
+	case DockerAPIContainer:
+		return dp.parseDeviceInDocker(ctx, c, rs)
+	default:
+		return dp.parseDevicesInContainerd(ctx, c, rs)
 	}
-
-	return dp.parseDevicesInContainerd(ctx, c, rs)
 }
 
 func (dp *DevicesParser) parseDevicesInContainerd(ctx context.Context, c *CommonContainer, rs chan<- DevicesInfo) error {
@@ -199,7 +218,7 @@ func (dp *DevicesParser) getDevicesWithoutAscendRuntime(spec v1.Spec, c *CommonC
 
 	if len(devicesIDs) != 0 {
 		if deviceInfo, err = makeUpDeviceInfo(c); err == nil {
-			deviceInfo.Devices = devicesIDs
+			deviceInfo.Devices, deviceInfo.MismatchNum = dp.selectDevices(c, devicesIDs)
 			return deviceInfo, nil
 		}
 		hwlog.RunLog.Error(err)
@@ -209,6 +228,31 @@ func (dp *DevicesParser) getDevicesWithoutAscendRuntime(spec v1.Spec, c *CommonC
 	return DevicesInfo{}, nil
 }
 
+// selectDevices choose the devices of container which has npu devices in its cgroup, the devices in pod
+// annotations take precedence over the minor numbers of cgroup device rules, but only the annotation devices which
+// are in the cgroup of the container are taken. The number of mismatched devices is returned too
+func (dp *DevicesParser) selectDevices(c *CommonContainer, cgroupDevIDs []int) ([]NpuDevice, int) {
+	cgroupDevs, mismatchNum := dp.normalizeDevices(newNpuDevices(cgroupDevIDs), sourcePhysical)
+	annoDevs, err := getDevicesFromAnnotations(c.Annotations)
+	if err != nil {
+		hwlog.RunLog.Debugf("get npu devices from annotations failed by container id (%s), err is %v", c.Id, err)
+		return cgroupDevs, mismatchNum
+	}
+	if len(annoDevs) == 0 {
+		return cgroupDevs, mismatchNum
+	}
+	annoDevs, annoMismatchNum := dp.normalizeDevices(annoDevs, sourcePhysical)
+	mismatchNum += annoMismatchNum
+	devices, diff := matchAnnotationDevices(cgroupDevs, annoDevs)
+	if diff != 0 {
+		hwlog.RunLog.Warnf("%d npu devices of %v in cgroup are not in annotations %v of container (%s)",
+			diff, cgroupDevs, annoDevs, c.Id)
+		mismatchNum += diff
+	}
+	hwlog.RunLog.Debugf("get npu devices %v from annotations in container (%s)", devices, c.Id)
+	return devices, mismatchNum
+}
+
 func (dp *DevicesParser) getDevicesWithAscendRuntime(ascendDevEnv string, c *CommonContainer) (DevicesInfo, error) {
 	hwlog.RunLog.Debugf("get device info by env (%s) in %s", ascendDevEnv, c.Id)
 	devInfo := strings.Split(ascendDevEnv, "=")
@@ -237,7 +281,7 @@ func (dp *DevicesParser) getDevicesWithAscendRuntime(ascendDevEnv string, c *Com
 		hwlog.RunLog.Error(err)
 		return DevicesInfo{}, err
 	}
-	deviceInfo.Devices = devicesIDs
+	deviceInfo.Devices, deviceInfo.MismatchNum = dp.normalizeDevices(newNpuDevices(devicesIDs), sourceLogic)
 	return deviceInfo, nil
 }
 
@@ -260,7 +304,7 @@ func (dp *DevicesParser) getDevWithoutAscendRuntimeInIsula(containerInfo isula.C
 		hwlog.RunLog.Error(err)
 		return DevicesInfo{}, err
 	}
-	deviceInfo.Devices = devicesIDs
+	deviceInfo.Devices, deviceInfo.MismatchNum = dp.selectDevices(c, devicesIDs)
 	return deviceInfo, nil
 }
 
@@ -297,6 +341,63 @@ func (dp *DevicesParser) parseDeviceInIsula(ctx context.Context, c *CommonContai
 	return err
 }
 
+func (dp *DevicesParser) parseDeviceInDocker(ctx context.Context, c *CommonContainer, rs chan<- DevicesInfo) error {
+	if rs == nil {
+		return errors.New("empty result channel")
+	}
+
+	deviceInfo := DevicesInfo{}
+	defer func(di *DevicesInfo) {
+		rs <- *di
+	}(&deviceInfo)
+
+	containerInfo, err := dp.RuntimeOperator.GetDockerContainerInfoByID(ctx, c.Id)
+	if err != nil {
+		return contactError(err, fmt.Sprintf("getting config of container(%s) fail", c.Id))
+	}
+	if containerInfo.HostConfig == nil || containerInfo.Config == nil {
+		return errors.New("empty container info")
+	}
+	if len(containerInfo.Config.Env) > maxEnvNum || len(containerInfo.HostConfig.Devices) > maxDevicesNum {
+		return fmt.Errorf("env or devices in container(%s) is too much", c.Id)
+	}
+
+	for _, env := range containerInfo.Config.Env {
+		if strings.Contains(env, ascendDeviceInfo) {
+			deviceInfo, err = dp.getDevicesWithAscendRuntime(env, c)
+			return err
+		}
+	}
+
+	deviceInfo, err = dp.getDevWithoutAscendRuntimeInDocker(containerInfo, c)
+	return err
+}
+
+func (dp *DevicesParser) getDevWithoutAscendRuntimeInDocker(containerInfo docker.ContainerJSON,
+	c *CommonContainer) (DevicesInfo, error) {
+	if containerInfo.HostConfig.Privileged {
+		hwlog.RunLog.Debugf("container (%s) is a privileged container and skip it", c.Id)
+		return DevicesInfo{}, nil
+	}
+	paths := make([]string, 0, len(containerInfo.HostConfig.Devices))
+	for _, dev := range containerInfo.HostConfig.Devices {
+		paths = append(paths, dev.PathOnHost)
+	}
+	devicesIDs := filterNPUDevicesByPath(paths)
+	hwlog.RunLog.Debugf("filter npu devices %v in container (%s)", devicesIDs, c.Id)
+	if len(devicesIDs) == 0 {
+		return DevicesInfo{}, nil
+	}
+
+	deviceInfo, err := makeUpDeviceInfo(c)
+	if err != nil {
+		hwlog.RunLog.Error(err)
+		return DevicesInfo{}, err
+	}
+	deviceInfo.Devices, deviceInfo.MismatchNum = dp.selectDevices(c, devicesIDs)
+	return deviceInfo, nil
+}
+
 func (dp *DevicesParser) collect(ctx context.Context, r <-chan DevicesInfo, ct int32) (DevicesInfos, error) {
 	if r == nil {
 		return nil, errors.New("receiving channel is empty")
@@ -343,6 +444,7 @@ func (dp *DevicesParser) doParse(resultOut chan<- DevicesInfos) {
 		dp.err <- err
 		return
 	}
+	containers = dp.filterContainers(containers)
 
 	l := len(containers)
 	if l == 0 || l > maxContainers {
@@ -376,6 +478,21 @@ func (dp *DevicesParser) doParse(resultOut chan<- DevicesInfos) {
 	wg.Wait()
 }
 
+// filterContainers drops the containers not matching the filter before querying their devices
+func (dp *DevicesParser) filterContainers(containers []*CommonContainer) []*CommonContainer {
+	if dp.Filter == nil {
+		return containers
+	}
+	filtered := make([]*CommonContainer, 0, len(containers))
+	for _, c := range containers {
+		if dp.Filter.Match(c) {
+			filtered = append(filtered, c)
+		}
+	}
+	hwlog.RunLog.Debugf("%d of %d containers are left after filtering", len(filtered), len(containers))
+	return filtered
+}
+
 // FetchAndParse triggers the asynchronous process of querying and analyzing all containers
 // resultOut channel is for fetching the current result
 func (dp *DevicesParser) FetchAndParse(resultOut chan<- DevicesInfos) {
@@ -386,6 +503,32 @@ func (dp *DevicesParser) FetchAndParse(resultOut chan<- DevicesInfos) {
 	go dp.doParse(resultOut)
 }
 
+// Parse queries and analyzes all containers and waits for the result, the parser should not be shared with the
+// caller of FetchAndParse, because the results of both are received from the same channels
+func (dp *DevicesParser) Parse(timeout time.Duration) (DevicesInfos, error) {
+	if dp.err == nil {
+		return nil, errors.New("device parser is not initialized")
+	}
+	// drop the result or the error left by the previous parsing which is timed out
+	select {
+	case <-dp.result:
+	default:
+	}
+	select {
+	case <-dp.err:
+	default:
+	}
+	dp.FetchAndParse(nil)
+	select {
+	case result := <-dp.result:
+		return result, nil
+	case err := <-dp.err:
+		return nil, err
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("parse devices of containers timeout after %v", timeout)
+	}
+}
+
 func withDefault(v time.Duration, d time.Duration) time.Duration {
 	if v == 0 {
 		return d
@@ -494,18 +637,25 @@ func filterNPUDevicesInIsula(containerInfo isula.ContainerJson) ([]int, error) {
 		return nil, errors.New("it's a privileged container and skip it")
 	}
 
+	paths := make([]string, 0, len(containerInfo.HostConfig.Devices))
+	for _, dev := range containerInfo.HostConfig.Devices {
+		paths = append(paths, dev.PathInContainer)
+	}
+	return filterNPUDevicesByPath(paths), nil
+}
+
+// filterNPUDevicesByPath get id of npu devices from device paths such as /dev/davinci0
+func filterNPUDevicesByPath(paths []string) []int {
 	devIDs := make([]int, 0, sliceLen8)
-	devices := containerInfo.HostConfig.Devices
-	for _, dev := range devices {
-		Id, err := getDevIdFromPath(devicePathPattern, dev.PathInContainer)
+	for _, path := range paths {
+		Id, err := getDevIdFromPath(devicePathPattern, path)
 		if err != nil {
-			hwlog.RunLog.Warn(err)
+			hwlog.RunLog.Debug(err)
 			continue
 		}
 		devIDs = append(devIDs, Id)
 	}
-
-	return devIDs, nil
+	return devIDs
 }
 
 func getDevIdFromPath(pattern, path string) (int, error) {
@@ -27,6 +27,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 
+	"huawei.com/npu-exporter/v5/collector/container/docker"
 	"huawei.com/npu-exporter/v5/collector/container/isula"
 	"huawei.com/npu-exporter/v5/collector/container/v1"
 	"huawei.com/npu-exporter/v5/common-utils/hwlog"
@@ -58,8 +59,12 @@ const (
 
 // CommonContainer wraps some common container attribute of isulad and containerd
 type CommonContainer struct {
-	Id     string
+	Id string
+	// Name name of the container given by docker, only set by docker engine api
+	Name   string
 	Labels map[string]string
+	// Annotations annotations of the container, merged with the annotations of its pod sandbox if any
+	Annotations map[string]string
 }
 
 // RuntimeOperator wraps operations against container runtime
@@ -69,6 +74,7 @@ type RuntimeOperator interface {
 	GetContainers(ctx context.Context) ([]*CommonContainer, error)
 	GetContainerInfoByID(ctx context.Context, id string) (v1.Spec, error)
 	GetIsulaContainerInfoByID(ctx context.Context, id string) (isula.ContainerJson, error)
+	GetDockerContainerInfoByID(ctx context.Context, id string) (docker.ContainerJSON, error)
 	GetContainerType() string
 }
 
@@ -259,6 +265,12 @@ func (operator *RuntimeOperatorTool) GetIsulaContainerInfoByID(ctx context.Conte
 	return containerJsonInfo, errors.New("unexpected isula client")
 }
 
+// GetDockerContainerInfoByID is not supported by CRI and OCI interface
+func (operator *RuntimeOperatorTool) GetDockerContainerInfoByID(_ context.Context,
+	_ string) (docker.ContainerJSON, error) {
+	return docker.ContainerJSON{}, errors.New("unexpected docker engine client")
+}
+
 func (operator *RuntimeOperatorTool) GetContainerType() string {
 	if operator.OciEndpoint == DefaultIsuladAddr {
 		return IsulaContainer
@@ -288,15 +300,50 @@ func getContainersByContainerd(ctx context.Context, client v1alpha2.RuntimeServi
 		hwlog.RunLog.Error(err)
 		return nil, err
 	}
+	podAnnotations := getPodAnnotations(ctx, client)
 	for _, container := range r.Containers {
 		allContainers = append(allContainers, &CommonContainer{
-			Id:     container.Id,
-			Labels: container.Labels,
+			Id:          container.Id,
+			Labels:      container.Labels,
+			Annotations: mergeAnnotations(container.Annotations, podAnnotations[container.PodSandboxId]),
 		})
 	}
 	return allContainers, nil
 }
 
+// getPodAnnotations returns the annotations of ready pod sandboxes, the key is pod sandbox id
+func getPodAnnotations(ctx context.Context, client v1alpha2.RuntimeServiceClient) map[string]map[string]string {
+	request := &v1alpha2.ListPodSandboxRequest{
+		Filter: &v1alpha2.PodSandboxFilter{
+			State: &v1alpha2.PodSandboxStateValue{State: v1alpha2.PodSandboxState_SANDBOX_READY},
+		},
+	}
+	r, err := client.ListPodSandbox(ctx, request)
+	if err != nil {
+		hwlog.RunLog.Warnf("list pod sandbox failed, pod annotations will not be used: %v", err)
+		return nil
+	}
+	podAnnotations := make(map[string]map[string]string, len(r.Items))
+	for _, pod := range r.Items {
+		podAnnotations[pod.Id] = pod.Annotations
+	}
+	return podAnnotations
+}
+
+func mergeAnnotations(cntAnnotations, podAnnotations map[string]string) map[string]string {
+	if len(podAnnotations) == 0 {
+		return cntAnnotations
+	}
+	annotations := make(map[string]string, len(cntAnnotations)+len(podAnnotations))
+	for k, v := range podAnnotations {
+		annotations[k] = v
+	}
+	for k, v := range cntAnnotations {
+		annotations[k] = v
+	}
+	return annotations
+}
+
 func getContainersByIsulad(ctx context.Context, client isula.RuntimeServiceClient) ([]*CommonContainer, error) {
 	var allContainers []*CommonContainer
 	request := genIsulaRequest()
@@ -307,8 +354,9 @@ func getContainersByIsulad(ctx context.Context, client isula.RuntimeServiceClien
 	}
 	for _, container := range r.Containers {
 		allContainers = append(allContainers, &CommonContainer{
-			Id:     container.Id,
-			Labels: container.Labels,
+			Id:          container.Id,
+			Labels:      container.Labels,
+			Annotations: container.Annotations,
 		})
 	}
 	return allContainers, nil
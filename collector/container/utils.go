@@ -45,6 +45,12 @@ const (
 
 	maxDevicesNum = 100000
 	maxEnvNum     = 10000
+
+	labelComposeProject = "com.docker.compose.project"
+	labelComposeService = "com.docker.compose.service"
+	// dockerNamePattern docker name pattern without '_', which is the separator of container name parts
+	dockerNamePattern = `^[a-zA-Z0-9][a-zA-Z0-9.-]*$`
+	maxLenDockerName  = 253
 )
 
 // CgroupVersion is the cgroups mode of the host system
@@ -119,6 +125,9 @@ func validDNSRe(dnsContent string) error {
 }
 
 func makeUpDeviceInfo(c *CommonContainer) (DevicesInfo, error) {
+	if _, ok := c.Labels[labelK8sPodNamespace]; !ok && c.Name != "" {
+		return makeUpDockerDeviceInfo(c)
+	}
 	deviceInfo := DevicesInfo{}
 	var names []string
 
@@ -138,3 +147,42 @@ func makeUpDeviceInfo(c *CommonContainer) (DevicesInfo, error) {
 	deviceInfo.Name = ns + "_" + podName + "_" + containerName
 	return deviceInfo, nil
 }
+
+// makeUpDockerDeviceInfo name the container without K8S labels by docker, the namespace is the docker compose
+// project, the pod name is the docker compose service, and both of them fall back to the docker name
+func makeUpDockerDeviceInfo(c *CommonContainer) (DevicesInfo, error) {
+	containerName := dockerNamePart(c.Name)
+	ns := dockerNamePart(c.Labels[labelComposeProject])
+	podName := dockerNamePart(c.Labels[labelComposeService])
+	if podName == "" {
+		podName = containerName
+	}
+	for _, v := range []string{ns, podName, containerName} {
+		if v == "" {
+			continue
+		}
+		if err := validDockerName(v); err != nil {
+			return DevicesInfo{}, err
+		}
+	}
+	if containerName == "" {
+		return DevicesInfo{}, errors.New("empty docker container name")
+	}
+
+	return DevicesInfo{ID: c.Id, Name: ns + "_" + podName + "_" + containerName}, nil
+}
+
+// dockerNamePart replace '_' in docker name, because it is the separator of container name parts
+func dockerNamePart(name string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(name, "/"), "_", "-")
+}
+
+func validDockerName(name string) error {
+	if len(name) > maxLenDockerName {
+		return errors.New("docker name len invalid")
+	}
+	if match, err := regexp.MatchString(dockerNamePattern, name); err != nil || !match {
+		return fmt.Errorf("docker name invalid, not meet requirement or match error: %v", err)
+	}
+	return nil
+}
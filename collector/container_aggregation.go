@@ -0,0 +1,203 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"huawei.com/npu-exporter/v5/collector/container"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+const kilo = 1024
+
+var (
+	containerLabels = []string{namespace, podName, "container_name"}
+	podLabels       = []string{namespace, podName}
+
+	containerNpuUtilSum = prometheus.NewDesc("container_npu_utilization_sum",
+		"the sum of npu ai core utilization in container, unit is '%'", containerLabels, nil)
+	containerNpuUtilAvg = prometheus.NewDesc("container_npu_utilization_avg",
+		"the average of npu ai core utilization in container, unit is '%'", containerLabels, nil)
+	containerNpuUsedMemorySum = prometheus.NewDesc("container_npu_used_memory_sum",
+		"the sum of npu used memory in container, unit is 'MB'", containerLabels, nil)
+	containerNpuTotalMemorySum = prometheus.NewDesc("container_npu_total_memory_sum",
+		"the sum of npu total memory in container, unit is 'MB'", containerLabels, nil)
+	containerNpuPowerSum = prometheus.NewDesc("container_npu_power_sum",
+		"the sum of npu power in container, unit is 'W'", containerLabels, nil)
+	containerNpuChipCount = prometheus.NewDesc("container_npu_chip_count",
+		"the number of npu chips and vNPUs in container", containerLabels, nil)
+	podNpuUtilSum = prometheus.NewDesc("pod_npu_utilization_sum",
+		"the sum of npu ai core utilization in pod, unit is '%'", podLabels, nil)
+	podNpuUtilAvg = prometheus.NewDesc("pod_npu_utilization_avg",
+		"the average of npu ai core utilization in pod, unit is '%'", podLabels, nil)
+	podNpuUsedMemorySum = prometheus.NewDesc("pod_npu_used_memory_sum",
+		"the sum of npu used memory in pod, unit is 'MB'", podLabels, nil)
+	podNpuTotalMemorySum = prometheus.NewDesc("pod_npu_total_memory_sum",
+		"the sum of npu total memory in pod, unit is 'MB'", podLabels, nil)
+	podNpuPowerSum = prometheus.NewDesc("pod_npu_power_sum",
+		"the sum of npu power in pod, unit is 'W'", podLabels, nil)
+	podNpuChipCount = prometheus.NewDesc("pod_npu_chip_count",
+		"the number of npu chips and vNPUs in pod", podLabels, nil)
+)
+
+// npuUsage the npu usage aggregated from the chips of a container or a pod
+type npuUsage struct {
+	labels      []string
+	utilSum     float64
+	usedMemory  float64
+	totalMemory float64
+	power       float64
+	// devices the keys of npu devices which have been aggregated, a device in several cards counts once
+	devices map[int]struct{}
+	// powers the power of chips or cards which have been aggregated, the vNPUs share the chip power
+	powers map[powerKey]struct{}
+}
+
+// powerKey locates the power reported for a chip, the power of Ascend310P is the power of its card, which is shared
+// by the chips on the card such as Atlas 300I Duo
+type powerKey struct {
+	cardID int
+	phyID  int
+}
+
+func chipPowerKey(cardID int, chip *HuaWeiAIChip) powerKey {
+	if chip.CardType == common.Ascend310P {
+		return powerKey{cardID: cardID, phyID: -1}
+	}
+	return powerKey{cardID: cardID, phyID: chip.DeviceID}
+}
+
+func newNpuUsage(labels []string) *npuUsage {
+	return &npuUsage{
+		labels:  labels,
+		devices: make(map[int]struct{}, initSize),
+		powers:  make(map[powerKey]struct{}, initSize),
+	}
+}
+
+func (u *npuUsage) add(chip *HuaWeiAIChip, cardID, deviceKey int) {
+	if _, ok := u.devices[deviceKey]; ok {
+		return
+	}
+	u.devices[deviceKey] = struct{}{}
+
+	util, used, total := getChipUsage(chip)
+	u.utilSum += util
+	u.usedMemory += used
+	u.totalMemory += total
+	key := chipPowerKey(cardID, chip)
+	if _, ok := u.powers[key]; !ok {
+		u.powers[key] = struct{}{}
+		u.power += float64(chip.Power)
+	}
+}
+
+func (u *npuUsage) utilAvg() float64 {
+	if len(u.devices) == 0 {
+		return 0
+	}
+	return u.utilSum / float64(len(u.devices))
+}
+
+// getChipUsage returns the ai core utilization, used memory and total memory of the chip or the vNPU
+func getChipUsage(chip *HuaWeiAIChip) (float64, float64, float64) {
+	if chip.VDevActivityInfo.IsVirtualDev {
+		return float64(chip.VDevActivityInfo.VDevAiCoreRate), float64(chip.VDevActivityInfo.VDevUsedMem) / kilo,
+			float64(chip.VDevActivityInfo.VDevTotalMem) / kilo
+	}
+	if chip.ChipIfo != nil && strings.Contains(chip.ChipIfo.Name, common.Chip910) && chip.HbmInfo != nil {
+		return float64(chip.Utilization), float64(chip.HbmInfo.Usage), float64(chip.HbmInfo.MemorySize)
+	}
+	if chip.Meminf != nil {
+		return float64(chip.Utilization), float64(chip.Meminf.MemorySize - chip.Meminf.MemoryAvailable),
+			float64(chip.Meminf.MemorySize)
+	}
+	return float64(chip.Utilization), 0, 0
+}
+
+// containerUsageAggregator aggregates the npu usage of chips by container and by pod, the chips of a container
+// may be in several cards
+type containerUsageAggregator struct {
+	containers map[string]*npuUsage
+	pods       map[string]*npuUsage
+}
+
+func newContainerUsageAggregator() *containerUsageAggregator {
+	return &containerUsageAggregator{
+		containers: make(map[string]*npuUsage, initSize),
+		pods:       make(map[string]*npuUsage, initSize),
+	}
+}
+
+func (a *containerUsageAggregator) add(chip *HuaWeiAIChip, cardID, deviceKey int, devInfo container.DevicesInfo) {
+	containerName := getContainerNameArray(devInfo)
+	if len(containerName) != containerNameLen {
+		return
+	}
+	cntUsage, ok := a.containers[devInfo.ID]
+	if !ok {
+		cntUsage = newNpuUsage(containerName)
+		a.containers[devInfo.ID] = cntUsage
+	}
+	cntUsage.add(chip, cardID, deviceKey)
+
+	podKey := containerName[nameSpaceIdx] + "_" + containerName[podNameIdx]
+	podUsage, ok := a.pods[podKey]
+	if !ok {
+		podUsage = newNpuUsage(containerName[:conNameIdx])
+		a.pods[podKey] = podUsage
+	}
+	podUsage.add(chip, cardID, deviceKey)
+}
+
+func (a *containerUsageAggregator) collect(ch chan<- prometheus.Metric) {
+	for _, usage := range a.containers {
+		collectNpuUsage(ch, usage, []*prometheus.Desc{containerNpuUtilSum, containerNpuUtilAvg,
+			containerNpuUsedMemorySum, containerNpuTotalMemorySum, containerNpuPowerSum, containerNpuChipCount})
+	}
+	for _, usage := range a.pods {
+		collectNpuUsage(ch, usage, []*prometheus.Desc{podNpuUtilSum, podNpuUtilAvg, podNpuUsedMemorySum,
+			podNpuTotalMemorySum, podNpuPowerSum, podNpuChipCount})
+	}
+}
+
+// collectNpuUsage sends the metrics of usage in the order of utilization sum, utilization average, used memory,
+// total memory, power and chip count
+func collectNpuUsage(ch chan<- prometheus.Metric, usage *npuUsage, descs []*prometheus.Desc) {
+	values := []float64{usage.utilSum, usage.utilAvg(), usage.usedMemory, usage.totalMemory, usage.power,
+		float64(len(usage.devices))}
+	for i, desc := range descs {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, values[i], usage.labels...)
+	}
+}
+
+func describeContainerUsage(ch chan<- *prometheus.Desc) {
+	ch <- containerNpuUtilSum
+	ch <- containerNpuUtilAvg
+	ch <- containerNpuUsedMemorySum
+	ch <- containerNpuTotalMemorySum
+	ch <- containerNpuPowerSum
+	ch <- containerNpuChipCount
+	ch <- podNpuUtilSum
+	ch <- podNpuUtilAvg
+	ch <- podNpuUsedMemorySum
+	ch <- podNpuTotalMemorySum
+	ch <- podNpuPowerSum
+	ch <- podNpuChipCount
+}
@@ -0,0 +1,105 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/collector/container"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+func mock910Chip(phyID, util int, power float32) *HuaWeiAIChip {
+	return &HuaWeiAIChip{
+		DeviceID:    phyID,
+		Utilization: util,
+		Power:       power,
+		ChipIfo:     &common.ChipInfo{Name: "910"},
+		HbmInfo:     &common.HbmInfo{MemorySize: 32768, Usage: 1024},
+		Meminf:      &common.MemoryInfo{},
+	}
+}
+
+func mock310PVNPU(phyID int, vDevID uint32, rate uint32) *HuaWeiAIChip {
+	return &HuaWeiAIChip{
+		DeviceID: phyID,
+		Power:    60,
+		ChipIfo:  &common.ChipInfo{Name: "310P3"},
+		VDevActivityInfo: common.VDevActivityInfo{VDevID: vDevID, VDevAiCoreRate: rate, VDevTotalMem: 4 * kilo * kilo,
+			VDevUsedMem: kilo * kilo, IsVirtualDev: true},
+	}
+}
+
+// TestContainerUsageAggregator test the aggregation of npu usage by container and pod
+func TestContainerUsageAggregator(t *testing.T) {
+	worker := container.DevicesInfo{ID: "c1", Name: "default_train_worker"}
+	sidecar := container.DevicesInfo{ID: "c2", Name: "default_train_sidecar"}
+	infer := container.DevicesInfo{ID: "c3", Name: "default_infer_server"}
+	a := newContainerUsageAggregator()
+	// the chips of worker are in two cards and the chip 1 is reported twice
+	a.add(mock910Chip(0, 20, 80), 0, 0, worker)
+	a.add(mock910Chip(1, 40, 90), 1, 1, worker)
+	a.add(mock910Chip(1, 40, 90), 1, 1, worker)
+	a.add(mock910Chip(8, 60, 70), 8, 8, sidecar)
+	// two vNPUs on the same 310P chip share the chip power
+	a.add(mock310PVNPU(2, 100, 10), 2, 100, infer)
+	a.add(mock310PVNPU(2, 101, 30), 2, 101, infer)
+	a.add(mock910Chip(3, 50, 50), 3, 3, container.DevicesInfo{})
+	// the two chips of an Atlas 300I Duo card carry the same card power
+	duo := container.DevicesInfo{ID: "c4", Name: "default_duo_server"}
+	for _, phyID := range []int{4, 5} {
+		chip := mock910Chip(phyID, 10, 72)
+		chip.CardType = common.Ascend310P
+		a.add(chip, 4, phyID, duo)
+	}
+
+	t.Run("should aggregate chips of container in several cards", func(t *testing.T) {
+		usage := a.containers["c1"]
+		assert.Equal(t, 2, len(usage.devices))
+		assert.Equal(t, float64(60), usage.utilSum)
+		assert.Equal(t, float64(30), usage.utilAvg())
+		assert.Equal(t, float64(2048), usage.usedMemory)
+		assert.Equal(t, float64(65536), usage.totalMemory)
+		assert.Equal(t, float64(170), usage.power)
+	})
+	t.Run("should aggregate containers of pod", func(t *testing.T) {
+		usage := a.pods["default_train"]
+		assert.Equal(t, []string{"default", "train"}, usage.labels)
+		assert.Equal(t, 3, len(usage.devices))
+		assert.Equal(t, float64(40), usage.utilAvg())
+		assert.Equal(t, float64(240), usage.power)
+	})
+	t.Run("should count chip power once when vNPUs are on the same chip", func(t *testing.T) {
+		usage := a.containers["c3"]
+		assert.Equal(t, 2, len(usage.devices))
+		assert.Equal(t, float64(20), usage.utilAvg())
+		assert.Equal(t, float64(2*kilo), usage.usedMemory)
+		assert.Equal(t, float64(8*kilo), usage.totalMemory)
+		assert.Equal(t, float64(60), usage.power)
+	})
+	t.Run("should count card power once when chips of 310P are on the same card", func(t *testing.T) {
+		usage := a.containers["c4"]
+		assert.Equal(t, 2, len(usage.devices))
+		assert.Equal(t, float64(72), usage.power)
+		assert.Equal(t, float64(72), a.pods["default_duo"].power)
+	})
+	t.Run("should skip chip when it is not used by container", func(t *testing.T) {
+		assert.Equal(t, 4, len(a.containers))
+		assert.Equal(t, 3, len(a.pods))
+	})
+}
@@ -0,0 +1,38 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"huawei.com/npu-exporter/v5/devmanager"
+)
+
+var dcmiErrorsTotal = prometheus.NewDesc("npu_exporter_dcmi_errors_total",
+	"the number of dcmi errors of each call and error class, the class is one of not_supported, busy, not_found, "+
+		"invalid_value, driver and other, the not supported call is not retried so it is counted once per chip",
+	[]string{"call", "class"}, nil)
+
+func describeDcmiErrors(ch chan<- *prometheus.Desc) {
+	ch <- dcmiErrorsTotal
+}
+
+func updateDcmiErrors(ch chan<- prometheus.Metric) {
+	for key, count := range devmanager.GetDcmiErrorCounts() {
+		ch <- prometheus.MustNewConstMetric(dcmiErrorsTotal, prometheus.CounterValue, float64(count), key.Call,
+			key.Class)
+	}
+}
@@ -0,0 +1,54 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/collector/container"
+	"huawei.com/npu-exporter/v5/common-utils/cache"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+// TestUpdateDcmiErrors test the dcmi errors are exported by call and class
+func TestUpdateDcmiErrors(t *testing.T) {
+	n := &npuCollector{cache: cache.New(cacheSize), cacheTime: time.Minute, devicesParser: makeMockDevicesParser()}
+	assert.Nil(t, n.cache.Set(npuListCacheKey, []HuaWeiNPUCard{}, n.cacheTime))
+	assert.Nil(t, n.cache.Set(containersDevicesCacheKey, container.DevicesInfos{}, n.cacheTime))
+	patch := gomonkey.ApplyFunc(devmanager.GetDcmiErrorCounts, func() map[devmanager.DcmiErrorKey]uint64 {
+		return map[devmanager.DcmiErrorKey]uint64{
+			{Call: "GetDeviceTemperature", Class: common.ErrClassNotSupported}: 1,
+			{Call: "GetDeviceHbmInfo", Class: common.ErrClassDriver}:           3,
+		}
+	})
+	defer patch.Reset()
+	t.Run("should export error count of each call and class when dcmi errors occur", func(t *testing.T) {
+		expected := `
+# HELP npu_exporter_dcmi_errors_total the number of dcmi errors of each call and error class, the class is one of not_supported, busy, not_found, invalid_value, driver and other, the not supported call is not retried so it is counted once per chip
+# TYPE npu_exporter_dcmi_errors_total counter
+npu_exporter_dcmi_errors_total{call="GetDeviceHbmInfo",class="driver"} 3
+npu_exporter_dcmi_errors_total{call="GetDeviceTemperature",class="not_supported"} 1
+`
+		assert.Nil(t, testutil.CollectAndCompare(n, strings.NewReader(expected), "npu_exporter_dcmi_errors_total"))
+	})
+}
@@ -0,0 +1,141 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+var (
+	hccsLaneLabels = append(append([]string{}, chipLabels...), "lane")
+
+	npuChipHccsTxBandwidth = prometheus.NewDesc("npu_chip_hccs_tx_bandwidth",
+		"the npu hccs transport speed of the lane, unit is 'MB/s'", hccsLaneLabels, nil)
+	npuChipHccsRxBandwidth = prometheus.NewDesc("npu_chip_hccs_rx_bandwidth",
+		"the npu hccs receive speed of the lane, unit is 'MB/s'", hccsLaneLabels, nil)
+	npuChipHccsTxNum = prometheus.NewDesc("npu_chip_hccs_tx_num",
+		"the npu hccs transport count of the lane since the chip started", hccsLaneLabels, nil)
+	npuChipHccsRxNum = prometheus.NewDesc("npu_chip_hccs_rx_num",
+		"the npu hccs receive count of the lane since the chip started", hccsLaneLabels, nil)
+	npuChipHccsCrcErrNum = prometheus.NewDesc("npu_chip_hccs_crc_err_num",
+		"the npu hccs crc error count of the lane since the chip started", hccsLaneLabels, nil)
+	npuChipHccsRetryNum = prometheus.NewDesc("npu_chip_hccs_retry_num",
+		"the npu hccs retry count of the lane since the chip started", hccsLaneLabels, nil)
+)
+
+// hccsInfoMap the hccs info sampled in background by logic id, since the query blocks for the bandwidth sampling
+var hccsInfoMap sync.Map
+
+func describeHccsInfo(ch chan<- *prometheus.Desc) {
+	ch <- npuChipHccsTxBandwidth
+	ch <- npuChipHccsRxBandwidth
+	ch <- npuChipHccsTxNum
+	ch <- npuChipHccsRxNum
+	ch <- npuChipHccsCrcErrNum
+	ch <- npuChipHccsRetryNum
+}
+
+// startToGetHccsInfo samples the hccs info of each chip of 910 series in its own goroutine, so that the blocking
+// query does not delay the collecting of the other information
+func startToGetHccsInfo(dmgr devmanager.DeviceInterface, updateTime time.Duration) {
+	cardNum, cards, err := dmgr.GetCardList()
+	if err != nil || cardNum == 0 {
+		hwlog.RunLog.Errorf("failed to get npu info, error is: %v", err)
+		return
+	}
+	for _, cardID := range cards {
+		if cardType := dmgr.GetCardType(cardID); cardType != common.Ascend910 && cardType != common.Ascend910B {
+			continue
+		}
+		deviceNum, err := dmgr.GetDeviceNumInCard(cardID)
+		if err != nil {
+			hwlog.RunLog.Errorf("get device num of card: %v failed: %v", cardID, err)
+			continue
+		}
+		for i := int32(0); i < deviceNum; i++ {
+			logicID, err := dmgr.GetDeviceLogicID(cardID, i)
+			if err != nil {
+				hwlog.RunLog.Errorf("get logic ID of card: %v device:%v failed: %v", cardID, i, err)
+				continue
+			}
+			go assembleHccsInfo(logicID, dmgr, updateTime)
+		}
+	}
+}
+
+func assembleHccsInfo(logicID int32, dmgr devmanager.DeviceInterface, updateTime time.Duration) {
+	for {
+		if hccsInfo := getHccsInfo(logicID, dmgr); hccsInfo != nil {
+			hccsInfoMap.Store(logicID, hccsInfo)
+		} else {
+			hccsInfoMap.Delete(logicID)
+		}
+		time.Sleep(updateTime)
+	}
+}
+
+func getHccsInfo(logicID int32, dmgr devmanager.DeviceInterface) *common.HccsInfo {
+	hccsInfo, err := dmgr.GetHccsInfo(logicID)
+	if err != nil {
+		// the drivers of the earlier versions do not support the query
+		hwlog.RunLog.Debugf("get hccs info of logic id %d failed: %v", logicID, err)
+		return nil
+	}
+	return &hccsInfo
+}
+
+// getHccsInfoFromMap returns the hccs info sampled last time, nil is returned before the first sampling
+func getHccsInfoFromMap(logicID int32) *common.HccsInfo {
+	value, ok := hccsInfoMap.Load(logicID)
+	if !ok {
+		return nil
+	}
+	hccsInfo, ok := value.(*common.HccsInfo)
+	if !ok {
+		hwlog.RunLog.Warnf("failed to get value of hccs info from map, which is: %v", value)
+		return nil
+	}
+	return hccsInfo
+}
+
+func updateHccsInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
+	if chip.HccsInfo == nil {
+		return
+	}
+	for _, lane := range chip.HccsInfo.Lanes {
+		labels := append(chipLabelValues(chip), strconv.FormatInt(int64(lane.Lane), base))
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipHccsTxBandwidth,
+			prometheus.GaugeValue, lane.TxBandwidth, labels...))
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipHccsRxBandwidth,
+			prometheus.GaugeValue, lane.RxBandwidth, labels...))
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipHccsTxNum,
+			prometheus.CounterValue, float64(lane.TxCnt), labels...))
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipHccsRxNum,
+			prometheus.CounterValue, float64(lane.RxCnt), labels...))
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipHccsCrcErrNum,
+			prometheus.CounterValue, float64(lane.CrcErrCnt), labels...))
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipHccsRetryNum,
+			prometheus.CounterValue, float64(lane.RetryCnt), labels...))
+	}
+}
@@ -0,0 +1,71 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+// TestUpdateHccsInfo test the hccs metrics of a chip
+func TestUpdateHccsInfo(t *testing.T) {
+	tests := []struct {
+		name      string
+		dmgr      devmanager.DeviceInterface
+		wantCount map[*prometheus.Desc]int
+	}{
+		{name: "should report every lane when hccs info is got", dmgr: &devmanager.DeviceManagerMock{},
+			wantCount: map[*prometheus.Desc]int{npuChipHccsTxBandwidth: 1, npuChipHccsRxBandwidth: 1,
+				npuChipHccsTxNum: 1, npuChipHccsRxNum: 1, npuChipHccsCrcErrNum: 1, npuChipHccsRetryNum: 1}},
+		{name: "should report nothing when hccs info is not supported", dmgr: &devmanager.DeviceManagerMockErr{},
+			wantCount: map[*prometheus.Desc]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chip := &HuaWeiAIChip{DeviceID: 1, ChipIfo: &common.ChipInfo{Name: "910B3"},
+				HccsInfo: getHccsInfo(0, tt.dmgr)}
+			const chanSize = 16
+			ch := make(chan prometheus.Metric, chanSize)
+			updateHccsInfo(ch, &HuaWeiNPUCard{Timestamp: time.Now()}, chip)
+			close(ch)
+			counts := make(map[*prometheus.Desc]int, chanSize)
+			for metric := range ch {
+				counts[metric.Desc()]++
+			}
+			assert.Equal(t, tt.wantCount, counts)
+		})
+	}
+}
+
+// TestGetHccsInfoFromMap test the hccs info sampled in background is read by the collecting loop
+func TestGetHccsInfoFromMap(t *testing.T) {
+	const logicID = 100
+	defer hccsInfoMap.Delete(int32(logicID))
+	t.Run("should return nil when hccs info is not sampled yet", func(t *testing.T) {
+		assert.Nil(t, getHccsInfoFromMap(logicID))
+	})
+	t.Run("should return the hccs info sampled last time", func(t *testing.T) {
+		hccsInfo := &common.HccsInfo{Lanes: []common.HccsLaneStat{{Lane: 1, TxCnt: 1}}}
+		hccsInfoMap.Store(int32(logicID), hccsInfo)
+		assert.Equal(t, hccsInfo, getHccsInfoFromMap(logicID))
+	})
+}
@@ -0,0 +1,236 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/common-utils/utils"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+	"huawei.com/npu-exporter/v5/devmanager/dcmi"
+	"huawei.com/npu-exporter/v5/devmanager/hccn"
+)
+
+const (
+	// DefaultInventoryUpdateTime the default interval of refreshing the chip inventory, which rarely changes
+	DefaultInventoryUpdateTime = time.Hour
+	// InventoryPath the url path of the chip inventory in json
+	InventoryPath = "/inventory"
+	// versionInfoFile the file recording the version of the driver or the firmware in the install path
+	versionInfoFile = "version.info"
+	// firmwareRelPath the firmware install path relative to the driver install path
+	firmwareRelPath    = "../firmware"
+	versionKey         = "version"
+	maxVersionInfoSize = 64 * 1024
+)
+
+var (
+	inventoryLabels = append(append([]string{}, chipLabels...), "logic_id", "chip_type", "chip_name",
+		"chip_version", "board_id", "pcb_id", "bom_id", "slot_id", "ndie_id", "driver_version", "firmware_version")
+
+	npuChipInventoryInfo = prometheus.NewDesc("npu_chip_inventory_info",
+		"the inventory of the npu with value '1', which is refreshed much less often than the other metrics",
+		inventoryLabels, nil)
+
+	inventory = &chipInventory{}
+)
+
+// ChipInventory the static and slow-changing information of a chip for asset management
+type ChipInventory struct {
+	LogicID         int32  `json:"logic_id"`
+	PhyID           int32  `json:"phy_id"`
+	CardID          int32  `json:"card_id"`
+	DeviceID        int32  `json:"device_id"`
+	ChipType        string `json:"chip_type"`
+	ChipName        string `json:"chip_name"`
+	ChipVersion     string `json:"chip_version"`
+	CardType        string `json:"card_type"`
+	ProductType     string `json:"product_type"`
+	BoardID         uint32 `json:"board_id"`
+	PcbID           uint32 `json:"pcb_id"`
+	BomID           uint32 `json:"bom_id"`
+	SlotID          uint32 `json:"slot_id"`
+	VDieID          string `json:"vdie_id"`
+	NDieID          string `json:"ndie_id"`
+	PCIeBusInfo     string `json:"pcie_bus_info"`
+	DriverVersion   string `json:"driver_version"`
+	FirmwareVersion string `json:"firmware_version"`
+}
+
+// InventoryReport the chip inventory served in json
+type InventoryReport struct {
+	// UpdateTime the time when the inventory is refreshed, zero before the first refresh
+	UpdateTime time.Time       `json:"update_time"`
+	Chips      []ChipInventory `json:"chips"`
+}
+
+// chipInventory the inventory shared by the refreshing goroutine, Collect and the json handler
+type chipInventory struct {
+	lock   sync.RWMutex
+	report InventoryReport
+}
+
+func (c *chipInventory) set(chips []ChipInventory, updateTime time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.report = InventoryReport{UpdateTime: updateTime, Chips: chips}
+}
+
+func (c *chipInventory) get() InventoryReport {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	report := c.report
+	report.Chips = append([]ChipInventory{}, c.report.Chips...)
+	return report
+}
+
+// InventoryHandler serves the chip inventory in json
+func InventoryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(inventory.get()); err != nil {
+		hwlog.RunLog.Errorf("write inventory response failed: %v", err)
+	}
+}
+
+func inventoryCollect(group *sync.WaitGroup, dmgr devmanager.DeviceInterface, updateTime time.Duration) {
+	group.Add(1)
+	go func() {
+		defer group.Done()
+		ticker := time.NewTicker(updateTime)
+		defer ticker.Stop()
+		for {
+			inventory.set(getChipInventory(dmgr), time.Now())
+			hwlog.RunLog.Infof("update chip inventory, next update is after %v", updateTime)
+			if _, ok := <-ticker.C; !ok {
+				hwlog.RunLog.Error("inventory ticker failed, task shutdown")
+				return
+			}
+		}
+	}()
+}
+
+func getChipInventory(dmgr devmanager.DeviceInterface) []ChipInventory {
+	var chips []ChipInventory
+	cardNum, cards, err := dmgr.GetCardList()
+	if err != nil || cardNum == 0 {
+		hwlog.RunLog.Errorf("failed to get card list for inventory, error is: %v", err)
+		return chips
+	}
+	driverPath := hccn.DriverPath()
+	driverVersion := readVersionInfo(filepath.Join(driverPath, versionInfoFile))
+	firmwareVersion := readVersionInfo(filepath.Join(driverPath, firmwareRelPath, versionInfoFile))
+	for _, cardID := range cards {
+		deviceNum, err := dmgr.GetDeviceNumInCard(cardID)
+		if err != nil {
+			hwlog.RunLog.Errorf("get device num of card %v failed: %v", cardID, err)
+			continue
+		}
+		for i := int32(0); i < deviceNum; i++ {
+			logicID, err := dmgr.GetDeviceLogicID(cardID, i)
+			if err != nil {
+				hwlog.RunLog.Errorf("get logic ID of card %v device %v failed: %v", cardID, i, err)
+				continue
+			}
+			chip, err := getChipInventoryOfChip(dmgr, cardID, i, logicID)
+			if err != nil {
+				hwlog.RunLog.Errorf("get inventory of logic ID %d failed: %v", logicID, err)
+				continue
+			}
+			chip.DriverVersion, chip.FirmwareVersion = driverVersion, firmwareVersion
+			chips = append(chips, chip)
+		}
+	}
+	return chips
+}
+
+func getChipInventoryOfChip(dmgr devmanager.DeviceInterface, cardID, deviceID, logicID int32) (ChipInventory,
+	error) {
+	phyID, err := dmgr.GetPhysicIDFromLogicID(logicID)
+	if err != nil {
+		return ChipInventory{}, err
+	}
+	chip := ChipInventory{LogicID: logicID, PhyID: phyID, CardID: cardID, DeviceID: deviceID,
+		CardType: dmgr.GetCardType(cardID)}
+	if chipInfo, err := dmgr.GetChipInfo(logicID); err == nil {
+		chip.ChipType, chip.ChipName, chip.ChipVersion = chipInfo.Type, chipInfo.Name, chipInfo.Version
+	}
+	if chip.ProductType, err = dmgr.GetProductType(cardID, deviceID); err != nil {
+		hwlog.RunLog.Debugf("get product type of logic ID %d failed: %v", logicID, err)
+		chip.ProductType = dmgr.GetCardProductType(cardID)
+	}
+	if boardInfo, err := dmgr.GetBoardInfo(logicID); err == nil {
+		chip.BoardID, chip.PcbID, chip.BomID, chip.SlotID = boardInfo.BoardId, boardInfo.PcbId, boardInfo.BomId,
+			boardInfo.SlotId
+	}
+	if chip.VDieID, err = dmgr.GetDieID(logicID, dcmi.VDIE); err != nil {
+		hwlog.RunLog.Debugf("get vdie id of logic ID %d failed: %v", logicID, err)
+	}
+	if chip.NDieID, err = dmgr.GetDieID(logicID, dcmi.NDIE); err != nil {
+		hwlog.RunLog.Debugf("get ndie id of logic ID %d failed: %v", logicID, err)
+	}
+	if chip.PCIeBusInfo, err = dmgr.GetPCIeBusInfo(logicID); err != nil {
+		hwlog.RunLog.Debugf("get pcie bus info of logic ID %d failed: %v", logicID, err)
+	}
+	return chip, nil
+}
+
+// readVersionInfo reads the version from the version.info file of the driver or the firmware, whose lines are in
+// the format of key=value, empty is returned if the file is missing
+func readVersionInfo(path string) string {
+	data, err := utils.ReadLimitBytes(path, maxVersionInfoSize)
+	if err != nil {
+		hwlog.RunLog.Debugf("read version info %s failed: %v", path, err)
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) == 2 && strings.EqualFold(strings.TrimSpace(kv[0]), versionKey) {
+			return strings.TrimSpace(kv[1])
+		}
+	}
+	return ""
+}
+
+func describeInventoryInfo(ch chan<- *prometheus.Desc) {
+	ch <- npuChipInventoryInfo
+}
+
+func updateInventoryInfo(ch chan<- prometheus.Metric) {
+	for _, chip := range inventory.get().Chips {
+		ch <- prometheus.MustNewConstMetric(npuChipInventoryInfo, prometheus.GaugeValue, 1,
+			strconv.FormatInt(int64(chip.PhyID), base),
+			common.GetNpuName(common.ChipInfo{Type: chip.ChipType, Name: chip.ChipName, Version: chip.ChipVersion}),
+			chip.VDieID, chip.PCIeBusInfo, chip.CardType, chip.ProductType, strconv.FormatInt(int64(chip.LogicID), base),
+			chip.ChipType, chip.ChipName, chip.ChipVersion, strconv.FormatUint(uint64(chip.BoardID), base),
+			strconv.FormatUint(uint64(chip.PcbID), base), strconv.FormatUint(uint64(chip.BomID), base),
+			strconv.FormatUint(uint64(chip.SlotID), base), chip.NDieID, chip.DriverVersion, chip.FirmwareVersion)
+	}
+}
@@ -0,0 +1,118 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+	"huawei.com/npu-exporter/v5/devmanager/hccn"
+)
+
+// TestReadVersionInfo test readVersionInfo
+func TestReadVersionInfo(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "should return version when version key exists",
+			content: "package_name=Ascend-hdk-910b-npu-driver\nVersion=23.0.rc2\n", want: "23.0.rc2"},
+		{name: "should ignore case and spaces when version key is lower case",
+			content: "version = 6.4.12.1.241\n", want: "6.4.12.1.241"},
+		{name: "should return empty when version key is missing", content: "package_name=driver\n", want: ""},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.want, readVersionInfo(path), "case %d", i)
+		})
+	}
+	t.Run("should return empty when file is missing", func(t *testing.T) {
+		assert.Equal(t, "", readVersionInfo(filepath.Join(dir, "missing")))
+	})
+}
+
+// TestGetChipInventory test getChipInventory
+func TestGetChipInventory(t *testing.T) {
+	driverPath := filepath.Join(t.TempDir(), "driver")
+	firmwarePath := filepath.Join(filepath.Dir(driverPath), "firmware")
+	for path, content := range map[string]string{driverPath: "Version=23.0.rc2\n",
+		firmwarePath: "Version=6.4.12.1.241\n"} {
+		if err := os.MkdirAll(path, 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(path, versionInfoFile), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv(hccn.DriverPathEnv, driverPath)
+	t.Run("should return inventory of all chips when dcmi works normally", func(t *testing.T) {
+		chips := getChipInventory(&devmanager.DeviceManagerMock{})
+		assert.Equal(t, []ChipInventory{{LogicID: 1, PhyID: 1, CardID: 0, DeviceID: 0, ChipType: "ascend",
+			ChipName: common.Chip910, ChipVersion: "v1", CardType: common.Ascend910,
+			VDieID: "ABCDEFGHIGKLMNOPQRSTUVWXYZ01234567890123", NDieID: "ABCDEFGHIGKLMNOPQRSTUVWXYZ01234567890123",
+			PCIeBusInfo: "0000:61:00.0", DriverVersion: "23.0.rc2", FirmwareVersion: "6.4.12.1.241"}}, chips)
+	})
+	t.Run("should return empty when card list is unavailable", func(t *testing.T) {
+		assert.Empty(t, getChipInventory(&devmanager.DeviceManagerMockErr{}))
+	})
+}
+
+// TestInventoryInfo test the inventory is exported as the metric and json
+func TestInventoryInfo(t *testing.T) {
+	defer inventory.set(nil, time.Time{})
+	updateTime := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+	inventory.set([]ChipInventory{{LogicID: 0, PhyID: 0, ChipName: "910B3", CardType: common.Ascend910B,
+		DriverVersion: "23.0.rc2"}}, updateTime)
+	t.Run("should export inventory info metric when inventory is refreshed", func(t *testing.T) {
+		const chanSize = 4
+		ch := make(chan prometheus.Metric, chanSize)
+		updateInventoryInfo(ch)
+		close(ch)
+		assert.Len(t, ch, 1)
+	})
+	t.Run("should serve inventory in json when method is get", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		InventoryHandler(w, httptest.NewRequest(http.MethodGet, InventoryPath, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		var report InventoryReport
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.True(t, updateTime.Equal(report.UpdateTime))
+		if assert.Len(t, report.Chips, 1) {
+			assert.Equal(t, "23.0.rc2", report.Chips[0].DriverVersion)
+		}
+	})
+	t.Run("should reject request when method is not get", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		InventoryHandler(w, httptest.NewRequest(http.MethodPost, InventoryPath, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
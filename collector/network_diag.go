@@ -0,0 +1,197 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+	"huawei.com/npu-exporter/v5/devmanager/hccn"
+)
+
+const (
+	// diagUpdateTime the interval of collecting the diagnostic information of network port
+	diagUpdateTime = time.Minute
+	// maxDiagEntries the max number of the arp and route entries exported for each chip
+	maxDiagEntries = 64
+)
+
+var (
+	npuChipLinkDownNum = prometheus.NewDesc("npu_chip_link_down_num",
+		"the npu interface receive link-down num", chipLabels, nil)
+	npuChipLinkFlapTotal = prometheus.NewDesc("npu_chip_link_flap_total",
+		"the times of npu interface link status transitions observed since the exporter started",
+		chipLabels, nil)
+	npuChipLLDPNeighborInfo = prometheus.NewDesc("npu_chip_lldp_neighbor_info",
+		"the neighbor of npu interface discovered by lldp with value '1'", append(append([]string{}, chipLabels...),
+			"chassis_id", "port_id", "system_name", "port_description", "management_address"), nil)
+	npuChipIPInfo = prometheus.NewDesc("npu_chip_ip_info",
+		"the ip address of npu interface with value '1'", append(append([]string{}, chipLabels...),
+			"ip", "netmask", "gateway", "ipv6"), nil)
+	npuChipARPInfo = prometheus.NewDesc("npu_chip_arp_info",
+		"the arp entry of npu interface with value '1'", append(append([]string{}, chipLabels...), "ip", "mac"),
+		nil)
+	npuChipRouteInfo = prometheus.NewDesc("npu_chip_route_info",
+		"the route entry of npu interface with value '1'", append(append([]string{}, chipLabels...),
+			"destination", "gateway", "iface"), nil)
+)
+
+func describeNetworkDiagInfo(ch chan<- *prometheus.Desc) {
+	ch <- npuChipLinkDownNum
+	ch <- npuChipLinkFlapTotal
+	ch <- npuChipLLDPNeighborInfo
+	ch <- npuChipIPInfo
+	ch <- npuChipARPInfo
+	ch <- npuChipRouteInfo
+}
+
+// diagCycle returns the number of network updates between two diagnostic information updates
+func diagCycle(updateTime time.Duration) int {
+	if updateTime <= 0 || updateTime >= diagUpdateTime {
+		return 1
+	}
+	return int(diagUpdateTime / updateTime)
+}
+
+// linkFlapPackInfo counts a flap when the link status collected by networkPackInfo differs from the one observed
+// last time, the empty status of the failed query keeps the last status so that it is not taken as a flap
+func linkFlapPackInfo(phyID int32, status string, last LinkStatInfo) (float64, string) {
+	if status == "" {
+		return last.LinkFlapNum, last.LinkStatus
+	}
+	if last.LinkStatus != "" && last.LinkStatus != status {
+		hwlog.RunLog.Warnf("link status of npu %d changed from %s to %s", phyID, last.LinkStatus, status)
+		return last.LinkFlapNum + 1, status
+	}
+	return last.LinkFlapNum, status
+}
+
+// diagPackInfo gets the diagnostic information of network port, the ip addresses which can not be got by hccn are
+// got by dcmi
+func diagPackInfo(phyID int32, dmgr devmanager.DeviceInterface) DiagInfo {
+	diagInfo := DiagInfo{}
+	if lldpInfo, err := hccn.GetNPULLDPInfo(phyID); err == nil {
+		diagInfo.LLDPInfo = lldpInfo
+	}
+	if ipInfo, err := hccn.GetNPUIPInfo(phyID); err == nil {
+		diagInfo.IPInfo = ipInfo
+	}
+	if entries, err := hccn.GetNPUARPTable(phyID); err == nil {
+		if len(entries) > maxDiagEntries {
+			hwlog.RunLog.Warnf("npu %d has %d arp entries, only %d are exported", phyID, len(entries), maxDiagEntries)
+			entries = entries[:maxDiagEntries]
+		}
+		diagInfo.ARPEntries = entries
+	}
+	if routes, err := hccn.GetNPURouteTable(phyID); err == nil {
+		if len(routes) > maxDiagEntries {
+			hwlog.RunLog.Warnf("npu %d has %d routes, only %d are exported", phyID, len(routes), maxDiagEntries)
+			routes = routes[:maxDiagEntries]
+		}
+		diagInfo.Routes = routes
+	}
+	logicID, err := dmgr.GetLogicIDFromPhysicID(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("failed to get logic id of npu %d when assemble diag info: %v", phyID, err)
+		return diagInfo
+	}
+	if diagInfo.IPInfo.IP == "" {
+		if ip, err := dmgr.GetDeviceIPAddress(logicID, common.IPAddrTypeV4); err == nil {
+			diagInfo.IPInfo.IP = ip
+		}
+	}
+	if ip, err := dmgr.GetDeviceIPAddress(logicID, common.IPAddrTypeV6); err == nil {
+		diagInfo.IPv6 = ip
+	}
+	return diagInfo
+}
+
+// statResetPackInfo keeps the last packet counters when the sampling fails, so that the failure is not taken as a
+// counter reset, and counts the resets detected between the samples
+func statResetPackInfo(phyID int32, netInfo *NpuNetInfo, last NpuNetInfo) {
+	netInfo.StatResetNum = last.StatResetNum
+	if netInfo.StatSample.Time.IsZero() {
+		netInfo.StatInfo, netInfo.StatSample = last.StatInfo, last.StatSample
+		return
+	}
+	if hccn.NewStatWindow(last.StatSample, netInfo.StatSample).Reset {
+		hwlog.RunLog.Warnf("packet counters of npu %d are reset", phyID)
+		netInfo.StatResetNum++
+	}
+}
+
+// updateNetInfo gets the network information which is updated every time and keeps the link flap count and the
+// last packet counters, the diagnostic information is updated only when withDiag is true
+func updateNetInfo(phyID int32, dmgr devmanager.DeviceInterface, last NpuNetInfo, withDiag bool) NpuNetInfo {
+	netInfo := networkPackInfo(phyID)
+	netInfo.LinkStatInfo.LinkFlapNum, netInfo.LinkStatInfo.LinkStatus = linkFlapPackInfo(phyID,
+		netInfo.LinkStatInfo.LinkStatus, last.LinkStatInfo)
+	statResetPackInfo(phyID, &netInfo, last)
+	netInfo.DiagInfo = last.DiagInfo
+	if withDiag {
+		netInfo.DiagInfo = diagPackInfo(phyID, dmgr)
+	}
+	return netInfo
+}
+
+func updateNetworkDiagInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
+	labels := chipLabelValues(chip)
+	withLabels := func(values ...string) []string {
+		return append(append(make([]string, 0, len(labels)+len(values)), labels...), values...)
+	}
+	linkStatInfo := chip.NetInfo.LinkStatInfo
+	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
+		prometheus.MustNewConstMetric(npuChipLinkDownNum, prometheus.GaugeValue, linkStatInfo.LinkDownNum, labels...))
+	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
+		prometheus.MustNewConstMetric(npuChipLinkFlapTotal, prometheus.CounterValue, linkStatInfo.LinkFlapNum,
+			labels...))
+	diagInfo := chip.NetInfo.DiagInfo
+	if lldp := diagInfo.LLDPInfo; lldp.ChassisID != "" || lldp.PortID != "" {
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
+			prometheus.MustNewConstMetric(npuChipLLDPNeighborInfo, prometheus.GaugeValue, 1, withLabels(lldp.ChassisID,
+				lldp.PortID, lldp.SystemName, lldp.PortDescription, lldp.ManagementAddress)...))
+	}
+	if ip := diagInfo.IPInfo; ip.IP != "" || diagInfo.IPv6 != "" {
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
+			prometheus.MustNewConstMetric(npuChipIPInfo, prometheus.GaugeValue, 1, withLabels(ip.IP, ip.Netmask,
+				ip.Gateway, diagInfo.IPv6)...))
+	}
+	// the duplicated entries are reported once, since the metrics with the same labels can not be collected twice
+	reported := make(map[string]struct{}, len(diagInfo.ARPEntries)+len(diagInfo.Routes))
+	for _, entry := range diagInfo.ARPEntries {
+		key := "arp " + entry.IP + " " + entry.MAC
+		if _, ok := reported[key]; ok {
+			continue
+		}
+		reported[key] = struct{}{}
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
+			prometheus.MustNewConstMetric(npuChipARPInfo, prometheus.GaugeValue, 1, withLabels(entry.IP, entry.MAC)...))
+	}
+	for _, route := range diagInfo.Routes {
+		key := "route " + route.Destination + " " + route.Gateway + " " + route.Iface
+		if _, ok := reported[key]; ok {
+			continue
+		}
+		reported[key] = struct{}{}
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
+			prometheus.MustNewConstMetric(npuChipRouteInfo, prometheus.GaugeValue, 1, withLabels(route.Destination,
+				route.Gateway, route.Iface)...))
+	}
+}
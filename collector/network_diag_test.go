@@ -0,0 +1,136 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+	"huawei.com/npu-exporter/v5/devmanager/hccn"
+	"huawei.com/npu-exporter/v5/devmanager/hccn/parser"
+)
+
+func newDiagRunner() *hccn.FakeRunner {
+	return &hccn.FakeRunner{Outputs: map[string]string{
+		"-link":      "link status: UP\n",
+		"-link_stat": "link up count : 3\nlink down count : 2\n",
+		"-lldp":      "Chassis ID TLV\n\tMAC: 00:18:82:00:00:01\nPort ID TLV\n\tIfname: 100GE1/0/1\n",
+		"-ip":        "ipaddr:192.168.100.101\nnetmask:255.255.255.0\n",
+		"-gateway":   "default gateway:192.168.100.1\n",
+		"-arp": "ip_addr:192.168.100.1 mac_addr:00:18:82:00:00:01\n" +
+			"ip_addr:192.168.100.1 mac_addr:00:18:82:00:00:01\n",
+		"-route": "0.0.0.0 192.168.100.1 0.0.0.0 UG 0 0 0 eth0\n",
+		"-stat":  "roce_rx_err_pkt_num:10\n",
+	}}
+}
+
+// TestUpdateNetInfo test the link flaps are counted and the diagnostic information is kept between the updates
+func TestUpdateNetInfo(t *testing.T) {
+	runner := newDiagRunner()
+	defer hccn.SetTool(hccn.GetTool())
+	hccn.SetTool(hccn.NewTool(context.Background(), runner))
+	dmgr := &devmanager.DeviceManagerMock{}
+	netInfo := updateNetInfo(1, dmgr, NpuNetInfo{}, true)
+	t.Run("should get diagnostic information when hccn_tool works normally", func(t *testing.T) {
+		assert.Equal(t, 2.0, netInfo.LinkStatInfo.LinkDownNum)
+		assert.Equal(t, "00:18:82:00:00:01", netInfo.DiagInfo.LLDPInfo.ChassisID)
+		assert.Equal(t, parser.IPInfo{IP: "192.168.100.101", Netmask: "255.255.255.0", Gateway: "192.168.100.1"},
+			netInfo.DiagInfo.IPInfo)
+		assert.Equal(t, "::1", netInfo.DiagInfo.IPv6)
+		assert.Len(t, netInfo.DiagInfo.ARPEntries, 2)
+		assert.Equal(t, []parser.Route{{Destination: "0.0.0.0/0", Gateway: "192.168.100.1", Iface: "eth0"}},
+			netInfo.DiagInfo.Routes)
+		assert.Equal(t, 0.0, netInfo.LinkStatInfo.LinkFlapNum)
+	})
+	t.Run("should count flaps and keep diagnostic information when link status changes", func(t *testing.T) {
+		runner.Outputs["-link"] = "link status: DOWN\n"
+		delete(runner.Outputs, "-lldp")
+		netInfo = updateNetInfo(1, dmgr, netInfo, false)
+		runner.Outputs["-link"] = "link status: UP\n"
+		netInfo = updateNetInfo(1, dmgr, netInfo, false)
+		assert.Equal(t, 2.0, netInfo.LinkStatInfo.LinkFlapNum)
+		assert.Equal(t, LinkUp, netInfo.LinkStatInfo.LinkStatus)
+		assert.Equal(t, "00:18:82:00:00:01", netInfo.DiagInfo.LLDPInfo.ChassisID)
+	})
+	t.Run("should not count flap when link status query fails", func(t *testing.T) {
+		delete(runner.Outputs, "-link")
+		netInfo = updateNetInfo(1, dmgr, netInfo, true)
+		assert.Equal(t, 2.0, netInfo.LinkStatInfo.LinkFlapNum)
+		assert.Equal(t, LinkUp, netInfo.LinkStatInfo.LinkStatus)
+		assert.Empty(t, netInfo.DiagInfo.LLDPInfo.ChassisID)
+	})
+	t.Run("should get ipv4 address by dcmi when hccn_tool fails", func(t *testing.T) {
+		delete(runner.Outputs, "-ip")
+		netInfo = updateNetInfo(1, dmgr, netInfo, true)
+		assert.Equal(t, "127.0.0.1", netInfo.DiagInfo.IPInfo.IP)
+	})
+}
+
+// TestStatResetPackInfo test the counter resets are counted and the last counters are kept when sampling fails
+func TestStatResetPackInfo(t *testing.T) {
+	runner := newDiagRunner()
+	defer hccn.SetTool(hccn.GetTool())
+	hccn.SetTool(hccn.NewTool(context.Background(), runner))
+	dmgr := &devmanager.DeviceManagerMock{}
+	netInfo := updateNetInfo(1, dmgr, NpuNetInfo{}, false)
+	t.Run("should count reset when counter decreases", func(t *testing.T) {
+		runner.Outputs["-stat"] = "roce_rx_err_pkt_num:3\n"
+		netInfo = updateNetInfo(1, dmgr, netInfo, false)
+		assert.Equal(t, 1.0, netInfo.StatResetNum)
+		assert.Equal(t, 3.0, netInfo.StatInfo.RoceRxErrPktNum)
+	})
+	t.Run("should keep last counters when sampling fails", func(t *testing.T) {
+		delete(runner.Outputs, "-stat")
+		netInfo = updateNetInfo(1, dmgr, netInfo, false)
+		assert.Equal(t, 1.0, netInfo.StatResetNum)
+		assert.Equal(t, 3.0, netInfo.StatInfo.RoceRxErrPktNum)
+		assert.False(t, netInfo.StatSample.Time.IsZero())
+	})
+}
+
+// TestUpdateNetworkDiagInfo test the diagnostic metrics of a chip
+func TestUpdateNetworkDiagInfo(t *testing.T) {
+	runner := newDiagRunner()
+	defer hccn.SetTool(hccn.GetTool())
+	hccn.SetTool(hccn.NewTool(context.Background(), runner))
+	netInfo := updateNetInfo(1, &devmanager.DeviceManagerMock{}, NpuNetInfo{}, true)
+	chip := &HuaWeiAIChip{DeviceID: 1, ChipIfo: &common.ChipInfo{Name: "910B3"}, NetInfo: &netInfo}
+	const chanSize = 16
+	ch := make(chan prometheus.Metric, chanSize)
+	updateNetworkDiagInfo(ch, &HuaWeiNPUCard{Timestamp: time.Now()}, chip)
+	close(ch)
+	counts := make(map[*prometheus.Desc]int, chanSize)
+	for metric := range ch {
+		counts[metric.Desc()]++
+	}
+	assert.Equal(t, map[*prometheus.Desc]int{npuChipLinkDownNum: 1, npuChipLinkFlapTotal: 1,
+		npuChipLLDPNeighborInfo: 1, npuChipIPInfo: 1, npuChipARPInfo: 1, npuChipRouteInfo: 1}, counts,
+		"the duplicated arp entry should be reported once")
+}
+
+// TestDiagCycle test the diagnostic information is updated about every minute
+func TestDiagCycle(t *testing.T) {
+	const updateTime = 5 * time.Second
+	assert.Equal(t, 12, diagCycle(updateTime))
+	assert.Equal(t, 1, diagCycle(time.Hour))
+	assert.Equal(t, 1, diagCycle(0))
+}
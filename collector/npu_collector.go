@@ -33,6 +33,7 @@ import (
 	"huawei.com/npu-exporter/v5/devmanager/common"
 	"huawei.com/npu-exporter/v5/devmanager/dcmi"
 	"huawei.com/npu-exporter/v5/devmanager/hccn"
+	"huawei.com/npu-exporter/v5/devmanager/hccn/parser"
 	"huawei.com/npu-exporter/v5/versions"
 )
 
@@ -43,172 +44,171 @@ const (
 	npuUUID     = "vdie_id"
 	vNpuUUID    = "v_dev_id"
 	npuPCIEInfo = "pcie_bus_info"
+	cardType    = "card_type"
+	productType = "product_type"
 	namespace   = "namespace"
 	podName     = "pod_name"
 	isVirtual   = "is_virtual"
 )
 
-const (
-	txPower0 = "Tx_Power0"
-	txPower1 = "Tx_Power1"
-	txPower2 = "Tx_Power2"
-	txPower3 = "Tx_Power3"
-
-	rxPower0 = "Rx_Power0"
-	rxPower1 = "Rx_Power1"
-	rxPower2 = "Rx_Power2"
-	rxPower3 = "Rx_Power3"
-
-	present     = "present"
-	temperature = "temperature"
-	voltage     = "Vcc"
-)
-
-const (
-	macRxMacPauseNum       = "mac_rx_mac_pause_num"
-	macTxMacPauseNum       = "mac_tx_mac_pause_num"
-	macRxPfcPktNum         = "mac_rx_pfc_pkt_num"
-	macTxPfcPktNum         = "mac_tx_pfc_pkt_num"
-	macRxBadPktNum         = "mac_rx_bad_pkt_num"
-	macTxBadPktNum         = "mac_tx_bad_pkt_num"
-	roCERxAllPktNum        = "roce_rx_all_pkt_num"
-	roCETxAllPktNum        = "roce_tx_all_pkt_num"
-	roCERxErrPktNum        = "roce_rx_err_pkt_num"
-	roCETxErrPktNum        = "roce_tx_err_pkt_num"
-	roCERxCnpPktNum        = "roce_rx_cnp_pkt_num"
-	roCETxCnpPktNum        = "roce_tx_cnp_pkt_num"
-	macRxBadOctNum         = "mac_rx_bad_oct_num"
-	macTxBadOctNum         = "mac_tx_bad_oct_num"
-	roCEUnexpectedAckNum   = "roce_unexpected_ack_num"
-	roCEOutOfOrderNum      = "roce_out_of_order_num"
-	roCEVerificationErrNum = "roce_verification_err_num"
-	roCEQpStatusErrNum     = "roce_qp_status_err_num"
-	roCENewPktRtyNum       = "roce_new_pkt_rty_num"
-)
-
 var (
+	// chipLabels the labels of the chip metrics, the card type and the product type tell the chips of a
+	// heterogeneous server apart
+	chipLabels = []string{npuID, modelName, npuUUID, npuPCIEInfo, cardType, productType}
+	// processLabels the labels of the processes running on the chip, the process metadata is read from the host
+	processLabels = []string{npuID, modelName, npuUUID, "process_id", "container_id", "container_name", npuPCIEInfo,
+		cardType, productType, "process_name", "cmdline_hash", "user"}
+
 	versionInfoDesc = prometheus.NewDesc("npu_exporter_version_info",
 		"exporter version with value '1'", []string{"exporterVersion"}, nil)
 	machineInfoNPUDesc = prometheus.NewDesc("machine_npu_nums",
 		"Amount of npu installed on the machine.", nil, nil)
 	npuChipInfoDescNpuName = prometheus.NewDesc("npu_chip_info_name",
-		"the Ascend npu name with value '1'", []string{npuID, "name", npuUUID, npuPCIEInfo}, nil)
+		"the Ascend npu name with value '1'", []string{npuID, "name", npuUUID, npuPCIEInfo, cardType, productType}, nil)
 	npuChipInfoDescUtil = prometheus.NewDesc("npu_chip_info_utilization",
-		"the ai core utilization", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the ai core utilization", chipLabels, nil)
 	npuChipInfoDescTemp = prometheus.NewDesc("npu_chip_info_temperature",
-		"the npu temperature", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu temperature", chipLabels, nil)
 	npuChipInfoDescPower = prometheus.NewDesc("npu_chip_info_power",
-		"the npu power", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu power", chipLabels, nil)
 	npuChipInfoDescVoltage = prometheus.NewDesc("npu_chip_info_voltage",
-		"the npu voltage", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu voltage", chipLabels, nil)
 	npuChipInfoDescUsedMemory = prometheus.NewDesc("npu_chip_info_used_memory",
-		"the npu used memory", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu used memory", chipLabels, nil)
 	npuChipInfoDescTotalMemory = prometheus.NewDesc("npu_chip_info_total_memory",
-		"the npu total memory", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu total memory", chipLabels, nil)
 	npuChipInfoDescHealthStatus = prometheus.NewDesc("npu_chip_info_health_status",
-		"the npu health status", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu health status", chipLabels, nil)
 	npuChipInfoDescHbmUsedMemory = prometheus.NewDesc("npu_chip_info_hbm_used_memory",
-		"the npu hbm used memory", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu hbm used memory", chipLabels, nil)
 	npuChipInfoDescHbmTotalMemory = prometheus.NewDesc("npu_chip_info_hbm_total_memory",
-		"the npu hbm total memory", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu hbm total memory", chipLabels, nil)
 	npuChipInfoDescErrorCode = prometheus.NewDesc("npu_chip_info_error_code",
-		"the npu error code", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu error code", chipLabels, nil)
 	npuChipInfoDescLinkStatus = prometheus.NewDesc("npu_chip_info_link_status",
-		"the npu link status", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu link status", chipLabels, nil)
 	npuChipInfoDescNetworkStatus = prometheus.NewDesc("npu_chip_info_network_status",
-		"the npu network health status", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu network health status", chipLabels, nil)
 	npuChipInfoDescBandwidthTx = prometheus.NewDesc("npu_chip_info_bandwidth_tx",
-		"the npu interface transport speed, unit is 'MB/s'", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface transport speed, unit is 'MB/s'", chipLabels, nil)
 	npuChipInfoDescBandwidthRx = prometheus.NewDesc("npu_chip_info_bandwidth_rx",
-		"the npu interface receive speed, unit is 'MB/s'", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive speed, unit is 'MB/s'", chipLabels, nil)
 	npuChipLinkSpeed = prometheus.NewDesc("npu_chip_link_speed",
-		"the npu interface receive link speed, unit is 'Mb/s'", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive link speed, unit is 'Mb/s'", chipLabels, nil)
 	npuChipLinkUpNum = prometheus.NewDesc("npu_chip_link_up_num",
-		"the npu interface receive link-up num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipMacRxPauseNum = prometheus.NewDesc("npu_chip_mac_rx_pause_num",
-		"the npu interface receive mac-rx-pause-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipMacTxPauseNum = prometheus.NewDesc("npu_chip_mac_tx_pause_num",
-		"the npu interface receive mac-tx-pause-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipMacRxPfcPktNum = prometheus.NewDesc("npu_chip_mac_rx_pfc_pkt_num",
-		"the npu interface receive mac-rx-pfc-pkt-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipMacTxPfcPktNum = prometheus.NewDesc("npu_chip_mac_tx_pfc_pkt_num",
-		"the npu interface receive mac-tx-pfc-pkt-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipMacRxBadPktNum = prometheus.NewDesc("npu_chip_mac_rx_bad_pkt_num",
-		"the npu interface receive mac-rx-bad-pkt-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipMacTxBadPktNum = prometheus.NewDesc("npu_chip_mac_tx_bad_pkt_num",
-		"the npu interface receive mac-tx-bad-pkt-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceRxAllPktNum = prometheus.NewDesc("npu_chip_roce_rx_all_pkt_num",
-		"the npu interface receive roce-rx-all-pkt-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceTxAllPktNum = prometheus.NewDesc("npu_chip_roce_tx_all_pkt_num",
-		"the npu interface receive roce-tx-all-pkt-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceRxErrPktNum = prometheus.NewDesc("npu_chip_roce_rx_err_pkt_num",
-		"the npu interface receive roce-rx-err-pkt-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceTxErrPktNum = prometheus.NewDesc("npu_chip_roce_tx_err_pkt_num",
-		"the npu interface receive roce-tx-err-pkt-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceRxCnpPktNum = prometheus.NewDesc("npu_chip_roce_rx_cnp_pkt_num",
-		"the npu interface receive roce-rx-cnp-pkt-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceTxCnpPktNum = prometheus.NewDesc("npu_chip_roce_tx_cnp_pkt_num",
-		"the npu interface receive roce-tx-cnp-pkt-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceNewPktRtyNum = prometheus.NewDesc("npu_chip_roce_new_pkt_rty_num",
-		"the npu interface receive roce-new-pkt-rty-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipMacTxBadOctNum = prometheus.NewDesc("npu_chip_mac_tx_bad_oct_num",
-		"the npu interface receive mac-tx-bad-oct-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipMacRxBadOctNum = prometheus.NewDesc("npu_chip_mac_rx_bad_oct_num",
-		"the npu interface receive mac-rx-bad-oct-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceUnexpectedAcktNum = prometheus.NewDesc("npu_chip_roce_unexpected_ack_num",
-		"the npu interface receive roce-unexpected-ack-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceOutOfOrderNum = prometheus.NewDesc("npu_chip_roce_out_of_order_num",
-		"the npu interface receive roce-out-of-order-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceVerificationErrNum = prometheus.NewDesc("npu_chip_roce_verification_err_num",
-		"the npu interface receive roce-verification-err-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
-	npuChipRoceQpStatusErrNum = prometheus.NewDesc("npu_chip_roce_qp_status_err_num",
-		"the npu interface receive roce-qp-status-err-num", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive link-up num", chipLabels, nil)
+	npuChipMacRxPauseNum = prometheus.NewDesc("npu_chip_mac_rx_pause_total",
+		"the npu interface receive mac-rx-pause-num", chipLabels, nil)
+	npuChipMacTxPauseNum = prometheus.NewDesc("npu_chip_mac_tx_pause_total",
+		"the npu interface receive mac-tx-pause-num", chipLabels, nil)
+	npuChipMacRxPfcPktNum = prometheus.NewDesc("npu_chip_mac_rx_pfc_pkt_total",
+		"the npu interface receive mac-rx-pfc-pkt-num", chipLabels, nil)
+	npuChipMacTxPfcPktNum = prometheus.NewDesc("npu_chip_mac_tx_pfc_pkt_total",
+		"the npu interface receive mac-tx-pfc-pkt-num", chipLabels, nil)
+	npuChipMacRxBadPktNum = prometheus.NewDesc("npu_chip_mac_rx_bad_pkt_total",
+		"the npu interface receive mac-rx-bad-pkt-num", chipLabels, nil)
+	npuChipMacTxBadPktNum = prometheus.NewDesc("npu_chip_mac_tx_bad_pkt_total",
+		"the npu interface receive mac-tx-bad-pkt-num", chipLabels, nil)
+	npuChipRoceRxAllPktNum = prometheus.NewDesc("npu_chip_roce_rx_all_pkt_total",
+		"the npu interface receive roce-rx-all-pkt-num", chipLabels, nil)
+	npuChipRoceTxAllPktNum = prometheus.NewDesc("npu_chip_roce_tx_all_pkt_total",
+		"the npu interface receive roce-tx-all-pkt-num", chipLabels, nil)
+	npuChipRoceRxErrPktNum = prometheus.NewDesc("npu_chip_roce_rx_err_pkt_total",
+		"the npu interface receive roce-rx-err-pkt-num", chipLabels, nil)
+	npuChipRoceTxErrPktNum = prometheus.NewDesc("npu_chip_roce_tx_err_pkt_total",
+		"the npu interface receive roce-tx-err-pkt-num", chipLabels, nil)
+	npuChipRoceRxCnpPktNum = prometheus.NewDesc("npu_chip_roce_rx_cnp_pkt_total",
+		"the npu interface receive roce-rx-cnp-pkt-num", chipLabels, nil)
+	npuChipRoceTxCnpPktNum = prometheus.NewDesc("npu_chip_roce_tx_cnp_pkt_total",
+		"the npu interface receive roce-tx-cnp-pkt-num", chipLabels, nil)
+	npuChipRoceNewPktRtyNum = prometheus.NewDesc("npu_chip_roce_new_pkt_rty_total",
+		"the npu interface receive roce-new-pkt-rty-num", chipLabels, nil)
+	npuChipMacTxBadOctNum = prometheus.NewDesc("npu_chip_mac_tx_bad_oct_total",
+		"the npu interface receive mac-tx-bad-oct-num", chipLabels, nil)
+	npuChipMacRxBadOctNum = prometheus.NewDesc("npu_chip_mac_rx_bad_oct_total",
+		"the npu interface receive mac-rx-bad-oct-num", chipLabels, nil)
+	npuChipRoceUnexpectedAcktNum = prometheus.NewDesc("npu_chip_roce_unexpected_ack_total",
+		"the npu interface receive roce-unexpected-ack-num", chipLabels, nil)
+	npuChipRoceOutOfOrderNum = prometheus.NewDesc("npu_chip_roce_out_of_order_total",
+		"the npu interface receive roce-out-of-order-num", chipLabels, nil)
+	npuChipRoceVerificationErrNum = prometheus.NewDesc("npu_chip_roce_verification_err_total",
+		"the npu interface receive roce-verification-err-num", chipLabels, nil)
+	npuChipRoceQpStatusErrNum = prometheus.NewDesc("npu_chip_roce_qp_status_err_total",
+		"the npu interface receive roce-qp-status-err-num", chipLabels, nil)
+	npuChipStatResetNum = prometheus.NewDesc("npu_chip_stat_reset_total",
+		"the times of npu interface packet counter resets detected since the exporter started",
+		chipLabels, nil)
 	npuChipOpticalState = prometheus.NewDesc("npu_chip_optical_state",
-		"the npu interface receive optical-state", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-state", chipLabels, nil)
 	npuChipOpticalTxPower0 = prometheus.NewDesc("npu_chip_optical_tx_power_0",
-		"the npu interface receive optical-tx-power-0", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-tx-power-0", chipLabels, nil)
 	npuChipOpticalTxPower1 = prometheus.NewDesc("npu_chip_optical_tx_power_1",
-		"the npu interface receive optical-tx-power-1", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-tx-power-1", chipLabels, nil)
 	npuChipOpticalTxPower2 = prometheus.NewDesc("npu_chip_optical_tx_power_2",
-		"the npu interface receive optical-tx-power-2", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-tx-power-2", chipLabels, nil)
 	npuChipOpticalTxPower3 = prometheus.NewDesc("npu_chip_optical_tx_power_3",
-		"the npu interface receive optical-tx-power-3", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-tx-power-3", chipLabels, nil)
 	npuChipOpticalRxPower0 = prometheus.NewDesc("npu_chip_optical_rx_power_0",
-		"the npu interface receive optical-rx-power-0", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-rx-power-0", chipLabels, nil)
 	npuChipOpticalRxPower1 = prometheus.NewDesc("npu_chip_optical_rx_power_1",
-		"the npu interface receive optical-rx-power-1", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-rx-power-1", chipLabels, nil)
 	npuChipOpticalRxPower2 = prometheus.NewDesc("npu_chip_optical_rx_power_2",
-		"the npu interface receive optical-rx-power-2", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-rx-power-2", chipLabels, nil)
 	npuChipOpticalRxPower3 = prometheus.NewDesc("npu_chip_optical_rx_power_3",
-		"the npu interface receive optical-rx-power-3", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-rx-power-3", chipLabels, nil)
 	npuChipOpticalVcc = prometheus.NewDesc("npu_chip_optical_vcc",
-		"the npu interface receive optical-vcc", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-vcc", chipLabels, nil)
 	npuChipOpticalTemp = prometheus.NewDesc("npu_chip_optical_temp",
-		"the npu interface receive optical-temperature", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu interface receive optical-temperature", chipLabels, nil)
 	npuChipInfoDescDevProcessInfo = prometheus.NewDesc("npu_chip_info_process_info",
 		"the npu process info, unit is 'MB'. if process run on host, container_id and container_name will be empty",
-		[]string{npuID, modelName, npuUUID, "process_id", "container_id", "container_name", npuPCIEInfo}, nil)
+		processLabels, nil)
+	npuChipInfoDescProcessStartTime = prometheus.NewDesc("npu_chip_info_process_start_time",
+		"the unix time when the npu process started on the host", processLabels, nil)
 	npuChipInfoDescAICoreFreqInfo = prometheus.NewDesc("npu_chip_info_aicore_current_freq",
-		"the npu ai core current frequency, unit is 'MHz'", []string{npuID, modelName, npuUUID, npuPCIEInfo}, nil)
+		"the npu ai core current frequency, unit is 'MHz'", chipLabels, nil)
+	npuChipInfoDescAICoreRatedFreq = prometheus.NewDesc("npu_chip_info_aicore_rated_freq",
+		"the npu ai core rated frequency, unit is 'MHz'", chipLabels, nil)
+	npuChipInfoDescCtrlCpuFreq = prometheus.NewDesc("npu_chip_info_ctrl_cpu_freq",
+		"the npu control cpu frequency, unit is 'MHz'", chipLabels, nil)
+	npuChipInfoDescHbmFreq = prometheus.NewDesc("npu_chip_info_hbm_freq",
+		"the npu hbm frequency, unit is 'MHz'", chipLabels, nil)
+	npuChipInfoDescHbmTemp = prometheus.NewDesc("npu_chip_info_hbm_temperature",
+		"the npu hbm temperature, unit is '℃'", chipLabels, nil)
+	npuChipInfoDescHbmBandwidthUtil = prometheus.NewDesc("npu_chip_info_hbm_bandwidth_utilization",
+		"the npu hbm bandwidth utilization, unit is '%'", chipLabels, nil)
+	npuChipInfoDescMemoryFreq = prometheus.NewDesc("npu_chip_info_memory_freq",
+		"the npu memory frequency, unit is 'MHz'", chipLabels, nil)
+	npuChipInfoDescMemoryUtil = prometheus.NewDesc("npu_chip_info_memory_utilization",
+		"the npu memory utilization, unit is '%'", chipLabels, nil)
+	npuChipInfoDescCoreUtil = prometheus.NewDesc("npu_chip_info_core_utilization",
+		"the npu utilization of the vector core and the cpus, the ai core is in npu_chip_info_utilization, unit is '%'",
+		append(append([]string{}, chipLabels...), "core_type"), nil)
 	npuContainerInfo = prometheus.NewDesc("npu_container_info",
 		"the container name and deviceID relationship", []string{"containerID", "containerName", "npuID", modelName, npuUUID,
-			npuPCIEInfo}, nil)
+			npuPCIEInfo, cardType, productType}, nil)
 	npuContainerTotalMemory = prometheus.NewDesc("container_npu_total_memory",
 		"the npu total memory in container, unit is 'MB'", []string{npuID, namespace, podName, "container_name",
-			modelName, npuUUID, npuPCIEInfo}, nil)
+			modelName, npuUUID, npuPCIEInfo, cardType, productType}, nil)
 	npuContainerUsedMemory = prometheus.NewDesc("container_npu_used_memory",
 		"the npu used memory in container, unit is 'MB'", []string{npuID, namespace, podName, "container_name",
-			modelName, npuUUID, npuPCIEInfo}, nil)
+			modelName, npuUUID, npuPCIEInfo, cardType, productType}, nil)
 	npuContainerUtilization = prometheus.NewDesc("container_npu_utilization",
 		"the npu ai core utilization in container, unit is '%'", []string{npuID, namespace, podName,
-			"container_name", modelName, npuUUID, npuPCIEInfo}, nil)
+			"container_name", modelName, npuUUID, npuPCIEInfo, cardType, productType}, nil)
 	podAiCoreUtilizationRate = prometheus.NewDesc("vnpu_pod_aicore_utilization",
 		"the vnpu aicore utilization rate, unit is '%'",
-		[]string{npuID, modelName, vNpuUUID, "aicore_count", namespace, podName, "container_name", isVirtual}, nil)
+		[]string{npuID, modelName, vNpuUUID, "aicore_count", namespace, podName, "container_name", isVirtual,
+			cardType, productType}, nil)
 	podTotalMemory = prometheus.NewDesc("vnpu_pod_total_memory", "the vnpu total memory on pod, unit is 'KB'",
-		[]string{npuID, modelName, vNpuUUID, "aicore_count", namespace, podName, "container_name", isVirtual}, nil)
+		[]string{npuID, modelName, vNpuUUID, "aicore_count", namespace, podName, "container_name", isVirtual,
+			cardType, productType}, nil)
 	podUsedMemory = prometheus.NewDesc("vnpu_pod_used_memory", "the vnpu used memory on pod, unit is 'KB'",
-		[]string{npuID, modelName, vNpuUUID, "aicore_count", namespace, podName, "container_name", isVirtual}, nil)
+		[]string{npuID, modelName, vNpuUUID, "aicore_count", namespace, podName, "container_name", isVirtual,
+			cardType, productType}, nil)
+	npuContainerDeviceMismatch = prometheus.NewDesc("npu_container_device_mismatch",
+		"the number of npu devices in container which can not be matched with npu chips on the host or differ "+
+			"between device sources", []string{"containerID", "containerName"}, nil)
 	npuContainerInfoInit sync.Once
 	npuChipInfoInit      sync.Once
 )
@@ -229,21 +229,48 @@ const (
 	bitSize        = 64
 )
 
+// NpuCollectorOpts the options of the npu collector besides the cache time and the update time
+type NpuCollectorOpts struct {
+	// SuppressUnbooted only exports the boot status of the chip which is still booting, because its other metrics
+	// are misleading
+	SuppressUnbooted bool
+	// InventoryUpdateTime the interval of refreshing the chip inventory, 0 means DefaultInventoryUpdateTime
+	InventoryUpdateTime time.Duration
+	// Process the options of exporting the processes running on the chips
+	Process ProcessOpts
+}
+
+// withDefaults fills the unset options with the default values
+func (o NpuCollectorOpts) withDefaults() NpuCollectorOpts {
+	if o.InventoryUpdateTime <= 0 {
+		o.InventoryUpdateTime = DefaultInventoryUpdateTime
+	}
+	if o.Process.ProcRoot == "" {
+		o.Process.ProcRoot = DefaultProcRoot
+	}
+	if o.Process.TopN < 0 {
+		o.Process.TopN = 0
+	}
+	return o
+}
+
 type npuCollector struct {
 	cache         *cache.ConcurrencyLRUCache
 	devicesParser *container.DevicesParser
 	updateTime    time.Duration
 	cacheTime     time.Duration
+	opts          NpuCollectorOpts
 }
 
 // NewNpuCollector create an instance of prometheus Collector
 func NewNpuCollector(ctx context.Context, cacheTime time.Duration, updateTime time.Duration,
-	deviceParser *container.DevicesParser) (prometheus.Collector, error) {
+	deviceParser *container.DevicesParser, opts NpuCollectorOpts) (prometheus.Collector, error) {
 	npuCollect := &npuCollector{
 		cache:         cache.New(cacheSize),
 		cacheTime:     cacheTime,
 		updateTime:    updateTime,
 		devicesParser: deviceParser,
+		opts:          opts.withDefaults(),
 	}
 	devManager, err := devmanager.AutoInit("")
 	if err != nil {
@@ -297,6 +324,9 @@ func startToGetNetInfo(dmgr devmanager.DeviceInterface, updateTime time.Duration
 				hwlog.RunLog.Errorf("get logic ID of card: %v device:%v failed: %v", cardID, i, err)
 				continue
 			}
+			if !dmgr.IsTrainingCard(logicID) {
+				continue
+			}
 
 			phyID, err := dmgr.GetPhysicIDFromLogicID(logicID)
 			if err != nil {
@@ -334,7 +364,7 @@ func getNPUInfo(dmgr devmanager.DeviceInterface) []HuaWeiNPUCard {
 			if chipInfo == nil {
 				continue
 			}
-			if !strings.Contains(chipInfo.ChipIfo.Name, "310P") || chipInfo.VDevInfos.TotalResource.VDevNum == 0 {
+			if chipInfo.VDevInfos.TotalResource.VDevNum == 0 {
 				deviceList = append(deviceList, chipInfo)
 				continue
 			}
@@ -351,11 +381,11 @@ func getNPUInfo(dmgr devmanager.DeviceInterface) []HuaWeiNPUCard {
 }
 
 func assembleNPUNetInfo(phyID int32, dmgr devmanager.DeviceInterface, updateTime time.Duration) {
-	if !dmgr.IsTrainingCard() {
-		return
-	}
-	for {
-		setNetInfoWithMap(phyID, networkPackInfo(phyID))
+	cycle := diagCycle(updateTime)
+	var netInfo NpuNetInfo
+	for i := 0; ; i++ {
+		netInfo = updateNetInfo(phyID, dmgr, netInfo, i%cycle == 0)
+		setNetInfoWithMap(phyID, netInfo)
 		time.Sleep(updateTime)
 	}
 }
@@ -369,8 +399,9 @@ func assembleNPUInfo(cardID int32, logicID int32, dmgr devmanager.DeviceInterfac
 	}
 	chipInfo := packChipInfo(logicID, dmgr)
 	chipInfo.DeviceID = int(phyID)
+	chipInfo.HccsInfo = getHccsInfoFromMap(logicID)
 
-	if dmgr.GetDevType() == common.Ascend310P {
+	if chipInfo.CardType == common.Ascend310P {
 		cardPower, err := dmgr.GetMcuPowerInfo(cardID)
 		if err != nil {
 			hwlog.RunLog.Error(err)
@@ -378,8 +409,12 @@ func assembleNPUInfo(cardID int32, logicID int32, dmgr devmanager.DeviceInterfac
 		}
 		// Ascend310P use cardPower to replace chipPower
 		chipInfo.Power = cardPower
+	}
+	if common.IsVNPUSupported(chipInfo.CardType) {
+		// the resources are kept without vnpu as well, which are exported for capacity planning
 		vDevInfos, err := dmgr.GetVirtualDeviceInfo(logicID)
-		if err != nil || vDevInfos.TotalResource.VDevNum == 0 {
+		if err != nil {
+			hwlog.RunLog.Debugf("get virtual device info of logic id %d failed: %v", logicID, err)
 			return chipInfo
 		}
 		chipInfo.VDevInfos = vDevInfos
@@ -415,13 +450,16 @@ func start(ctx context.Context, n *npuCollector, dmgr devmanager.DeviceInterface
 	}
 	defer n.devicesParser.Close()
 	n.devicesParser.Timeout = n.updateTime
+	n.devicesParser.IDConverter = dmgr
 	hwlog.RunLog.Infof("Starting update cache every %d seconds", n.updateTime/time.Second)
 
 	group := &sync.WaitGroup{}
 
 	npuBaseInfoCollect(group, n, dmgr)
 	npuNetworkInfoCollect(group, n, dmgr)
+	startToGetHccsInfo(dmgr, n.updateTime)
 	containerInfoCollect(group, n)
+	inventoryCollect(group, dmgr, n.opts.InventoryUpdateTime)
 
 	group.Wait()
 	hwlog.RunLog.Info("received the stop signal,STOPPED")
@@ -436,6 +474,7 @@ func npuBaseInfoCollect(group *sync.WaitGroup, n *npuCollector, dmgr devmanager.
 		defer ticker.Stop()
 		for {
 			npuInfo := getNPUInfo(dmgr)
+			setHostProcesses(npuInfo, n.opts.Process.ProcRoot)
 			if err := n.cache.Set(npuListCacheKey, npuInfo, n.cacheTime); err != nil {
 				hwlog.RunLog.Error(err)
 			} else {
@@ -564,6 +603,7 @@ func describeRoCEInfo(ch chan<- *prometheus.Desc) {
 	ch <- npuChipRoceOutOfOrderNum
 	ch <- npuChipRoceVerificationErrNum
 	ch <- npuChipRoceQpStatusErrNum
+	ch <- npuChipStatResetNum
 }
 
 // Describe implements prometheus.Collector
@@ -575,15 +615,32 @@ func (n *npuCollector) Describe(ch chan<- *prometheus.Desc) {
 	describeBaseChipInfo(ch)
 	describeOpticalInfo(ch)
 	describeRoCEInfo(ch)
+	describeNetworkDiagInfo(ch)
+	describeHccsInfo(ch)
+	describeVNPUCapacityInfo(ch)
+	describeInventoryInfo(ch)
+	describeBootStatusInfo(ch)
+	describeDcmiErrors(ch)
 	ch <- npuContainerInfo
 	ch <- npuContainerTotalMemory
 	ch <- npuContainerUsedMemory
 	ch <- npuContainerUtilization
 	ch <- npuChipInfoDescDevProcessInfo
+	ch <- npuChipInfoDescProcessStartTime
 	ch <- npuChipInfoDescAICoreFreqInfo
+	ch <- npuChipInfoDescAICoreRatedFreq
+	ch <- npuChipInfoDescCtrlCpuFreq
+	ch <- npuChipInfoDescHbmFreq
+	ch <- npuChipInfoDescHbmTemp
+	ch <- npuChipInfoDescHbmBandwidthUtil
+	ch <- npuChipInfoDescMemoryFreq
+	ch <- npuChipInfoDescMemoryUtil
+	ch <- npuChipInfoDescCoreUtil
 	ch <- podAiCoreUtilizationRate
 	ch <- podTotalMemory
 	ch <- podUsedMemory
+	ch <- npuContainerDeviceMismatch
+	describeContainerUsage(ch)
 }
 
 // Collect implements prometheus.Collector
@@ -596,14 +653,24 @@ func (n *npuCollector) Collect(ch chan<- prometheus.Metric) {
 	networkInfoMap := getNetworkInfoInCache(ch, n)
 	containerMap := getContainerNPUInfo(ch, n)
 	ch <- prometheus.MustNewConstMetric(versionInfoDesc, prometheus.GaugeValue, 1, []string{versions.BuildVersion}...)
+	updateDcmiErrors(ch)
 	var totalCount = 0
+	usageAggregator := newContainerUsageAggregator()
+	readiness := newReadinessRollup()
 	for _, card := range npuList {
 		deviceCount := len(card.DeviceList)
 		if deviceCount <= 0 {
 			continue
 		}
 		totalCount += deviceCount
+		updateVNPUCapacityInfo(ch, &card)
 		for _, chip := range card.DeviceList {
+			if readiness.add(chip) {
+				updateBootStatusInfo(ch, &card, chip)
+			}
+			if n.opts.SuppressUnbooted && isBooting(chip.BootStatus) {
+				continue
+			}
 			deviceID := chip.DeviceID
 			if devNetWorkInfo, ok := networkInfoMap[int32(deviceID)]; ok {
 				chip.NetInfo = &devNetWorkInfo
@@ -611,6 +678,7 @@ func (n *npuCollector) Collect(ch chan<- prometheus.Metric) {
 				hwlog.RunLog.Warn("no network information at the moment, so use initial info")
 				chip.NetInfo = &NpuNetInfo{}
 			}
+			setLinkStatus(chip)
 
 			if chip.VDevActivityInfo.IsVirtualDev {
 				deviceID = int(chip.VDevActivityInfo.VDevID)
@@ -621,14 +689,23 @@ func (n *npuCollector) Collect(ch chan<- prometheus.Metric) {
 			}
 			updateNPUCommonInfo(ch, &card, chip)
 			updateNPUMemoryInfo(ch, &card, chip)
+			updateNPUMemoryExtInfo(ch, &card, chip)
+			updateNPUFreqInfo(ch, &card, chip)
+			updateNPUCoreUtilInfo(ch, &card, chip)
 			updateNPUNetworkInfo(ch, &card, chip)
-			updateProcessInfo(ch, &card, chip, devInfo)
+			updateHccsInfo(ch, &card, chip)
+			updateProcessInfo(ch, &card, chip, devInfo, n.opts.Process)
 			updateContainerInfo(ch, &card, chip, devInfo)
 			updatePodVNPUInfo(ch, &card, chip, devInfo)
+			usageAggregator.add(chip, card.CardID, deviceID, devInfo)
 		}
 	}
+	usageAggregator.collect(ch)
+	readiness.collect(ch)
+	updateInventoryInfo(ch)
 
 	ch <- prometheus.MustNewConstMetric(machineInfoNPUDesc, prometheus.GaugeValue, float64(totalCount))
+	updateContainerDeviceMismatch(ch, containerMap)
 }
 
 func getNPUInfoInCache(ch chan<- prometheus.Metric, n *npuCollector) []HuaWeiNPUCard {
@@ -646,6 +723,7 @@ func getNPUInfoInCache(ch chan<- prometheus.Metric, n *npuCollector) []HuaWeiNPU
 				return
 			}
 			npuInfo := getNPUInfo(devManager)
+			setHostProcesses(npuInfo, n.opts.Process.ProcRoot)
 			if err = n.cache.Set(npuListCacheKey, npuInfo, n.cacheTime); err != nil {
 				hwlog.RunLog.Errorf("no cache for prometheus, try to build cache failed, error is: %v", err)
 				return
@@ -714,8 +792,8 @@ func getContainerNPUInfo(ch chan<- prometheus.Metric, n *npuCollector) map[int]c
 	}
 	res := make(map[int]container.DevicesInfo, initSize)
 	for _, v := range cntNpuInfos {
-		for _, deviceID := range v.Devices {
-			res[deviceID] = v
+		for _, device := range v.Devices {
+			res[device.Key()] = v
 		}
 	}
 	return res
@@ -752,118 +830,178 @@ func updateNPUMemoryInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *
 	}
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipInfoDescHbmUsedMemory, prometheus.GaugeValue, float64(chip.HbmInfo.Usage),
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipInfoDescHbmTotalMemory, prometheus.GaugeValue,
-			float64(chip.HbmInfo.MemorySize), []string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID,
-				chip.PCIeBusInfo}...))
+			float64(chip.HbmInfo.MemorySize), chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescUsedMemory,
 		prometheus.GaugeValue, float64(chip.Meminf.MemorySize-chip.Meminf.MemoryAvailable),
-		[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipInfoDescTotalMemory, prometheus.GaugeValue,
-			float64(chip.Meminf.MemorySize), []string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID,
-				chip.PCIeBusInfo}...))
+			float64(chip.Meminf.MemorySize), chipLabelValues(chip)...))
+}
+
+func updateNPUMemoryExtInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
+	if !validate(ch, npu, chip, chip.HbmInfo, chip.Meminf) {
+		hwlog.RunLog.Error("Invalid param in function updateNPUMemoryExtInfo")
+		return
+	}
+	labels := chipLabelValues(chip)
+	if hasHbm(chip.CardType) {
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescHbmTemp,
+			prometheus.GaugeValue, float64(chip.HbmInfo.Temp), labels...))
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(
+			npuChipInfoDescHbmBandwidthUtil, prometheus.GaugeValue, float64(chip.HbmInfo.BandWidthUtilRate),
+			labels...))
+	}
+	// the chips of 910B have no ddr, their memory info is zero
+	if chip.CardType == common.Ascend910B {
+		return
+	}
+	updateFreqMetric(ch, npu, npuChipInfoDescMemoryFreq, chip.Meminf.Frequency, labels)
+	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescMemoryUtil,
+		prometheus.GaugeValue, float64(chip.Meminf.Utilization), labels...))
+}
+
+func updateNPUFreqInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
+	if !validate(ch, npu, chip, chip.ChipIfo) {
+		hwlog.RunLog.Error("Invalid param in function updateNPUFreqInfo")
+		return
+	}
+	labels := chipLabelValues(chip)
+	updateFreqMetric(ch, npu, npuChipInfoDescAICoreRatedFreq, chip.AICoreRatedFreq, labels)
+	updateFreqMetric(ch, npu, npuChipInfoDescCtrlCpuFreq, chip.CtrlCpuFreq, labels)
+	updateFreqMetric(ch, npu, npuChipInfoDescHbmFreq, chip.HbmFreq, labels)
+}
+
+// updateFreqMetric skip the frequency which is not supported or failed to query, instead of exporting it as 0
+func updateFreqMetric(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, desc *prometheus.Desc, freq uint32,
+	labels []string) {
+	if freq == common.InvalidVal {
+		return
+	}
+	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(desc,
+		prometheus.GaugeValue, float64(freq), labels...))
+}
+
+// hasHbm only the chips of 910 series have hbm, the hbm info of the other chips is the ddr info or zero
+func hasHbm(cardType string) bool {
+	return cardType == common.Ascend910 || cardType == common.Ascend910B
+}
+
+func updateNPUCoreUtilInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
+	if !validate(ch, npu, chip, chip.ChipIfo) {
+		hwlog.RunLog.Error("Invalid param in function updateNPUCoreUtilInfo")
+		return
+	}
+	for coreType, util := range chip.CoreUtilization {
+		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescCoreUtil,
+			prometheus.GaugeValue, float64(util), append(chipLabelValues(chip), coreType)...))
+	}
 }
 
 func updateStatInfoOfMac(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipMacRxPauseNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.MacRxPauseNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipMacRxPauseNum, prometheus.CounterValue, chip.NetInfo.StatInfo.MacRxPauseNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipMacTxPauseNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.MacTxPauseNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipMacTxPauseNum, prometheus.CounterValue, chip.NetInfo.StatInfo.MacTxPauseNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipMacRxPfcPktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.MacRxPfcPktNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipMacRxPfcPktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.MacRxPfcPktNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipMacTxPfcPktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.MacTxPfcPktNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipMacTxPfcPktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.MacTxPfcPktNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipMacRxBadPktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.MacRxBadPktNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipMacRxBadPktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.MacRxBadPktNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipMacTxBadPktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.MacTxBadPktNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipMacTxBadPktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.MacTxBadPktNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipMacTxBadOctNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.MacTxBadOctNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipMacTxBadOctNum, prometheus.CounterValue, chip.NetInfo.StatInfo.MacTxBadOctNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipMacRxBadOctNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.MacRxBadOctNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipMacRxBadOctNum, prometheus.CounterValue, chip.NetInfo.StatInfo.MacRxBadOctNum,
+			chipLabelValues(chip)...))
 
 }
 
 func updateStatInfoOfRoCE(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceRxAllPktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceRxAllPktNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipRoceRxAllPktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceRxAllPktNum,
+			chipLabelValues(chip)...))
+	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
+		prometheus.MustNewConstMetric(npuChipRoceTxAllPktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceTxAllPktNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceTxAllPktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceTxAllPktNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipRoceRxErrPktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceRxErrPktNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceRxErrPktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceRxErrPktNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipRoceTxErrPktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceTxErrPktNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceTxErrPktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceTxErrPktNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipRoceRxCnpPktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceRxCnpPktNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceRxCnpPktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceRxCnpPktNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipRoceTxCnpPktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceTxCnpPktNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceTxCnpPktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceTxCnpPktNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipRoceNewPktRtyNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceNewPktRtyNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceNewPktRtyNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceNewPktRtyNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipRoceUnexpectedAcktNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceUnexpectedAckNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceUnexpectedAcktNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceUnexpectedAckNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipRoceOutOfOrderNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceOutOfOrderNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceOutOfOrderNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceOutOfOrderNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipRoceVerificationErrNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceVerificationErrNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceVerificationErrNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceVerificationErrNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipRoceQpStatusErrNum, prometheus.CounterValue, chip.NetInfo.StatInfo.RoceQpStatusErrNum,
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
-		prometheus.MustNewConstMetric(npuChipRoceQpStatusErrNum, prometheus.GaugeValue, chip.NetInfo.StatInfo.RoceQpStatusErrNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.MustNewConstMetric(npuChipStatResetNum, prometheus.CounterValue, chip.NetInfo.StatResetNum,
+			chipLabelValues(chip)...))
 }
 
 func updateOpticalInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalState, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalState,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalTxPower0, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalTxPower0,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalTxPower1, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalTxPower1,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalTxPower2, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalTxPower2,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalTxPower3, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalTxPower3,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalRxPower0, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalRxPower0,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalRxPower1, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalRxPower1,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalRxPower2, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalRxPower2,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalRxPower3, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalRxPower3,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalVcc, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalVcc,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipOpticalTemp, prometheus.GaugeValue, chip.NetInfo.OpticalInfo.OpticalTemp,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 }
 
 func updateNPUNetworkInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
@@ -874,22 +1012,22 @@ func updateNPUNetworkInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip
 	updateStatInfoOfMac(ch, npu, chip)
 	updateStatInfoOfRoCE(ch, npu, chip)
 	updateOpticalInfo(ch, npu, chip)
+	updateNetworkDiagInfo(ch, npu, chip)
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipInfoDescBandwidthTx, prometheus.GaugeValue, chip.NetInfo.BandwidthInfo.TxValue,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipInfoDescBandwidthRx, prometheus.GaugeValue, chip.NetInfo.BandwidthInfo.RxValue,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipInfoDescNetworkStatus, prometheus.GaugeValue,
-			float64(getHealthCode(chip.NetHealthStatus)), []string{strconv.FormatInt(int64(chip.DeviceID), base),
-				common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			float64(getHealthCode(chip.NetHealthStatus)), chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipLinkSpeed, prometheus.GaugeValue, chip.NetInfo.LinkSpeedInfo.Speed,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipLinkUpNum, prometheus.GaugeValue, chip.NetInfo.LinkStatInfo.LinkUPNum,
-			[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			chipLabelValues(chip)...))
 }
 
 func updateContainerInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip,
@@ -900,7 +1038,7 @@ func updateContainerInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *
 	}
 	ch <- prometheus.MustNewConstMetric(npuContainerInfo, prometheus.GaugeValue, 1,
 		[]string{devInfo.ID, strings.Join(containerName, "_"), strconv.Itoa(chip.DeviceID), common.GetNpuName(*chip.ChipIfo), chip.VDieID,
-			chip.PCIeBusInfo}...)
+			chip.PCIeBusInfo, chip.CardType, chip.ProductType}...)
 	if common.IsValidVDevID(chip.VDevActivityInfo.VDevID) {
 		return
 	}
@@ -908,13 +1046,26 @@ func updateContainerInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuContainerUtilization,
 		prometheus.GaugeValue, float64(chip.Utilization), []string{strconv.FormatInt(int64(chip.DeviceID), base),
 			containerName[nameSpaceIdx], containerName[podNameIdx], containerName[conNameIdx], common.GetNpuName(*chip.ChipIfo), chip.VDieID,
-			chip.PCIeBusInfo}...))
+			chip.PCIeBusInfo, chip.CardType, chip.ProductType}...))
+
+}
 
+func updateContainerDeviceMismatch(ch chan<- prometheus.Metric, containerMap map[int]container.DevicesInfo) {
+	reported := make(map[string]struct{}, len(containerMap))
+	for _, devInfo := range containerMap {
+		if _, ok := reported[devInfo.ID]; ok {
+			continue
+		}
+		reported[devInfo.ID] = struct{}{}
+		ch <- prometheus.MustNewConstMetric(npuContainerDeviceMismatch, prometheus.GaugeValue,
+			float64(devInfo.MismatchNum), []string{devInfo.ID, devInfo.Name}...)
+	}
 }
 
 func updatePodVNPUInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip,
 	devInfo container.DevicesInfo) {
-	if !strings.Contains(chip.ChipIfo.Name, "310P") || !common.IsValidVDevID(chip.VDevActivityInfo.VDevID) {
+	// the utilization and memory of the vNPU whose activity is unavailable are unknown rather than 0
+	if !common.IsValidVDevID(chip.VDevActivityInfo.VDevID) || chip.VDevActivityInfo.ActivityUnavailable {
 		return
 	}
 	containerName := getContainerNameArray(devInfo)
@@ -939,22 +1090,23 @@ func updateContainerNPUMemoryInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCar
 			prometheus.MustNewConstMetric(npuContainerTotalMemory, prometheus.GaugeValue,
 				float64(chip.HbmInfo.MemorySize), []string{strconv.FormatInt(int64(chip.DeviceID), base),
 					containerName[nameSpaceIdx], containerName[podNameIdx], containerName[conNameIdx],
-					common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+					common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo, chip.CardType, chip.ProductType}...))
 		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 			prometheus.MustNewConstMetric(npuContainerUsedMemory, prometheus.GaugeValue, float64(chip.HbmInfo.Usage),
 				[]string{strconv.FormatInt(int64(chip.DeviceID), base), containerName[nameSpaceIdx],
 					containerName[podNameIdx], containerName[conNameIdx],
-					common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+					common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo, chip.CardType, chip.ProductType}...))
 		return
 	}
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuContainerTotalMemory,
 		prometheus.GaugeValue, float64(chip.Meminf.MemorySize), []string{strconv.FormatInt(int64(chip.DeviceID), base),
 			containerName[nameSpaceIdx], containerName[podNameIdx], containerName[conNameIdx], common.GetNpuName(*chip.ChipIfo), chip.VDieID,
-			chip.PCIeBusInfo}...))
+			chip.PCIeBusInfo, chip.CardType, chip.ProductType}...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuContainerUsedMemory,
 		prometheus.GaugeValue, float64(chip.Meminf.MemorySize-chip.Meminf.MemoryAvailable),
 		[]string{strconv.FormatInt(int64(chip.DeviceID), base), containerName[nameSpaceIdx],
-			containerName[podNameIdx], containerName[conNameIdx], common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			containerName[podNameIdx], containerName[conNameIdx], common.GetNpuName(*chip.ChipIfo), chip.VDieID,
+			chip.PCIeBusInfo, chip.CardType, chip.ProductType}...))
 }
 
 func updateNPUCommonInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip) {
@@ -964,36 +1116,28 @@ func updateNPUCommonInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *
 	}
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescLinkStatus,
 		prometheus.GaugeValue, float64(hccn.GetLinkStatusCode(chip.LinkStatus)),
-		[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescUtil,
-		prometheus.GaugeValue, float64(chip.Utilization), []string{strconv.FormatInt(int64(chip.DeviceID), base),
-			common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.GaugeValue, float64(chip.Utilization), chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescTemp,
-		prometheus.GaugeValue, float64(chip.Temperature), []string{strconv.FormatInt(int64(chip.DeviceID), base),
-			common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.GaugeValue, float64(chip.Temperature), chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescPower,
-		prometheus.GaugeValue, float64(chip.Power), []string{strconv.FormatInt(int64(chip.DeviceID), base),
-			common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.GaugeValue, float64(chip.Power), chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescVoltage,
-		prometheus.GaugeValue, float64(chip.Voltage), []string{strconv.FormatInt(int64(chip.DeviceID), base),
-			common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.GaugeValue, float64(chip.Voltage), chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 		prometheus.MustNewConstMetric(npuChipInfoDescHealthStatus, prometheus.GaugeValue,
-			float64(getHealthCode(chip.HealthStatus)), []string{strconv.FormatInt(int64(chip.DeviceID), base),
-				common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+			float64(getHealthCode(chip.HealthStatus)), chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescErrorCode,
-		prometheus.GaugeValue, float64(chip.ErrorCode), []string{strconv.FormatInt(int64(chip.DeviceID), base),
-			common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.GaugeValue, float64(chip.ErrorCode), chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescNpuName,
-		prometheus.GaugeValue, 1, []string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID,
-			chip.PCIeBusInfo}...))
+		prometheus.GaugeValue, 1, chipLabelValues(chip)...))
 	ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipInfoDescAICoreFreqInfo,
-		prometheus.GaugeValue, float64(chip.AICoreCurrentFreq), []string{strconv.FormatInt(int64(chip.DeviceID), base),
-			common.GetNpuName(*chip.ChipIfo), chip.VDieID, chip.PCIeBusInfo}...))
+		prometheus.GaugeValue, float64(chip.AICoreCurrentFreq), chipLabelValues(chip)...))
 }
 
 func updateProcessInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *HuaWeiAIChip,
-	devInfo container.DevicesInfo) {
+	devInfo container.DevicesInfo, opts ProcessOpts) {
 	containerName := ""
 	containerID := ""
 	cNameArray := getContainerNameArray(devInfo)
@@ -1001,19 +1145,28 @@ func updateProcessInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard, chip *Hu
 		containerName = strings.Join(cNameArray, "_")
 		containerID = devInfo.ID
 	}
-	if chip.DevProcessInfo.ProcNum == 0 {
+	procs := selectProcesses(chip, opts)
+	if len(procs) == 0 {
 		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 			prometheus.MustNewConstMetric(npuChipInfoDescDevProcessInfo, prometheus.GaugeValue, 0,
 				[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo),
-					chip.VDieID, "", containerID, containerName, chip.PCIeBusInfo}...))
+					chip.VDieID, "", containerID, containerName, chip.PCIeBusInfo, chip.CardType, chip.ProductType,
+					"", "", ""}...))
 		return
 	}
-	for i := int32(0); i < chip.DevProcessInfo.ProcNum; i++ {
-		procInfo := chip.DevProcessInfo.DevProcArray[i]
+	for _, procInfo := range procs {
+		hostProcess := chip.HostProcesses[procInfo.Pid]
+		labelValues := []string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo),
+			chip.VDieID, strconv.FormatInt(int64(procInfo.Pid), base), containerID, containerName, chip.PCIeBusInfo,
+			chip.CardType, chip.ProductType, hostProcess.Name, hostProcess.CmdlineHash, hostProcess.User}
 		ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
 			prometheus.MustNewConstMetric(npuChipInfoDescDevProcessInfo, prometheus.GaugeValue, procInfo.MemUsage,
-				[]string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID,
-					strconv.FormatInt(int64(procInfo.Pid), base), containerID, containerName, chip.PCIeBusInfo}...))
+				labelValues...))
+		if hostProcess.StartTime > 0 {
+			ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
+				prometheus.MustNewConstMetric(npuChipInfoDescProcessStartTime, prometheus.GaugeValue,
+					float64(hostProcess.StartTime), labelValues...))
+		}
 	}
 }
 
@@ -1026,12 +1179,85 @@ var packChipInfo = func(logicID int32, dmgr devmanager.DeviceInterface) *HuaWeiA
 		info = &common.ChipInfo{}
 	}
 	chip.ChipIfo = info
+	setCardType(logicID, dmgr, chip)
+	setBootStatus(logicID, dmgr, chip)
 
 	packChipInfoPart2(logicID, dmgr, chip)
 	packChipInfoPart1(logicID, dmgr, chip)
+	packChipFreqInfo(logicID, dmgr, chip)
+	packChipCoreUtil(logicID, dmgr, chip)
 	return chip
 }
 
+func packChipFreqInfo(logicID int32, dmgr devmanager.DeviceInterface, hwChip *HuaWeiAIChip) {
+	ratedFreq, err := dmgr.GetDeviceFrequency(logicID, common.AICoreRatedFreq)
+	if err != nil {
+		ratedFreq = common.InvalidVal
+	}
+	ctrlCpuFreq, err := dmgr.GetDeviceFrequency(logicID, common.CtrlCpuFreq)
+	if err != nil {
+		ctrlCpuFreq = common.InvalidVal
+	}
+	hbmFreq := uint32(common.InvalidVal)
+	if hasHbm(hwChip.CardType) {
+		if hbmFreq, err = dmgr.GetDeviceFrequency(logicID, common.HBMFreq); err != nil && hwChip.HbmInfo != nil {
+			hbmFreq = hwChip.HbmInfo.Frequency
+		}
+	}
+
+	hwChip.AICoreRatedFreq = ratedFreq
+	hwChip.CtrlCpuFreq = ctrlCpuFreq
+	hwChip.HbmFreq = hbmFreq
+}
+
+// coreUtilType the core type label and the utilization type of dcmi
+type coreUtilType struct {
+	name    string
+	devType common.DeviceType
+}
+
+var (
+	vectorCoreUtil = coreUtilType{name: "vectorcore", devType: common.VectorCore}
+	aiCpuUtil      = coreUtilType{name: "aicpu", devType: common.AICpu}
+	ctrlCpuUtil    = coreUtilType{name: "ctrlcpu", devType: common.CtrlCpu}
+)
+
+// chipCoreUtilTypes the utilization types queried of the chip, the ai core utilization is exported by
+// npu_chip_info_utilization, only Ascend310P has vector core
+func chipCoreUtilTypes(cardType string) []coreUtilType {
+	if cardType == common.Ascend310P {
+		return []coreUtilType{vectorCoreUtil, aiCpuUtil, ctrlCpuUtil}
+	}
+	return []coreUtilType{aiCpuUtil, ctrlCpuUtil}
+}
+
+func packChipCoreUtil(logicID int32, dmgr devmanager.DeviceInterface, hwChip *HuaWeiAIChip) {
+	types := chipCoreUtilTypes(hwChip.CardType)
+	hwChip.CoreUtilization = make(map[string]uint32, len(types))
+	for _, t := range types {
+		util, err := dmgr.GetDeviceUtilizationRate(logicID, t.devType)
+		if err != nil || !common.IsValidUtilizationRate(util) {
+			hwlog.RunLog.Debugf("get %s utilization of chip %d failed, util: %d, err: %v", t.name, logicID, util,
+				err)
+			continue
+		}
+		hwChip.CoreUtilization[t.name] = util
+	}
+}
+
+// setCardType sets the device type and the product type of the card which the chip belongs to, the cards of a
+// heterogeneous server may differ from the device type of the device manager
+func setCardType(logicID int32, dmgr devmanager.DeviceInterface, hwChip *HuaWeiAIChip) {
+	cardID, _, err := dmgr.GetCardIDDeviceID(logicID)
+	if err != nil {
+		hwlog.RunLog.Debugf("get card id of logic id %d failed: %v", logicID, err)
+		hwChip.CardType = dmgr.GetDevType()
+		return
+	}
+	hwChip.CardType = dmgr.GetCardType(cardID)
+	hwChip.ProductType = dmgr.GetCardProductType(cardID)
+}
+
 func packChipInfoPart1(logicID int32, dmgr devmanager.DeviceInterface, hwChip *HuaWeiAIChip) {
 	freq, err := dmgr.GetDeviceFrequency(logicID, common.AICoreCurrentFreq)
 	if err != nil {
@@ -1084,7 +1310,6 @@ func packChipInfoPart2(logicID int32, dmgr devmanager.DeviceInterface, hwChip *H
 	setNetHealthStatus(logicID, dmgr, hwChip)
 	setProcessInfo(logicID, dmgr, hwChip)
 	setPCIeBusInfo(logicID, dmgr, hwChip)
-	setLinkStatus(logicID, dmgr, hwChip)
 	hwChip.ErrorCode = errCode
 	hwChip.Utilization = int(util)
 	hwChip.VDieID = vdieID
@@ -1092,7 +1317,7 @@ func packChipInfoPart2(logicID int32, dmgr devmanager.DeviceInterface, hwChip *H
 
 func setNetHealthStatus(logicID int32, dmgr devmanager.DeviceInterface, hwChip *HuaWeiAIChip) {
 	hwChip.NetHealthStatus = UnHealthy
-	if !dmgr.IsTrainingCard() {
+	if !dmgr.IsTrainingCard(logicID) {
 		return
 	}
 
@@ -1134,35 +1359,30 @@ func setPCIeBusInfo(logicID int32, dmgr devmanager.DeviceInterface, hwChip *HuaW
 	hwChip.PCIeBusInfo = pcieInfo
 }
 
-func setLinkStatus(logicID int32, dmgr devmanager.DeviceInterface, hwChip *HuaWeiAIChip) {
+// setLinkStatus sets the link status queried by networkPackInfo, which is also the one of the link flap detection,
+// the chips without network information are taken as link down
+func setLinkStatus(hwChip *HuaWeiAIChip) {
 	hwChip.LinkStatus = LinkDown
-	if !dmgr.IsTrainingCard() {
-		return
+	if hwChip.NetInfo != nil && hwChip.NetInfo.LinkStatInfo.LinkStatus != "" {
+		hwChip.LinkStatus = hwChip.NetInfo.LinkStatInfo.LinkStatus
 	}
-
-	phyID, err := dmgr.GetPhysicIDFromLogicID(logicID)
-	if err != nil {
-		hwlog.RunLog.Error("set link status failed")
-		return
-	}
-	hwChip.LinkStatus = hccn.GetNPULinkStatus(phyID)
 }
 
-func getMainOptInfo(opticalInfo map[string]string) OpticalInfo {
+func getMainOptInfo(opticalInfo parser.OpticalInfo) OpticalInfo {
 	mainOpticalInfo := OpticalInfo{}
-	mainOpticalInfo.OpticalTxPower0 = hccn.GetFloatDataFromStr(opticalInfo[txPower0])
-	mainOpticalInfo.OpticalTxPower1 = hccn.GetFloatDataFromStr(opticalInfo[txPower1])
-	mainOpticalInfo.OpticalTxPower2 = hccn.GetFloatDataFromStr(opticalInfo[txPower2])
-	mainOpticalInfo.OpticalTxPower3 = hccn.GetFloatDataFromStr(opticalInfo[txPower3])
-	mainOpticalInfo.OpticalRxPower0 = hccn.GetFloatDataFromStr(opticalInfo[rxPower0])
-	mainOpticalInfo.OpticalRxPower1 = hccn.GetFloatDataFromStr(opticalInfo[rxPower1])
-	mainOpticalInfo.OpticalRxPower2 = hccn.GetFloatDataFromStr(opticalInfo[rxPower2])
-	mainOpticalInfo.OpticalRxPower3 = hccn.GetFloatDataFromStr(opticalInfo[rxPower3])
-	mainOpticalInfo.OpticalVcc = hccn.GetFloatDataFromStr(opticalInfo[voltage])
-	mainOpticalInfo.OpticalTemp = hccn.GetFloatDataFromStr(opticalInfo[temperature])
+	mainOpticalInfo.OpticalTxPower0 = opticalInfo.TxPower[0]
+	mainOpticalInfo.OpticalTxPower1 = opticalInfo.TxPower[1]
+	mainOpticalInfo.OpticalTxPower2 = opticalInfo.TxPower[2]
+	mainOpticalInfo.OpticalTxPower3 = opticalInfo.TxPower[3]
+	mainOpticalInfo.OpticalRxPower0 = opticalInfo.RxPower[0]
+	mainOpticalInfo.OpticalRxPower1 = opticalInfo.RxPower[1]
+	mainOpticalInfo.OpticalRxPower2 = opticalInfo.RxPower[2]
+	mainOpticalInfo.OpticalRxPower3 = opticalInfo.RxPower[3]
+	mainOpticalInfo.OpticalVcc = opticalInfo.Vcc
+	mainOpticalInfo.OpticalTemp = opticalInfo.Temperature
 
 	optState := 0.0
-	if opticalInfo[present] == present {
+	if opticalInfo.Present {
 		optState = 1.0
 	}
 	mainOpticalInfo.OpticalState = optState
@@ -1170,27 +1390,27 @@ func getMainOptInfo(opticalInfo map[string]string) OpticalInfo {
 	return mainOpticalInfo
 }
 
-func getMainStatInfo(statInfo map[string]int) StatInfo {
+func getMainStatInfo(statInfo parser.StatInfo) StatInfo {
 	mainStatInfo := StatInfo{}
-	mainStatInfo.MacRxPauseNum = float64(statInfo[macRxMacPauseNum])
-	mainStatInfo.MacTxPauseNum = float64(statInfo[macTxMacPauseNum])
-	mainStatInfo.MacRxPfcPktNum = float64(statInfo[macRxPfcPktNum])
-	mainStatInfo.MacTxPfcPktNum = float64(statInfo[macTxPfcPktNum])
-	mainStatInfo.MacRxBadPktNum = float64(statInfo[macRxBadPktNum])
-	mainStatInfo.MacTxBadPktNum = float64(statInfo[macTxBadPktNum])
-	mainStatInfo.RoceRxAllPktNum = float64(statInfo[roCERxAllPktNum])
-	mainStatInfo.RoceTxAllPktNum = float64(statInfo[roCETxAllPktNum])
-	mainStatInfo.RoceRxErrPktNum = float64(statInfo[roCERxErrPktNum])
-	mainStatInfo.RoceTxErrPktNum = float64(statInfo[roCETxErrPktNum])
-	mainStatInfo.RoceRxCnpPktNum = float64(statInfo[roCERxCnpPktNum])
-	mainStatInfo.RoceTxCnpPktNum = float64(statInfo[roCETxCnpPktNum])
-	mainStatInfo.MacRxBadOctNum = float64(statInfo[macRxBadOctNum])
-	mainStatInfo.MacTxBadOctNum = float64(statInfo[macTxBadOctNum])
-	mainStatInfo.RoceUnexpectedAckNum = float64(statInfo[roCEUnexpectedAckNum])
-	mainStatInfo.RoceOutOfOrderNum = float64(statInfo[roCEOutOfOrderNum])
-	mainStatInfo.RoceVerificationErrNum = float64(statInfo[roCEVerificationErrNum])
-	mainStatInfo.RoceQpStatusErrNum = float64(statInfo[roCEQpStatusErrNum])
-	mainStatInfo.RoceNewPktRtyNum = float64(statInfo[roCENewPktRtyNum])
+	mainStatInfo.MacRxPauseNum = float64(statInfo.MacRxPauseNum)
+	mainStatInfo.MacTxPauseNum = float64(statInfo.MacTxPauseNum)
+	mainStatInfo.MacRxPfcPktNum = float64(statInfo.MacRxPfcPktNum)
+	mainStatInfo.MacTxPfcPktNum = float64(statInfo.MacTxPfcPktNum)
+	mainStatInfo.MacRxBadPktNum = float64(statInfo.MacRxBadPktNum)
+	mainStatInfo.MacTxBadPktNum = float64(statInfo.MacTxBadPktNum)
+	mainStatInfo.RoceRxAllPktNum = float64(statInfo.RoceRxAllPktNum)
+	mainStatInfo.RoceTxAllPktNum = float64(statInfo.RoceTxAllPktNum)
+	mainStatInfo.RoceRxErrPktNum = float64(statInfo.RoceRxErrPktNum)
+	mainStatInfo.RoceTxErrPktNum = float64(statInfo.RoceTxErrPktNum)
+	mainStatInfo.RoceRxCnpPktNum = float64(statInfo.RoceRxCnpPktNum)
+	mainStatInfo.RoceTxCnpPktNum = float64(statInfo.RoceTxCnpPktNum)
+	mainStatInfo.MacRxBadOctNum = float64(statInfo.MacRxBadOctNum)
+	mainStatInfo.MacTxBadOctNum = float64(statInfo.MacTxBadOctNum)
+	mainStatInfo.RoceUnexpectedAckNum = float64(statInfo.RoceUnexpectedAckNum)
+	mainStatInfo.RoceOutOfOrderNum = float64(statInfo.RoceOutOfOrderNum)
+	mainStatInfo.RoceVerificationErrNum = float64(statInfo.RoceVerificationErrNum)
+	mainStatInfo.RoceQpStatusErrNum = float64(statInfo.RoceQpStatusErrNum)
+	mainStatInfo.RoceNewPktRtyNum = float64(statInfo.RoceNewPktRtyNum)
 
 	return mainStatInfo
 }
@@ -1207,10 +1427,19 @@ func networkPackInfo(phyID int32) NpuNetInfo {
 
 	if statInfo, err := hccn.GetNPUStatInfo(phyID); err == nil {
 		newNetInfo.StatInfo = getMainStatInfo(statInfo)
+		newNetInfo.StatSample = hccn.StatSample{Counters: statInfo.Counters(), Time: time.Now()}
 	}
 
-	linkUpNum := hccn.GetNPULinkUpNum(phyID)
-	newNetInfo.LinkStatInfo.LinkUPNum = float64(linkUpNum)
+	if linkStat, err := hccn.GetNPULinkStat(phyID); err == nil {
+		newNetInfo.LinkStatInfo.LinkUPNum = float64(linkStat.LinkUpCount)
+		newNetInfo.LinkStatInfo.LinkDownNum = float64(linkStat.LinkDownCount)
+	}
+	// the status is left empty when the query fails, so that the link flap detection keeps the last status
+	if status, err := hccn.GetTool().GetLinkStatus(phyID); err == nil {
+		newNetInfo.LinkStatInfo.LinkStatus = status
+	} else {
+		hwlog.RunLog.Warnf("get link status of npu %d failed, %v", phyID, err)
+	}
 
 	speed := hccn.GetNPULinkSpeed(phyID)
 	newNetInfo.LinkSpeedInfo.Speed = float64(speed)
@@ -1250,5 +1479,13 @@ func getPodDisplayInfo(chip *HuaWeiAIChip, containerName []string) []string {
 		containerName[podNameIdx],
 		containerName[conNameIdx],
 		strconv.FormatBool(chip.VDevActivityInfo.IsVirtualDev),
+		chip.CardType,
+		chip.ProductType,
 	}
 }
+
+// chipLabelValues return the values of chipLabels
+func chipLabelValues(chip *HuaWeiAIChip) []string {
+	return []string{strconv.FormatInt(int64(chip.DeviceID), base), common.GetNpuName(*chip.ChipIfo), chip.VDieID,
+		chip.PCIeBusInfo, chip.CardType, chip.ProductType}
+}
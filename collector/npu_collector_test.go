@@ -27,12 +27,14 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"huawei.com/npu-exporter/v5/collector/container"
+	"huawei.com/npu-exporter/v5/collector/container/docker"
 	"huawei.com/npu-exporter/v5/collector/container/isula"
 	"huawei.com/npu-exporter/v5/collector/container/v1"
 	"huawei.com/npu-exporter/v5/common-utils/cache"
 	"huawei.com/npu-exporter/v5/common-utils/hwlog"
 	"huawei.com/npu-exporter/v5/devmanager"
 	"huawei.com/npu-exporter/v5/devmanager/common"
+	"huawei.com/npu-exporter/v5/devmanager/hccn"
 )
 
 const (
@@ -70,6 +72,12 @@ func (operator *mockContainerRuntimeOperator) GetIsulaContainerInfoByID(ctx cont
 	return isula.ContainerJson{}, nil
 }
 
+// GetDockerContainerInfoByID implements ContainerRuntimeOperator
+func (operator *mockContainerRuntimeOperator) GetDockerContainerInfoByID(ctx context.Context,
+	id string) (docker.ContainerJSON, error) {
+	return docker.ContainerJSON{}, nil
+}
+
 // GetContainerType implements ContainerRuntimeOperator
 func (operator *mockContainerRuntimeOperator) GetContainerType() string {
 	return container.DefaultContainer
@@ -136,14 +144,17 @@ func excuteTestCollector(t *testing.T, tt struct {
 		"npu_chip_info_hbm_used_memory", "npu_chip_info_health_status", "npu_chip_info_link_status",
 		"npu_chip_info_name", "npu_chip_info_network_status", "npu_chip_info_power", "npu_chip_info_temperature",
 		"npu_chip_info_total_memory", "npu_chip_info_used_memory", "npu_chip_info_utilization",
-		"npu_chip_info_voltage", "npu_exporter_version_info"}
+		"npu_chip_info_voltage", "npu_exporter_version_info", "npu_chip_info_aicore_rated_freq",
+		"npu_chip_info_ctrl_cpu_freq", "npu_chip_info_hbm_freq", "npu_chip_info_hbm_temperature",
+		"npu_chip_info_hbm_bandwidth_utilization", "npu_chip_info_memory_freq", "npu_chip_info_memory_utilization"}
 	startStub := gomonkey.ApplyFunc(start, tt.mockFunc)
 	defer startStub.Reset()
 	patch := gomonkey.ApplyFunc(devmanager.AutoInit, func(s string) (*devmanager.DeviceManager, error) {
 		return &devmanager.DeviceManager{}, nil
 	})
 	defer patch.Reset()
-	c, err := NewNpuCollector(context.Background(), cacheTime, time.Second, makeMockDevicesParser())
+	c, err := NewNpuCollector(context.Background(), cacheTime, time.Second, makeMockDevicesParser(),
+		NpuCollectorOpts{})
 	if err != nil {
 		t.Fatalf("test failes")
 	}
@@ -182,6 +193,123 @@ func TestGetChipInfo(t *testing.T) {
 	}
 }
 
+// TestPackChipFreqInfo test method packChipFreqInfo
+func TestPackChipFreqInfo(t *testing.T) {
+	t.Run("should return frequencies when dcmi works normally", func(t *testing.T) {
+		chip := &HuaWeiAIChip{CardType: common.Ascend910}
+		packChipFreqInfo(0, &devmanager.DeviceManagerMock{}, chip)
+		assert.Equal(t, uint32(1), chip.AICoreRatedFreq)
+		assert.Equal(t, uint32(1), chip.CtrlCpuFreq)
+		assert.Equal(t, uint32(1), chip.HbmFreq)
+	})
+	t.Run("should use hbm info frequency when dcmi works abnormally", func(t *testing.T) {
+		chip := &HuaWeiAIChip{CardType: common.Ascend910B, HbmInfo: &common.HbmInfo{Frequency: 1600}}
+		packChipFreqInfo(0, &devmanager.DeviceManagerMockErr{}, chip)
+		assert.Equal(t, uint32(common.InvalidVal), chip.AICoreRatedFreq)
+		assert.Equal(t, uint32(common.InvalidVal), chip.CtrlCpuFreq)
+		assert.Equal(t, uint32(1600), chip.HbmFreq)
+	})
+	t.Run("should not query hbm frequency when card is 310", func(t *testing.T) {
+		chip := &HuaWeiAIChip{CardType: common.Ascend310}
+		packChipFreqInfo(0, &devmanager.DeviceManagerMock{}, chip)
+		assert.Equal(t, uint32(common.InvalidVal), chip.HbmFreq)
+	})
+}
+
+// TestUpdateNPUFreqInfo test the frequencies which are not supported are not exported
+func TestUpdateNPUFreqInfo(t *testing.T) {
+	const chanSize = 4
+	collect := func(chip *HuaWeiAIChip) int {
+		chip.ChipIfo = &common.ChipInfo{}
+		ch := make(chan prometheus.Metric, chanSize)
+		updateNPUFreqInfo(ch, &HuaWeiNPUCard{Timestamp: time.Now()}, chip)
+		close(ch)
+		return len(ch)
+	}
+	t.Run("should export all frequencies when they are queried", func(t *testing.T) {
+		assert.Equal(t, 3, collect(&HuaWeiAIChip{AICoreRatedFreq: 1, CtrlCpuFreq: 1, HbmFreq: 1}))
+	})
+	t.Run("should not export hbm frequency when card is 310P", func(t *testing.T) {
+		assert.Equal(t, 2, collect(&HuaWeiAIChip{CardType: common.Ascend310P, AICoreRatedFreq: 1,
+			CtrlCpuFreq: 1, HbmFreq: common.InvalidVal}))
+	})
+	t.Run("should not export frequencies when query failed", func(t *testing.T) {
+		assert.Equal(t, 0, collect(&HuaWeiAIChip{}))
+	})
+}
+
+// TestPackChipCoreUtil test packChipCoreUtil
+func TestPackChipCoreUtil(t *testing.T) {
+	t.Run("should return utilization of 910 core types when dcmi works normally", func(t *testing.T) {
+		chip := &HuaWeiAIChip{CardType: common.Ascend910}
+		packChipCoreUtil(0, &devmanager.DeviceManagerMock{}, chip)
+		assert.Equal(t, map[string]uint32{"aicpu": 1, "ctrlcpu": 1}, chip.CoreUtilization)
+	})
+	t.Run("should skip core types when dcmi works abnormally", func(t *testing.T) {
+		chip := &HuaWeiAIChip{CardType: common.Ascend910}
+		packChipCoreUtil(0, &devmanager.DeviceManagerMockErr{}, chip)
+		assert.Empty(t, chip.CoreUtilization)
+	})
+	t.Run("should query vector core only when chip is 310P", func(t *testing.T) {
+		assert.Contains(t, chipCoreUtilTypes(common.Ascend310P), vectorCoreUtil)
+		assert.NotContains(t, chipCoreUtilTypes(common.Ascend910B), vectorCoreUtil)
+	})
+}
+
+// TestNetworkPackInfo test networkPackInfo with the canned outputs of hccn_tool
+func TestNetworkPackInfo(t *testing.T) {
+	runner := &hccn.FakeRunner{Outputs: map[string]string{
+		"-bandwidth": "Bandwidth TX: 12.50 MB/sec\nBandwidth RX: 8.25 MB/sec\n",
+		"-optical":   "present : present\nTx_Power0 : 0.6012 mW\nRx_Power3 : -3.0103 dBm\nVcc : 3.27 V\n",
+		"-stat":      "mac_rx_mac_pause_num:12\nroce_rx_err_pkt_num : 2\n",
+		"-link_stat": "[device 1]link up count : 3\n",
+		"-speed":     "Speed: 100 Gb/s\n",
+		"-link":      "link status: UP\n",
+	}}
+	defer hccn.SetTool(hccn.GetTool())
+	hccn.SetTool(hccn.NewTool(context.Background(), runner))
+	t.Run("should pack network info when hccn_tool works normally", func(t *testing.T) {
+		netInfo := networkPackInfo(1)
+		assert.Equal(t, 12.5, netInfo.BandwidthInfo.TxValue)
+		assert.Equal(t, 8.25, netInfo.BandwidthInfo.RxValue)
+		assert.Equal(t, 1.0, netInfo.OpticalInfo.OpticalState)
+		assert.Equal(t, 0.6012, netInfo.OpticalInfo.OpticalTxPower0)
+		assert.InDelta(t, 0.5, netInfo.OpticalInfo.OpticalRxPower3, 1e-4)
+		assert.Equal(t, 3270.0, netInfo.OpticalInfo.OpticalVcc)
+		assert.Equal(t, 12.0, netInfo.StatInfo.MacRxPauseNum)
+		assert.Equal(t, 2.0, netInfo.StatInfo.RoceRxErrPktNum)
+		assert.Equal(t, 3.0, netInfo.LinkStatInfo.LinkUPNum)
+		assert.Equal(t, 100000.0, netInfo.LinkSpeedInfo.Speed)
+		assert.Equal(t, LinkUp, netInfo.LinkStatInfo.LinkStatus)
+		assert.Len(t, runner.Calls(), 6)
+	})
+	t.Run("should leave network info empty when hccn_tool fails", func(t *testing.T) {
+		hccn.SetTool(hccn.NewTool(context.Background(), &hccn.FakeRunner{}))
+		assert.Equal(t, NpuNetInfo{}, networkPackInfo(1))
+	})
+}
+
+// TestSetLinkStatus test the link status of the chip is the one queried by networkPackInfo
+func TestSetLinkStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		netInfo *NpuNetInfo
+		want    string
+	}{
+		{name: "should use the status of network info when it is queried",
+			netInfo: &NpuNetInfo{LinkStatInfo: LinkStatInfo{LinkStatus: LinkUp}}, want: LinkUp},
+		{name: "should be link down when status is never queried", netInfo: &NpuNetInfo{}, want: LinkDown},
+		{name: "should be link down when chip has no network info", want: LinkDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chip := &HuaWeiAIChip{NetInfo: tt.netInfo, LinkStatus: LinkUp}
+			setLinkStatus(chip)
+			assert.Equal(t, tt.want, chip.LinkStatus)
+		})
+	}
+}
+
 // TestGetHealthCode test getHealthCode
 func TestGetHealthCode(t *testing.T) {
 	tests := []struct {
@@ -287,6 +415,8 @@ func mockGetNPUInfo(dmgr devmanager.DeviceInterface) []HuaWeiNPUCard {
 			DevProcessInfo:  &common.DevProcessInfo{},
 			LinkStatus:      LinkDown,
 			NetHealthStatus: UnHealthy,
+			CardType:        common.Ascend910,
+			ProductType:     "Atlas 800",
 		}
 		chipInfo.DeviceID = int(devicePhysicID)
 		npuCard := HuaWeiNPUCard{
@@ -313,6 +443,7 @@ func TestStart(t *testing.T) {
 				cacheTime:     cacheTime,
 				updateTime:    time.Second,
 				devicesParser: makeMockDevicesParser(),
+				opts:          NpuCollectorOpts{}.withDefaults(),
 			},
 		},
 	}
@@ -339,3 +470,114 @@ func init() {
 	}
 	hwlog.InitRunLogger(&config, nil)
 }
+
+// vnpuDeviceManagerMock the mock of an Ascend910 chip with a vNPU
+type vnpuDeviceManagerMock struct {
+	devmanager.DeviceManagerMock
+}
+
+func (d *vnpuDeviceManagerMock) GetVirtualDeviceInfo(logicID int32) (common.VirtualDevInfo, error) {
+	const aiCore = 2
+	return common.VirtualDevInfo{
+		TotalResource: common.CgoSocTotalResource{VDevNum: 1, VDevID: []uint32{common.MinVDevID}},
+		VDevInfo: []common.CgoVDevQueryStru{{VDevID: common.MinVDevID,
+			QueryInfo: common.CgoVDevQueryInfo{Name: "vir02"}}},
+		VDevActivityInfo: []common.VDevActivityInfo{{VDevID: common.MinVDevID, VDevAiCore: aiCore,
+			IsVirtualDev: true}},
+	}, nil
+}
+
+// TestGetNPUInfoWithVNPU test the vNPUs are discovered on the chips other than Ascend310P
+func TestGetNPUInfoWithVNPU(t *testing.T) {
+	npuList := getNPUInfo(&vnpuDeviceManagerMock{})
+	assert.Len(t, npuList, 1)
+	assert.Len(t, npuList[0].DeviceList, 1)
+	chip := npuList[0].DeviceList[0]
+	assert.Equal(t, common.VDevActivityInfo{VDevID: common.MinVDevID, VDevAiCore: 2, IsVirtualDev: true},
+		chip.VDevActivityInfo)
+	const chanSize = 8
+	ch := make(chan prometheus.Metric, chanSize)
+	devInfo := container.DevicesInfo{ID: "c1", Name: "ns_pod_container"}
+	updatePodVNPUInfo(ch, &npuList[0], chip, devInfo)
+	close(ch)
+	assert.Len(t, ch, 3, "the vnpu_pod metrics should be reported for the vNPU of Ascend910")
+	t.Run("should not report vnpu_pod metrics when activity of vNPU is unavailable", func(t *testing.T) {
+		unavailable := *chip
+		unavailable.VDevActivityInfo.ActivityUnavailable = true
+		ch := make(chan prometheus.Metric, chanSize)
+		updatePodVNPUInfo(ch, &npuList[0], &unavailable, devInfo)
+		close(ch)
+		assert.Empty(t, ch)
+	})
+}
+
+// mixedDeviceManagerMock the mock of a heterogeneous server, the chip of card 0 is Ascend310P and the others are
+// Ascend910B
+type mixedDeviceManagerMock struct {
+	devmanager.DeviceManagerMock
+}
+
+func (d *mixedDeviceManagerMock) GetCardIDDeviceID(logicID int32) (int32, int32, error) {
+	return logicID, 0, nil
+}
+
+func (d *mixedDeviceManagerMock) GetCardType(cardID int32) string {
+	if cardID == 0 {
+		return common.Ascend310P
+	}
+	return common.Ascend910B
+}
+
+func (d *mixedDeviceManagerMock) GetCardProductType(cardID int32) string {
+	if cardID == 0 {
+		return "Atlas 300V Pro"
+	}
+	return "Atlas 300T A2"
+}
+
+// TestPackChipInfoWithMixedCards test the chips are packed by the device type of their own card
+func TestPackChipInfoWithMixedCards(t *testing.T) {
+	dmgr := &mixedDeviceManagerMock{}
+	t.Run("should query vector core when card is 310P", func(t *testing.T) {
+		chip := packChipInfo(0, dmgr)
+		assert.Equal(t, common.Ascend310P, chip.CardType)
+		assert.Equal(t, "Atlas 300V Pro", chip.ProductType)
+		assert.Contains(t, chip.CoreUtilization, vectorCoreUtil.name)
+		assert.Equal(t, uint32(common.InvalidVal), chip.HbmFreq)
+	})
+	t.Run("should not query vector core when card is 910B", func(t *testing.T) {
+		chip := packChipInfo(1, dmgr)
+		assert.Equal(t, common.Ascend910B, chip.CardType)
+		assert.Equal(t, "Atlas 300T A2", chip.ProductType)
+		assert.NotContains(t, chip.CoreUtilization, vectorCoreUtil.name)
+		assert.Equal(t, uint32(1), chip.HbmFreq)
+	})
+	t.Run("should label chip metrics with card type and product type", func(t *testing.T) {
+		chip := &HuaWeiAIChip{DeviceID: 1, ChipIfo: &common.ChipInfo{Name: "910B4"}, CardType: common.Ascend910B,
+			ProductType: "Atlas 300T A2"}
+		assert.Equal(t, len(chipLabels), len(chipLabelValues(chip)))
+		assert.Equal(t, []string{common.Ascend910B, "Atlas 300T A2"}, chipLabelValues(chip)[len(chipLabels)-2:])
+	})
+}
+
+// TestUpdateNPUMemoryExtInfo test the hbm and memory metrics are only exported for the chips which have them
+func TestUpdateNPUMemoryExtInfo(t *testing.T) {
+	const chanSize = 8
+	collect := func(cardType string) int {
+		chip := &HuaWeiAIChip{ChipIfo: &common.ChipInfo{}, CardType: cardType, HbmInfo: &common.HbmInfo{},
+			Meminf: &common.MemoryInfo{Frequency: 1}}
+		ch := make(chan prometheus.Metric, chanSize)
+		updateNPUMemoryExtInfo(ch, &HuaWeiNPUCard{Timestamp: time.Now()}, chip)
+		close(ch)
+		return len(ch)
+	}
+	t.Run("should export hbm and memory metrics when card is 910", func(t *testing.T) {
+		assert.Equal(t, 4, collect(common.Ascend910))
+	})
+	t.Run("should export hbm metrics only when card is 910B", func(t *testing.T) {
+		assert.Equal(t, 2, collect(common.Ascend910B))
+	})
+	t.Run("should export memory metrics only when card is 310P", func(t *testing.T) {
+		assert.Equal(t, 2, collect(common.Ascend310P))
+	})
+}
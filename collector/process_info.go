@@ -0,0 +1,241 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/common-utils/utils"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+const (
+	// DefaultProcRoot the default procfs which the metadata of the processes running on the chips is read from
+	DefaultProcRoot = "/proc"
+	// DefaultProcessTopN the default max number of processes exported for each chip, 0 means no limit
+	DefaultProcessTopN = 0
+
+	// userHZ the clock ticks per second of the start time in /proc/<pid>/stat, which is 100 on arm64 and x86_64
+	userHZ          = 100
+	cmdlineHashLen  = 16
+	maxProcFileSize = 64 * 1024
+	maxPasswdSize   = 1024 * 1024
+	// statStartTimeIdx the index of the start time in the fields after the command name of /proc/<pid>/stat
+	statStartTimeIdx = 19
+	btimeKey         = "btime"
+	uidKey           = "Uid:"
+	// passwdUIDIdx the index of the uid in the fields of a line of /etc/passwd
+	passwdUIDIdx = 2
+)
+
+// ProcessOpts the options of exporting the processes running on the chips
+type ProcessOpts struct {
+	// ProcRoot the procfs of the host, which is mounted into the container of the exporter
+	ProcRoot string
+	// TopN the max number of processes with the most memory usage exported for each chip, 0 means no limit
+	TopN int
+	// DropEnded drops the processes which are still reported by the chip but have ended on the host
+	DropEnded bool
+}
+
+// HostProcess the metadata of a process running on the chip, which is read from the procfs of the host
+type HostProcess struct {
+	Name        string `json:"name"`
+	CmdlineHash string `json:"cmdline_hash"`
+	User        string `json:"user"`
+	// StartTime the unix time when the process started, 0 if it is unknown
+	StartTime int64 `json:"start_time"`
+	// Ended the process is not found on the host
+	Ended bool `json:"ended"`
+}
+
+// setHostProcesses reads the metadata of the processes running on the chips from the procfs, the vNPUs of a chip
+// share the processes of the chip
+func setHostProcesses(npuList []HuaWeiNPUCard, procRoot string) {
+	chipProcesses := make(map[int]map[int32]HostProcess, initSize)
+	for _, card := range npuList {
+		for _, chip := range card.DeviceList {
+			if chip == nil {
+				continue
+			}
+			processes, ok := chipProcesses[chip.DeviceID]
+			if !ok {
+				processes = getHostProcesses(procRoot, chip.DevProcessInfo)
+				chipProcesses[chip.DeviceID] = processes
+			}
+			chip.HostProcesses = processes
+		}
+	}
+}
+
+// getHostProcesses reads the metadata of the processes running on the chip from the procfs
+func getHostProcesses(procRoot string, info *common.DevProcessInfo) map[int32]HostProcess {
+	if info == nil || info.ProcNum <= 0 {
+		return nil
+	}
+	bootTime, err := readBootTime(procRoot)
+	if err != nil {
+		hwlog.RunLog.Debugf("read boot time from %s failed: %v", procRoot, err)
+	}
+	users := readHostUsers(procRoot)
+	processes := make(map[int32]HostProcess, info.ProcNum)
+	for i := int32(0); i < info.ProcNum && int(i) < len(info.DevProcArray); i++ {
+		pid := info.DevProcArray[i].Pid
+		processes[pid] = readHostProcess(procRoot, pid, bootTime, users)
+	}
+	return processes
+}
+
+func readHostProcess(procRoot string, pid int32, bootTime int64, users map[string]string) HostProcess {
+	pidDir := filepath.Join(procRoot, strconv.Itoa(int(pid)))
+	if _, err := os.Stat(pidDir); err != nil {
+		return HostProcess{Ended: os.IsNotExist(err)}
+	}
+	var process HostProcess
+	name, startTicks, err := readProcStat(filepath.Join(pidDir, "stat"))
+	if err != nil {
+		hwlog.RunLog.Debugf("read stat of process %d failed: %v", pid, err)
+	} else {
+		process.Name = name
+		if bootTime > 0 {
+			process.StartTime = bootTime + startTicks/userHZ
+		}
+	}
+	process.CmdlineHash = readCmdlineHash(filepath.Join(pidDir, "cmdline"))
+	process.User = readProcUser(filepath.Join(pidDir, "status"), users)
+	return process
+}
+
+// readProcStat returns the command name and the start time in clock ticks after boot, the command name is in
+// parentheses and may contain spaces or parentheses
+func readProcStat(path string) (string, int64, error) {
+	data, err := utils.ReadLimitBytes(path, maxProcFileSize)
+	if err != nil {
+		return "", 0, err
+	}
+	content := string(data)
+	start, end := strings.Index(content, "("), strings.LastIndex(content, ")")
+	if start < 0 || end < start {
+		return "", 0, errors.New("invalid format of stat")
+	}
+	fields := strings.Fields(content[end+1:])
+	if len(fields) <= statStartTimeIdx {
+		return "", 0, errors.New("too few fields in stat")
+	}
+	startTicks, err := strconv.ParseInt(fields[statStartTimeIdx], base, bitSize)
+	if err != nil {
+		return "", 0, err
+	}
+	return content[start+1 : end], startTicks, nil
+}
+
+// readCmdlineHash returns the hash of the command line, which identifies the same program without exporting its
+// arguments, it is empty when the command line is empty or unreadable
+func readCmdlineHash(path string) string {
+	data, err := utils.ReadLimitBytes(path, maxProcFileSize)
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:cmdlineHashLen]
+}
+
+// readProcUser returns the name of the real user of the process, or the uid if it is not found on the host
+func readProcUser(path string, users map[string]string) string {
+	data, err := utils.ReadLimitBytes(path, maxProcFileSize)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != uidKey {
+			continue
+		}
+		if name, ok := users[fields[1]]; ok {
+			return name
+		}
+		return fields[1]
+	}
+	return ""
+}
+
+// readHostUsers returns the user names keyed by the uid, which are read from the passwd of the host next to the
+// procfs, e.g. /host/etc/passwd for /host/proc, because the passwd in the container of the exporter is not the host's
+func readHostUsers(procRoot string) map[string]string {
+	path := filepath.Join(filepath.Dir(filepath.Clean(procRoot)), "etc", "passwd")
+	data, err := utils.ReadLimitBytes(path, maxPasswdSize)
+	if err != nil {
+		hwlog.RunLog.Debugf("read users of host from %s failed: %v", path, err)
+		return nil
+	}
+	users := make(map[string]string, initSize)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) <= passwdUIDIdx || fields[0] == "" || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if _, ok := users[fields[passwdUIDIdx]]; !ok {
+			users[fields[passwdUIDIdx]] = fields[0]
+		}
+	}
+	return users
+}
+
+// readBootTime returns the unix time when the host booted
+func readBootTime(procRoot string) (int64, error) {
+	data, err := utils.ReadLimitBytes(filepath.Join(procRoot, "stat"), maxProcFileSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == btimeKey {
+			return strconv.ParseInt(fields[1], base, bitSize)
+		}
+	}
+	return 0, errors.New("btime is not found")
+}
+
+// selectProcesses returns the processes exported for the chip, which are sorted by the memory usage and limited
+// to the top N, the ended processes are dropped when it is required
+func selectProcesses(chip *HuaWeiAIChip, opts ProcessOpts) []common.DevProcInfo {
+	if chip.DevProcessInfo == nil {
+		return nil
+	}
+	procs := make([]common.DevProcInfo, 0, len(chip.DevProcessInfo.DevProcArray))
+	for i := int32(0); i < chip.DevProcessInfo.ProcNum && int(i) < len(chip.DevProcessInfo.DevProcArray); i++ {
+		proc := chip.DevProcessInfo.DevProcArray[i]
+		if opts.DropEnded && chip.HostProcesses[proc.Pid].Ended {
+			continue
+		}
+		procs = append(procs, proc)
+	}
+	sort.SliceStable(procs, func(i, j int) bool {
+		return procs[i].MemUsage > procs[j].MemUsage
+	})
+	if opts.TopN > 0 && len(procs) > opts.TopN {
+		procs = procs[:opts.TopN]
+	}
+	return procs
+}
@@ -0,0 +1,137 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+const (
+	fakeBootTime = 1690000000
+	// the start time of the fake processes is 50000 clock ticks, which is 500 seconds after boot
+	fakeStatTail  = " S 1 100 100 0 -1 4194560 0 0 0 0 0 0 0 0 20 0 1 0 50000 1000 100\n"
+	fakeStartTime = fakeBootTime + 500
+)
+
+// makeFakeProcfs makes a procfs with the process 100 run by the user trainer of the host, the process 200 whose user
+// is not on the host and whose command name contains parentheses, the process 300 has ended
+func makeFakeProcfs(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"etc/passwd":       "root:x:0:0:root:/root:/bin/bash\ntrainer:x:1001:1001::/home/trainer:/bin/bash\n",
+		"proc/stat":        "cpu  1 2 3 4\nbtime 1690000000\nprocesses 300\n",
+		"proc/100/stat":    "100 (python3)" + fakeStatTail,
+		"proc/100/cmdline": "python3\x00train.py\x00",
+		"proc/100/status":  "Name:\tpython3\nUid:\t1001\t1001\t1001\t1001\n",
+		"proc/200/stat":    "200 (infer (v2) srv)" + fakeStatTail,
+		"proc/200/cmdline": "",
+		"proc/200/status":  "Name:\tinfer\nUid:\t54321\t54321\t54321\t54321\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return filepath.Join(root, "proc")
+}
+
+func mockDevProcessInfo() *common.DevProcessInfo {
+	return &common.DevProcessInfo{ProcNum: 3, DevProcArray: []common.DevProcInfo{{Pid: 100, MemUsage: 1024},
+		{Pid: 200, MemUsage: 4096}, {Pid: 300, MemUsage: 2048}}}
+}
+
+// TestGetHostProcesses test the metadata of the processes is read from the procfs
+func TestGetHostProcesses(t *testing.T) {
+	root := makeFakeProcfs(t)
+	processes := getHostProcesses(root, mockDevProcessInfo())
+	t.Run("should read name, command line, user and start time when process is running", func(t *testing.T) {
+		process := processes[100]
+		assert.Equal(t, "python3", process.Name)
+		assert.Len(t, process.CmdlineHash, cmdlineHashLen)
+		assert.Equal(t, "trainer", process.User)
+		assert.Equal(t, int64(fakeStartTime), process.StartTime)
+		assert.False(t, process.Ended)
+	})
+	t.Run("should keep parentheses in name and return uid when user is not found", func(t *testing.T) {
+		process := processes[200]
+		assert.Equal(t, "infer (v2) srv", process.Name)
+		assert.Empty(t, process.CmdlineHash)
+		assert.Equal(t, "54321", process.User)
+	})
+	t.Run("should mark process as ended when it is not found on host", func(t *testing.T) {
+		assert.Equal(t, HostProcess{Ended: true}, processes[300])
+	})
+	t.Run("should return uid when passwd of host is not readable", func(t *testing.T) {
+		if err := os.Remove(filepath.Join(filepath.Dir(root), "etc", "passwd")); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "1001", getHostProcesses(root, mockDevProcessInfo())[100].User)
+	})
+	t.Run("should return nil when there is no process", func(t *testing.T) {
+		assert.Nil(t, getHostProcesses(root, &common.DevProcessInfo{}))
+	})
+}
+
+// TestSetHostProcesses test the processes of the chips in the npu list are read from the procfs
+func TestSetHostProcesses(t *testing.T) {
+	vNPU1 := mock310PVNPU(1, common.MinVDevID, 0)
+	vNPU2 := mock310PVNPU(1, common.MinVDevID+1, 0)
+	vNPU1.DevProcessInfo = mockDevProcessInfo()
+	vNPU2.DevProcessInfo = vNPU1.DevProcessInfo
+	setHostProcesses([]HuaWeiNPUCard{{CardID: 0, DeviceList: []*HuaWeiAIChip{vNPU1, vNPU2, nil}}},
+		makeFakeProcfs(t))
+	t.Run("should share processes of chip between vnpus when they are on same chip", func(t *testing.T) {
+		assert.Len(t, vNPU1.HostProcesses, len(vNPU1.DevProcessInfo.DevProcArray))
+		assert.Equal(t, vNPU1.HostProcesses, vNPU2.HostProcesses)
+		assert.Equal(t, "python3", vNPU2.HostProcesses[100].Name)
+	})
+}
+
+// TestSelectProcesses test the processes are limited to the top N by memory usage and the ended ones are dropped
+func TestSelectProcesses(t *testing.T) {
+	chip := &HuaWeiAIChip{DevProcessInfo: mockDevProcessInfo(),
+		HostProcesses: getHostProcesses(makeFakeProcfs(t), mockDevProcessInfo())}
+	tests := []struct {
+		name string
+		opts ProcessOpts
+		want []int32
+	}{
+		{name: "should sort processes by memory usage when there is no limit", want: []int32{200, 300, 100}},
+		{name: "should keep top n processes when processes exceed limit", opts: ProcessOpts{TopN: 2},
+			want: []int32{200, 300}},
+		{name: "should drop ended processes when it is required", opts: ProcessOpts{TopN: 2, DropEnded: true},
+			want: []int32{200, 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pids []int32
+			for _, proc := range selectProcesses(chip, tt.opts) {
+				pids = append(pids, proc.Pid)
+			}
+			assert.Equal(t, tt.want, pids)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"huawei.com/npu-exporter/v5/collector/container"
+	"huawei.com/npu-exporter/v5/common-utils/cache"
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/common-utils/utils"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+const (
+	// maxRecordSize the recording stops when the record file is larger than it
+	maxRecordSize = 100 * 1024 * 1024
+	// maxSnapshotNum the max number of snapshots which can be replayed
+	maxSnapshotNum = 10000
+	// MaxSimulateSpeed the max time scaling of the replay
+	MaxSimulateSpeed = 1000
+)
+
+// Snapshot the npu, network and container information in the cache of one update cycle, the snapshots are
+// recorded as json lines
+type Snapshot struct {
+	// Offset the time since the recording started
+	Offset     time.Duration          `json:"offset"`
+	NPUList    []HuaWeiNPUCard        `json:"npu_list"`
+	NetInfo    map[int32]NpuNetInfo   `json:"net_info"`
+	Containers container.DevicesInfos `json:"containers"`
+}
+
+// SimulateOpts the options of replaying the recorded snapshots
+type SimulateOpts struct {
+	// File the record file written by the record mode
+	File string
+	// Speed the time scaling of the replay, 2 means the timeline is replayed twice as fast as recorded
+	Speed      float64
+	CacheTime  time.Duration
+	UpdateTime time.Duration
+	// Collector the options of the collector which are applied to the replayed snapshots
+	Collector NpuCollectorOpts
+}
+
+// NewNpuCollectorWithRecord create an instance of prometheus Collector which also appends the snapshot of the
+// cache to the record file in every update cycle, the record file can be replayed by NewSimulateCollector
+func NewNpuCollectorWithRecord(ctx context.Context, cacheTime time.Duration, updateTime time.Duration,
+	deviceParser *container.DevicesParser, opts NpuCollectorOpts, recordFile string) (prometheus.Collector, error) {
+	if _, err := utils.CheckPath(recordFile); err != nil {
+		return nil, fmt.Errorf("check record file failed: %v", err)
+	}
+	c, err := NewNpuCollector(ctx, cacheTime, updateTime, deviceParser, opts)
+	if err != nil {
+		return nil, err
+	}
+	npuCollect, ok := c.(*npuCollector)
+	if !ok {
+		return nil, errors.New("convert npu collector failed")
+	}
+	go recordSnapshots(ctx, npuCollect, recordFile)
+	return npuCollect, nil
+}
+
+// NewSimulateCollector create an instance of prometheus Collector which replays the recorded snapshots in a loop
+// in place of dcmi, hccn_tool and container runtime
+func NewSimulateCollector(ctx context.Context, opts SimulateOpts) (prometheus.Collector, error) {
+	if opts.Speed <= 0 || opts.Speed > MaxSimulateSpeed {
+		return nil, fmt.Errorf("the simulate speed should be in (0, %d]", MaxSimulateSpeed)
+	}
+	snapshots, err := loadSnapshots(opts.File)
+	if err != nil {
+		return nil, err
+	}
+	npuCollect := &npuCollector{
+		cache:      cache.New(cacheSize),
+		cacheTime:  opts.CacheTime,
+		updateTime: opts.UpdateTime,
+		opts:       opts.Collector.withDefaults(),
+	}
+	player := newSnapshotPlayer(snapshots, opts.Speed)
+	// the cache is set before serving, otherwise Collect tries to rebuild it by the real backends
+	setSnapshotCache(npuCollect, player.pick(0), time.Now())
+	go replaySnapshots(ctx, npuCollect, player)
+	hwlog.RunLog.Infof("simulate with %d snapshots of %v, speed is %v", len(snapshots), player.period,
+		opts.Speed)
+	return npuCollect, nil
+}
+
+// snapshotPlayer picks the snapshot by the time since the replay started, the timeline is looped
+type snapshotPlayer struct {
+	snapshots []Snapshot
+	speed     float64
+	// period the duration of the timeline, the last snapshot lasts as long as the interval before it
+	period time.Duration
+}
+
+func newSnapshotPlayer(snapshots []Snapshot, speed float64) *snapshotPlayer {
+	p := &snapshotPlayer{snapshots: snapshots, speed: speed}
+	last := len(snapshots) - 1
+	p.period = snapshots[last].Offset + time.Second
+	if last > 0 && snapshots[last].Offset > snapshots[last-1].Offset {
+		p.period = 2*snapshots[last].Offset - snapshots[last-1].Offset
+	}
+	return p
+}
+
+func (p *snapshotPlayer) pick(elapsed time.Duration) Snapshot {
+	offset := time.Duration(float64(elapsed)*p.speed) % p.period
+	idx := sort.Search(len(p.snapshots), func(i int) bool {
+		return p.snapshots[i].Offset > offset
+	})
+	if idx == 0 {
+		return p.snapshots[0]
+	}
+	return p.snapshots[idx-1]
+}
+
+func replaySnapshots(ctx context.Context, n *npuCollector, player *snapshotPlayer) {
+	startTime := time.Now()
+	ticker := time.NewTicker(n.updateTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			hwlog.RunLog.Info("received the stop signal, simulation STOPPED")
+			return
+		case now := <-ticker.C:
+			setSnapshotCache(n, player.pick(now.Sub(startTime)), now)
+		}
+	}
+}
+
+// setSnapshotCache sets the snapshot into the cache, the timestamps are replaced by now so that the metrics are
+// not rejected as out of date
+func setSnapshotCache(n *npuCollector, snapshot Snapshot, now time.Time) {
+	npuList := make([]HuaWeiNPUCard, len(snapshot.NPUList))
+	for i, card := range snapshot.NPUList {
+		card.Timestamp = now
+		npuList[i] = card
+	}
+	netInfo := snapshot.NetInfo
+	if netInfo == nil {
+		netInfo = make(map[int32]NpuNetInfo, initSize)
+	}
+	containers := snapshot.Containers
+	if containers == nil {
+		containers = make(container.DevicesInfos, initSize)
+	}
+	for key, value := range map[string]interface{}{npuListCacheKey: npuList, npuNetworkCacheKey: netInfo,
+		containersDevicesCacheKey: containers} {
+		if err := n.cache.Set(key, value, n.cacheTime); err != nil {
+			hwlog.RunLog.Error(err)
+		}
+	}
+}
+
+func loadSnapshots(path string) ([]Snapshot, error) {
+	realPath, err := utils.CheckPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("check simulate file failed: %v", err)
+	}
+	file, err := os.Open(realPath)
+	if err != nil {
+		return nil, fmt.Errorf("open simulate file failed: %v", err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), utils.Size10M)
+	var snapshots []Snapshot
+	for scanner.Scan() {
+		if len(snapshots) >= maxSnapshotNum {
+			hwlog.RunLog.Warnf("only the first %d snapshots are replayed", maxSnapshotNum)
+			break
+		}
+		var snapshot Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			return nil, fmt.Errorf("unmarshal snapshot %d failed: %v", len(snapshots), err)
+		}
+		fillSnapshot(&snapshot)
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read simulate file failed: %v", err)
+	}
+	if len(snapshots) == 0 {
+		return nil, errors.New("no snapshot in simulate file")
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Offset < snapshots[j].Offset
+	})
+	return snapshots, nil
+}
+
+// fillSnapshot fills the chip information which is always set by packChipInfo but may be missing in a hand-edited
+// snapshot
+func fillSnapshot(snapshot *Snapshot) {
+	for i := range snapshot.NPUList {
+		var chips []*HuaWeiAIChip
+		for _, chip := range snapshot.NPUList[i].DeviceList {
+			if chip == nil {
+				continue
+			}
+			chips = append(chips, chip)
+			if chip.ChipIfo == nil {
+				chip.ChipIfo = &common.ChipInfo{}
+			}
+			if chip.Meminf == nil {
+				chip.Meminf = &common.MemoryInfo{}
+			}
+			if chip.HbmInfo == nil {
+				chip.HbmInfo = &common.HbmInfo{}
+			}
+			if chip.DevProcessInfo == nil {
+				chip.DevProcessInfo = &common.DevProcessInfo{}
+			}
+		}
+		snapshot.NPUList[i].DeviceList = chips
+	}
+}
+
+// snapshotRecorder appends the snapshots to the record file until it is too large
+type snapshotRecorder struct {
+	file *os.File
+	size int64
+	// startTime the time of the first snapshot, the offsets are relative to it
+	startTime time.Time
+}
+
+func newSnapshotRecorder(path string) (*snapshotRecorder, error) {
+	realPath, err := utils.CheckPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("check record file failed: %v", err)
+	}
+	file, err := os.OpenFile(realPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, utils.FileMode)
+	if err != nil {
+		return nil, fmt.Errorf("open record file failed: %v", err)
+	}
+	return &snapshotRecorder{file: file}, nil
+}
+
+func (r *snapshotRecorder) record(snapshot Snapshot, now time.Time) error {
+	if r.startTime.IsZero() {
+		r.startTime = now
+	}
+	snapshot.Offset = now.Sub(r.startTime)
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if r.size+int64(len(data))+1 > maxRecordSize {
+		return fmt.Errorf("the record file is larger than %d bytes", maxRecordSize)
+	}
+	n, err := r.file.Write(append(data, '\n'))
+	r.size += int64(n)
+	return err
+}
+
+func (r *snapshotRecorder) close() {
+	if err := r.file.Close(); err != nil {
+		hwlog.RunLog.Error(err)
+	}
+}
+
+// getSnapshotFromCache returns the snapshot of the cache, the items not in cache are left empty
+func getSnapshotFromCache(n *npuCollector) Snapshot {
+	var snapshot Snapshot
+	if obj, err := n.cache.Get(npuListCacheKey); err == nil {
+		snapshot.NPUList, _ = obj.([]HuaWeiNPUCard)
+	}
+	if obj, err := n.cache.Get(npuNetworkCacheKey); err == nil {
+		snapshot.NetInfo, _ = obj.(map[int32]NpuNetInfo)
+	}
+	if obj, err := n.cache.Get(containersDevicesCacheKey); err == nil {
+		snapshot.Containers, _ = obj.(container.DevicesInfos)
+	}
+	return snapshot
+}
+
+func recordSnapshots(ctx context.Context, n *npuCollector, path string) {
+	recorder, err := newSnapshotRecorder(path)
+	if err != nil {
+		hwlog.RunLog.Errorf("start recording failed: %v", err)
+		return
+	}
+	defer recorder.close()
+	hwlog.RunLog.Infof("start recording every %d seconds", n.updateTime/time.Second)
+	ticker := time.NewTicker(n.updateTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			hwlog.RunLog.Info("received the stop signal, recording STOPPED")
+			return
+		case now := <-ticker.C:
+			snapshot := getSnapshotFromCache(n)
+			if len(snapshot.NPUList) == 0 {
+				hwlog.RunLog.Debug("npu info is not in cache yet, skip recording")
+				continue
+			}
+			if err := recorder.record(snapshot, now); err != nil {
+				hwlog.RunLog.Errorf("recording STOPPED: %v", err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/collector/container"
+	"huawei.com/npu-exporter/v5/common-utils/cache"
+)
+
+func recordMockSnapshots(t *testing.T, utils ...int) string {
+	path := filepath.Join(t.TempDir(), "record.json")
+	recorder, err := newSnapshotRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recorder.close()
+	n := &npuCollector{cache: cache.New(cacheSize), cacheTime: time.Minute}
+	startTime := time.Now()
+	for i, util := range utils {
+		chip := mock910Chip(0, util, 80)
+		assert.Nil(t, n.cache.Set(npuListCacheKey, []HuaWeiNPUCard{{CardID: 0,
+			DeviceList: []*HuaWeiAIChip{chip}}}, n.cacheTime))
+		assert.Nil(t, n.cache.Set(containersDevicesCacheKey, container.DevicesInfos{"c1": {ID: "c1",
+			Name: "default_train_worker", Devices: []container.NpuDevice{{PhyID: 0, VDevID: container.NoVDevID}}}},
+			n.cacheTime))
+		assert.Nil(t, recorder.record(getSnapshotFromCache(n), startTime.Add(time.Duration(i)*time.Second)))
+	}
+	return path
+}
+
+// TestSnapshotPlayer test recording and replaying the snapshots
+func TestSnapshotPlayer(t *testing.T) {
+	snapshots, err := loadSnapshots(recordMockSnapshots(t, 10, 20, 30))
+	assert.Nil(t, err)
+	assert.Len(t, snapshots, 3)
+	tests := []struct {
+		name     string
+		speed    float64
+		elapsed  time.Duration
+		wantUtil int
+	}{
+		{name: "should pick first snapshot when replay starts", speed: 1, elapsed: 0, wantUtil: 10},
+		{name: "should pick snapshot by offset when speed is 1", speed: 1, elapsed: 1500 * time.Millisecond,
+			wantUtil: 20},
+		{name: "should pick snapshot by scaled offset when speed is 2", speed: 2, elapsed: time.Second,
+			wantUtil: 30},
+		{name: "should loop the timeline when elapsed is longer than period", speed: 1,
+			elapsed: 4*time.Second + 100*time.Millisecond, wantUtil: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := newSnapshotPlayer(snapshots, tt.speed)
+			assert.Equal(t, 3*time.Second, player.period)
+			snapshot := player.pick(tt.elapsed)
+			assert.Equal(t, tt.wantUtil, snapshot.NPUList[0].DeviceList[0].Utilization)
+		})
+	}
+}
+
+// TestNewSimulateCollector test the metrics of the simulate collector
+func TestNewSimulateCollector(t *testing.T) {
+	path := recordMockSnapshots(t, 40)
+	t.Run("should return error when speed is invalid", func(t *testing.T) {
+		_, err := NewSimulateCollector(context.Background(), SimulateOpts{File: path, Speed: 0})
+		assert.NotNil(t, err)
+	})
+	t.Run("should return error when file is not found", func(t *testing.T) {
+		_, err := NewSimulateCollector(context.Background(), SimulateOpts{File: path + ".bak", Speed: 1})
+		assert.NotNil(t, err)
+	})
+	t.Run("should replay metrics when file is recorded", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		c, err := NewSimulateCollector(ctx, SimulateOpts{File: path, Speed: 1, CacheTime: time.Minute,
+			UpdateTime: time.Second})
+		assert.Nil(t, err)
+		r := prometheus.NewRegistry()
+		r.MustRegister(c)
+		count, err := testutil.GatherAndCount(r, "npu_chip_info_utilization", "container_npu_chip_count")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
@@ -19,6 +19,8 @@ import (
 	"time"
 
 	"huawei.com/npu-exporter/v5/devmanager/common"
+	"huawei.com/npu-exporter/v5/devmanager/hccn"
+	"huawei.com/npu-exporter/v5/devmanager/hccn/parser"
 )
 
 const (
@@ -69,22 +71,40 @@ type HuaWeiAIChip struct {
 	Voltage float32 `json:"voltage"`
 	// the AI core current frequency of the chip
 	AICoreCurrentFreq uint32 `json:"aicore_current_freq"`
+	// the AI core rated frequency of the chip
+	AICoreRatedFreq uint32 `json:"aicore_rated_freq"`
+	// the control cpu frequency of the chip
+	CtrlCpuFreq uint32 `json:"ctrl_cpu_freq"`
+	// the hbm frequency of the chip
+	HbmFreq uint32 `json:"hbm_freq"`
+	// the utilization of the cores of the chip, the key is the core type
+	CoreUtilization map[string]uint32 `json:"core_utilization"`
 	// the chip physic ID
 	DeviceID int `json:"device_id"`
 	// the vdie id
 	VDieID string `json:"vdie_id"`
 	// the interface status
 	LinkStatus string `json:"link_status"`
+	// BootStatus the boot status of the chip, common.BootStatusUnknown if it fails to be queried
+	BootStatus int `json:"boot_status"`
 	// NetHealthStatus chip network health status
 	NetHealthStatus string `json:"net_health_status"`
 	// DevProcessInfo chip process info
 	DevProcessInfo *common.DevProcessInfo
+	// HostProcesses the metadata of the processes in DevProcessInfo read from the procfs of the host, keyed by pid
+	HostProcesses map[int32]HostProcess `json:"host_processes,omitempty"`
 	// PCIeBusInfo bus info
 	PCIeBusInfo string
+	// CardType the device type of the card which the chip belongs to
+	CardType string `json:"card_type"`
+	// ProductType the product type of the card which the chip belongs to
+	ProductType string `json:"product_type"`
 	// BoardInfo board info of device, but not display
 	BoardInfo common.BoardInfo
 	// NetInfo network info of device, only support training card
 	NetInfo *NpuNetInfo
+	// HccsInfo the HCCS lanes sampled in background, only support Ascend910 and Ascend910B
+	HccsInfo *common.HccsInfo `json:"hccs_info,omitempty"`
 }
 
 // BandwidthInfo contains network port real-time bandwidth
@@ -141,6 +161,26 @@ type StatInfo struct {
 type LinkStatInfo struct {
 	// The times of link-up
 	LinkUPNum float64
+	// The times of link-down
+	LinkDownNum float64
+	// The times of the link status transitions observed since the exporter started
+	LinkFlapNum float64
+	// The link status observed last time, empty before the first observation
+	LinkStatus string
+}
+
+// DiagInfo the diagnostic information of network port, it changes rarely so that is collected at a lower frequency
+type DiagInfo struct {
+	// The neighbor discovered by lldp
+	LLDPInfo parser.LLDPInfo
+	// The ip address, netmask and gateway
+	IPInfo parser.IPInfo
+	// The ipv6 address
+	IPv6 string
+	// The entries of the arp table
+	ARPEntries []parser.ARPEntry
+	// The entries of the route table
+	Routes []parser.Route
 }
 
 // LinkSpeedInfo the transfer rate of network port
@@ -185,8 +225,14 @@ type NpuNetInfo struct {
 	LinkStatInfo LinkStatInfo
 	// Statistics about packets
 	StatInfo StatInfo
+	// The last sample of the packet counters, zero time when the sampling fails
+	StatSample hccn.StatSample
+	// The times of the packet counter resets detected since the exporter started
+	StatResetNum float64
 	// Network port real-time bandwidth
 	BandwidthInfo BandwidthInfo
+	// Diagnostic information of network port
+	DiagInfo DiagInfo
 }
 
 // HuaWeiNPUCard device
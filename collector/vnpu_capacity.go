@@ -0,0 +1,124 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+var (
+	vnpuMediaLabels    = append(append([]string{}, chipLabels...), "media")
+	vnpuTemplateLabels = append(append([]string{}, chipLabels...), "template")
+
+	npuChipVNPUTotalAiCore = prometheus.NewDesc("npu_chip_vnpu_total_aicore",
+		"the total aicore of the npu which can be allocated to vnpu", chipLabels, nil)
+	npuChipVNPUFreeAiCore = prometheus.NewDesc("npu_chip_vnpu_free_aicore",
+		"the free aicore of the npu which is not allocated to vnpu", chipLabels, nil)
+	npuChipVNPUTotalAiCPU = prometheus.NewDesc("npu_chip_vnpu_total_aicpu",
+		"the total aicpu of the npu which can be allocated to vnpu", chipLabels, nil)
+	npuChipVNPUFreeAiCPU = prometheus.NewDesc("npu_chip_vnpu_free_aicpu",
+		"the free aicpu of the npu which is not allocated to vnpu", chipLabels, nil)
+	npuChipVNPUTotalMemory = prometheus.NewDesc("npu_chip_vnpu_total_memory",
+		"the total memory of the npu which can be allocated to vnpu, unit is 'MB'", chipLabels, nil)
+	npuChipVNPUFreeMemory = prometheus.NewDesc("npu_chip_vnpu_free_memory",
+		"the free memory of the npu which is not allocated to vnpu, unit is 'MB'", chipLabels, nil)
+	npuChipVNPUTotalMedia = prometheus.NewDesc("npu_chip_vnpu_total_media",
+		"the total media resource of the npu which can be allocated to vnpu", vnpuMediaLabels, nil)
+	npuChipVNPUFreeMedia = prometheus.NewDesc("npu_chip_vnpu_free_media",
+		"the free media resource of the npu which is not allocated to vnpu", vnpuMediaLabels, nil)
+	npuChipVNPUTemplateCapacity = prometheus.NewDesc("npu_chip_vnpu_template_capacity",
+		"the number of the vnpu of the template which can be created with the free resource of the npu",
+		vnpuTemplateLabels, nil)
+)
+
+func describeVNPUCapacityInfo(ch chan<- *prometheus.Desc) {
+	ch <- npuChipVNPUTotalAiCore
+	ch <- npuChipVNPUFreeAiCore
+	ch <- npuChipVNPUTotalAiCPU
+	ch <- npuChipVNPUFreeAiCPU
+	ch <- npuChipVNPUTotalMemory
+	ch <- npuChipVNPUFreeMemory
+	ch <- npuChipVNPUTotalMedia
+	ch <- npuChipVNPUFreeMedia
+	ch <- npuChipVNPUTemplateCapacity
+}
+
+// mediaResources returns the media resources by their names
+func mediaResources(media common.CgoMediaResource) map[string]float32 {
+	return map[string]float32{"vpc": media.Vpc, "venc": media.Venc, "vdec": media.Vdec, "jpegd": media.Jpegd,
+		"jpege": media.Jpege, "pngd": media.Pngd}
+}
+
+// updateVNPUCapacityInfo exports the total and free resources of the chips which support vNPU, and the capacity of
+// each template; the vNPUs of a chip share its resources, so each chip is exported once
+func updateVNPUCapacityInfo(ch chan<- prometheus.Metric, npu *HuaWeiNPUCard) {
+	reported := make(map[int]struct{}, len(npu.DeviceList))
+	for _, chip := range npu.DeviceList {
+		info := chip.VDevInfos
+		if chip.ChipIfo == nil || info.TotalResource.Computing.Aic <= 0 {
+			continue
+		}
+		if _, ok := reported[chip.DeviceID]; ok {
+			continue
+		}
+		reported[chip.DeviceID] = struct{}{}
+		labels := chipLabelValues(chip)
+		withLabel := func(value string) []string {
+			return append(append(make([]string, 0, len(labels)+1), labels...), value)
+		}
+		total, free := info.TotalResource, info.FreeResource
+		gauges := []struct {
+			desc  *prometheus.Desc
+			value float64
+		}{
+			{desc: npuChipVNPUTotalAiCore, value: float64(total.Computing.Aic)},
+			{desc: npuChipVNPUFreeAiCore, value: float64(free.Computing.Aic)},
+			{desc: npuChipVNPUTotalAiCPU, value: float64(total.Computing.DeviceAicpu)},
+			{desc: npuChipVNPUFreeAiCPU, value: float64(free.Computing.DeviceAicpu)},
+			{desc: npuChipVNPUTotalMemory, value: float64(total.Computing.MemorySize)},
+			{desc: npuChipVNPUFreeMemory, value: float64(free.Computing.MemorySize)},
+		}
+		for _, gauge := range gauges {
+			ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
+				prometheus.MustNewConstMetric(gauge.desc, prometheus.GaugeValue, gauge.value, labels...))
+		}
+		freeMedia := mediaResources(free.Media)
+		for name, value := range mediaResources(total.Media) {
+			ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipVNPUTotalMedia,
+				prometheus.GaugeValue, float64(value), withLabel(name)...))
+			ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp, prometheus.MustNewConstMetric(npuChipVNPUFreeMedia,
+				prometheus.GaugeValue, float64(freeMedia[name]), withLabel(name)...))
+		}
+		devType := common.GetDeviceTypeByChipName(chip.ChipIfo.Name)
+		for _, template := range common.GetTemplateNameList(devType) {
+			need, err := common.GetTemplateResource(devType, template)
+			if err != nil {
+				hwlog.RunLog.Debugf("get resource of template %s failed: %v", template, err)
+				continue
+			}
+			if need.Media {
+				// the capacity can not be told without the media resources needed by the template
+				continue
+			}
+			ch <- prometheus.NewMetricWithTimestamp(npu.Timestamp,
+				prometheus.MustNewConstMetric(npuChipVNPUTemplateCapacity, prometheus.GaugeValue,
+					common.TemplateCapacity(need, info), withLabel(template)...))
+		}
+	}
+}
@@ -0,0 +1,92 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package collector for Prometheus
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+// memoryPerAiCore the memory of the chips in the tests, unit is MB
+const memoryPerAiCore = 1024
+
+func newVNPUResource(aiCore float32, aiCPU uint16, memory uint64) common.CgoComputingResource {
+	return common.CgoComputingResource{Aic: aiCore, DeviceAicpu: aiCPU, MemorySize: memory}
+}
+
+// TestTemplateCapacity test the number of the vnpu of a template which fit in the free resource
+func TestTemplateCapacity(t *testing.T) {
+	const total, free = 8, 6
+	info := common.VirtualDevInfo{
+		TotalResource: common.CgoSocTotalResource{Computing: newVNPUResource(total, total, total*memoryPerAiCore)},
+		FreeResource:  common.CgoSocFreeResource{Computing: newVNPUResource(free, 1, free*memoryPerAiCore)},
+	}
+	tests := []struct {
+		name     string
+		template string
+		want     float64
+	}{
+		{name: "should be limited by aicore when template only specifies aicore", template: "vir02", want: 3},
+		{name: "should be limited by aicpu when template specifies aicpu", template: "vir02_1c", want: 1},
+		{name: "should be zero when free aicore is not enough", template: "vir04_4c_dvpp", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			need, err := common.GetTemplateResource(common.Ascend310P, tt.template)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, common.TemplateCapacity(need, info))
+		})
+	}
+	t.Run("should not be limited by aicpu when chip does not report it", func(t *testing.T) {
+		need, err := common.GetTemplateResource(common.Ascend310P, "vir02_1c")
+		assert.NoError(t, err)
+		assert.Equal(t, 3.0, common.TemplateCapacity(need, common.VirtualDevInfo{
+			FreeResource: common.CgoSocFreeResource{Computing: newVNPUResource(free, 0, 0)}}))
+	})
+}
+
+// TestUpdateVNPUCapacityInfo test the capacity metrics are reported once for a chip with several vnpu, the capacity
+// of vir04_4c_dvpp which needs media resources is not reported
+func TestUpdateVNPUCapacityInfo(t *testing.T) {
+	const total = 8
+	info := common.VirtualDevInfo{
+		TotalResource: common.CgoSocTotalResource{Computing: newVNPUResource(total, total, total*memoryPerAiCore)},
+		FreeResource:  common.CgoSocFreeResource{Computing: newVNPUResource(total, total, total*memoryPerAiCore)},
+	}
+	chip := HuaWeiAIChip{DeviceID: 0, ChipIfo: &common.ChipInfo{Name: "310P3"}, VDevInfos: info}
+	vnpu := chip
+	vnpu.VDevActivityInfo = common.VDevActivityInfo{VDevID: common.MinVDevID, IsVirtualDev: true}
+	noVNPU := HuaWeiAIChip{DeviceID: 1, ChipIfo: &common.ChipInfo{Name: "310P3"}}
+	const chanSize = 128
+	ch := make(chan prometheus.Metric, chanSize)
+	updateVNPUCapacityInfo(ch, &HuaWeiNPUCard{Timestamp: time.Now(),
+		DeviceList: []*HuaWeiAIChip{&chip, &vnpu, &noVNPU}})
+	close(ch)
+	counts := make(map[*prometheus.Desc]int, chanSize)
+	for metric := range ch {
+		counts[metric.Desc()]++
+	}
+	mediaNum := len(mediaResources(common.CgoMediaResource{}))
+	assert.Equal(t, map[*prometheus.Desc]int{npuChipVNPUTotalAiCore: 1, npuChipVNPUFreeAiCore: 1,
+		npuChipVNPUTotalAiCPU: 1, npuChipVNPUFreeAiCPU: 1, npuChipVNPUTotalMemory: 1, npuChipVNPUFreeMemory: 1,
+		npuChipVNPUTotalMedia: mediaNum, npuChipVNPUFreeMedia: mediaNum,
+		npuChipVNPUTemplateCapacity: len(common.GetTemplateNameList(common.Ascend310P)) - 1}, counts)
+}
@@ -19,7 +19,7 @@ import (
 	"huawei.com/npu-exporter/v5/devmanager/dcmi"
 )
 
-// A310Manager Ascend310 device manager
+// A310Manager Ascend310 device manager, it wraps the common dcmi driver
 type A310Manager struct {
-	dcmi.DcManager
+	dcmi.DcDriverInterface
 }
@@ -19,9 +19,9 @@ import (
 	"huawei.com/npu-exporter/v5/devmanager/dcmi"
 )
 
-// A310PManager Ascend310P device manager
+// A310PManager Ascend310P device manager, it wraps the common dcmi driver
 type A310PManager struct {
-	dcmi.DcManager
+	dcmi.DcDriverInterface
 }
 
 // DcGetDevicePowerInfo query power by mcu interface for 310P
@@ -31,5 +31,14 @@ func (d *A310PManager) DcGetDevicePowerInfo(cardID, deviceID int32) (float32, er
 
 // DcGetMcuPowerInfo this function is only for Ascend310P
 func (d *A310PManager) DcGetMcuPowerInfo(cardID int32) (float32, error) {
-	return dcmi.FuncDcmiMcuGetPowerInfo(cardID)
+	return dcmiGetMcuPowerInfo(d.DcDriverInterface, cardID)
+}
+
+// dcmiGetMcuPowerInfo queries the power of card from the mcu by libdcmi.so when the wrapped driver is the common
+// dcmi driver, which returns 0, the other drivers such as the fake dcmi driver are queried by themselves
+func dcmiGetMcuPowerInfo(base dcmi.DcDriverInterface, cardID int32) (float32, error) {
+	if _, ok := base.(*dcmi.DcManager); ok {
+		return dcmi.FuncDcmiMcuGetPowerInfo(cardID)
+	}
+	return base.DcGetMcuPowerInfo(cardID)
 }
@@ -20,12 +20,21 @@ import (
 	"huawei.com/npu-exporter/v5/devmanager/dcmi"
 )
 
-// A910Manager Ascend910 device manager
+// A910Manager Ascend910 device manager, it wraps the common dcmi driver
 type A910Manager struct {
-	dcmi.DcManager
+	dcmi.DcDriverInterface
 }
 
 // DcGetHbmInfo get HBM information, only for Ascend910
 func (d *A910Manager) DcGetHbmInfo(cardID, deviceID int32) (*common.HbmInfo, error) {
-	return dcmi.FuncDcmiGetDeviceHbmInfo(cardID, deviceID)
+	return dcmiGetHbmInfo(d.DcDriverInterface, cardID, deviceID)
+}
+
+// dcmiGetHbmInfo queries the hbm info from libdcmi.so when the wrapped driver is the common dcmi driver, which
+// returns an empty hbm info, the other drivers such as the fake dcmi driver are queried by themselves
+func dcmiGetHbmInfo(base dcmi.DcDriverInterface, cardID, deviceID int32) (*common.HbmInfo, error) {
+	if _, ok := base.(*dcmi.DcManager); ok {
+		return dcmi.FuncDcmiGetDeviceHbmInfo(cardID, deviceID)
+	}
+	return base.DcGetHbmInfo(cardID, deviceID)
 }
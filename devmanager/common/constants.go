@@ -23,6 +23,10 @@ type DeviceType int32
 const (
 	// AICore Ascend310 & Ascend910
 	AICore DeviceType = 2
+	// AICpu Ascend310 & Ascend910 & Ascend910B & Ascend310P
+	AICpu DeviceType = 3
+	// CtrlCpu Ascend310 & Ascend910 & Ascend910B & Ascend310P
+	CtrlCpu DeviceType = 4
 
 	// MemoryFreq Ascend310 & Ascend310P
 	MemoryFreq DeviceType = 1
@@ -99,6 +103,25 @@ const (
 )
 
 const (
+	templatePrefix = "vir"
+	// templateCoreLen the length of the ai core number in template name, such as 02 in vir02_1c
+	templateCoreLen = 2
+	mbPerGB         = 1024
+	decimal         = 10
+	bitSize16       = 16
+	bitSize64       = 64
+)
+
+// the boot status of the chip returned by dcmi
+const (
+	// BootStatusUnknown the boot status of the chip fails to be queried
+	BootStatusUnknown = -1
+	// BootStatusUninit the chip is not initialized
+	BootStatusUninit = 0
+	// BootStatusBIOS the chip is starting the bios
+	BootStatusBIOS = 1
+	// BootStatusOS the chip is starting the os
+	BootStatusOS = 2
 	// BootStartFinish chip hot reset finish
 	BootStartFinish = 3
 )
@@ -130,6 +153,19 @@ const (
 	// NetworkSuccess chip network is healthy
 	NetworkSuccess = 0
 
+	// IPAddrTypeV4 ip address type of IPv4, used by GetDeviceIPAddress
+	IPAddrTypeV4 int32 = 0
+	// IPAddrTypeV6 ip address type of IPv6, used by GetDeviceIPAddress
+	IPAddrTypeV6 int32 = 1
+
+	// HccsMaxPcsNum the max number of HCCS lanes (pcs) of a chip, HCCS_MAX_PCS_NUM of dcmi
+	HccsMaxPcsNum = 16
+	// HccsProfilingTime the sampling window of the HCCS bandwidth in ms, the query of bandwidth blocks for the window,
+	// so the callers should not query it in the collecting loop
+	HccsProfilingTime = 100
+	// Hccs910DomainSize the number of chips full meshed by HCCS on Ascend910 boards
+	Hccs910DomainSize = 4
+
 	// MaxProcNum process number in device side
 	MaxProcNum = 32
 	// UnitMB MB
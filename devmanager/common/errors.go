@@ -0,0 +1,133 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package common this for the errors of device manager
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	// ErrNotSupported the interface is not supported on this chip
+	ErrNotSupported = errors.New("not supported on this chip")
+	// ErrDeviceBusy the device is busy, such as not ready, upgrading or occupied, the call can be retried later
+	ErrDeviceBusy = errors.New("device busy")
+	// ErrDeviceNotFound the device does not exist or the device id is invalid
+	ErrDeviceNotFound = errors.New("device not found")
+	// ErrInvalidValue the input is invalid or the driver returns an invalid value
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// the return codes of dcmi interface
+const (
+	dcmiErrInvalidParameter      = -8001
+	dcmiErrTimeout               = -8006
+	dcmiErrInvalidDeviceID       = -8007
+	dcmiErrDeviceNotExist        = -8008
+	dcmiErrNotReady              = -8012
+	dcmiErrNotSupportInContainer = -8013
+	dcmiErrIsUpgrading           = -8017
+	dcmiErrResourceOccupied      = -8018
+	dcmiErrNotSupport            = -8255
+	// FunctionNotFound the interface does not exist in the dcmi library of the installed driver
+	FunctionNotFound = -99998
+)
+
+// the error classes, which are used as the label of error metrics
+const (
+	// ErrClassNotSupported class of ErrNotSupported
+	ErrClassNotSupported = "not_supported"
+	// ErrClassBusy class of ErrDeviceBusy
+	ErrClassBusy = "busy"
+	// ErrClassNotFound class of ErrDeviceNotFound
+	ErrClassNotFound = "not_found"
+	// ErrClassInvalidValue class of ErrInvalidValue
+	ErrClassInvalidValue = "invalid_value"
+	// ErrClassDriver class of the DriverError which has no specific class
+	ErrClassDriver = "driver"
+	// ErrClassOther class of the other errors
+	ErrClassOther = "other"
+)
+
+// DriverError the error code returned by dcmi interface, the error text is the code itself, so that it can be
+// wrapped into a message such as "error code: %w"
+type DriverError int32
+
+// Error return the error code as text
+func (e DriverError) Error() string {
+	return strconv.Itoa(int(e))
+}
+
+// Code return the error code
+func (e DriverError) Code() int32 {
+	return int32(e)
+}
+
+// Is report whether the error code belongs to the class of target
+func (e DriverError) Is(target error) bool {
+	switch target {
+	case ErrNotSupported:
+		return e == dcmiErrNotSupport || e == dcmiErrNotSupportInContainer || e == FunctionNotFound
+	case ErrDeviceBusy:
+		return e == dcmiErrNotReady || e == dcmiErrIsUpgrading || e == dcmiErrResourceOccupied ||
+			e == dcmiErrTimeout
+	case ErrDeviceNotFound:
+		return e == dcmiErrInvalidDeviceID || e == dcmiErrDeviceNotExist
+	case ErrInvalidValue:
+		return e == dcmiErrInvalidParameter
+	default:
+		return false
+	}
+}
+
+type invalidValueError struct {
+	msg string
+}
+
+func (e *invalidValueError) Error() string {
+	return e.msg
+}
+
+func (e *invalidValueError) Is(target error) bool {
+	return target == ErrInvalidValue
+}
+
+// InvalidErrorf return the error which formats the message like fmt.Errorf and is ErrInvalidValue
+func InvalidErrorf(format string, a ...interface{}) error {
+	return &invalidValueError{msg: fmt.Sprintf(format, a...)}
+}
+
+// ErrorClass return the class of the error
+func ErrorClass(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrNotSupported):
+		return ErrClassNotSupported
+	case errors.Is(err, ErrDeviceBusy):
+		return ErrClassBusy
+	case errors.Is(err, ErrDeviceNotFound):
+		return ErrClassNotFound
+	case errors.Is(err, ErrInvalidValue):
+		return ErrClassInvalidValue
+	}
+	var driverErr DriverError
+	if errors.As(err, &driverErr) {
+		return ErrClassDriver
+	}
+	return ErrClassOther
+}
@@ -150,6 +150,17 @@ type CgoSocTotalResource struct {
 	Media     CgoMediaResource
 }
 
+// TemplateResource the resource needed by a vNPU template, the zero value means the resource is not specified by
+// the template name
+type TemplateResource struct {
+	AiCore float32
+	AiCPU  uint16
+	// MemorySize MB as unit
+	MemorySize uint64
+	// Media whether the template needs media resources, the amount is not encoded in the template name
+	Media bool
+}
+
 // VirtualDevInfo virtual device infos
 type VirtualDevInfo struct {
 	TotalResource    CgoSocTotalResource
@@ -188,6 +199,37 @@ type BoardInfo struct {
 	SlotId  uint32
 }
 
+// HccsLaneInfo the HCCS information of the lanes (pcs) of a chip got from dcmi, indexed as dcmi returns them
+type HccsLaneInfo struct {
+	// TxBandwidth unit is MB/s
+	TxBandwidth [HccsMaxPcsNum]float64
+	// RxBandwidth unit is MB/s
+	RxBandwidth [HccsMaxPcsNum]float64
+	TxCnt       [HccsMaxPcsNum]uint64
+	RxCnt       [HccsMaxPcsNum]uint64
+	CrcErrCnt   [HccsMaxPcsNum]uint64
+	RetryCnt    [HccsMaxPcsNum]uint64
+}
+
+// HccsLaneStat the HCCS statistics of a lane (pcs) of the chip
+type HccsLaneStat struct {
+	// Lane the index of the lane in the dcmi arrays
+	Lane int32 `json:"lane"`
+	// TxBandwidth unit is MB/s
+	TxBandwidth float64 `json:"tx_bandwidth"`
+	// RxBandwidth unit is MB/s
+	RxBandwidth float64 `json:"rx_bandwidth"`
+	TxCnt       uint64  `json:"tx_cnt"`
+	RxCnt       uint64  `json:"rx_cnt"`
+	CrcErrCnt   uint64  `json:"crc_err_cnt"`
+	RetryCnt    uint64  `json:"retry_cnt"`
+}
+
+// HccsInfo the HCCS lanes of a chip which have carried traffic, ordered by the lane
+type HccsInfo struct {
+	Lanes []HccsLaneStat `json:"lanes"`
+}
+
 // VDevActivityInfo vNPU activity info for 310P
 type VDevActivityInfo struct {
 	VDevID         uint32
@@ -196,4 +238,6 @@ type VDevActivityInfo struct {
 	VDevUsedMem    uint64
 	VDevAiCore     float64
 	IsVirtualDev   bool
+	// ActivityUnavailable the activity of the vNPU can not be queried, the ai core rate and the memory are not valid
+	ActivityUnavailable bool
 }
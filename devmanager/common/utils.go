@@ -19,9 +19,20 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
+var (
+	// templateAiCPUReg the ai cpu number in template name, such as 3 in vir10_3c_16g
+	templateAiCPUReg = regexp.MustCompile(`_(\d+)c(?:_|$)`)
+	// templateMemoryReg the memory size in GB in template name, such as 16 in vir10_3c_16g
+	templateMemoryReg = regexp.MustCompile(`_(\d+)g(?:_|$)`)
+	// templateMediaReg the suffix of the template which needs media resources, such as vir04_4c_dvpp
+	templateMediaReg = regexp.MustCompile(`_(?:dvpp|m)$`)
+)
+
 // IsGreaterThanOrEqualInt32 check num range
 func IsGreaterThanOrEqualInt32(num int64) bool {
 	if num >= int64(math.MaxInt32) {
@@ -133,6 +144,88 @@ func IsValidTemplateName(devType, templateName string) bool {
 	return isTemplateNameValid
 }
 
+// GetTemplateAiCore returns the ai core number of the vNPU template, such as 2 for vir02_1c
+func GetTemplateAiCore(devType, templateName string) (float32, error) {
+	if !IsValidTemplateName(devType, templateName) || !strings.HasPrefix(templateName, templatePrefix) ||
+		len(templateName) < len(templatePrefix)+templateCoreLen {
+		return 0, fmt.Errorf("template name %s is invalid for %s", templateName, devType)
+	}
+	aiCore, err := strconv.Atoi(templateName[len(templatePrefix) : len(templatePrefix)+templateCoreLen])
+	if err != nil {
+		return 0, fmt.Errorf("template name %s is invalid: %v", templateName, err)
+	}
+	return float32(aiCore), nil
+}
+
+// IsVNPUSupported whether the chips of the device type can be split into vNPUs by the templates
+func IsVNPUSupported(devType string) bool {
+	return devType == Ascend310P || devType == Ascend910 || devType == Ascend910B
+}
+
+// GetTemplateNameList returns the sorted vNPU template names of the device type
+func GetTemplateNameList(devType string) []string {
+	var templates map[string]struct{}
+	switch devType {
+	case Ascend310P:
+		templates = get310PTemplateNameList()
+	case Ascend910:
+		templates = get910TemplateNameList()
+	case Ascend910B:
+		templates = get910BTemplateNameList()
+	default:
+	}
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetTemplateResource returns the resource needed by the vNPU template which is encoded in its name, such as
+// vir10_3c_16g needs 10 ai core, 3 ai cpu and 16GB memory
+func GetTemplateResource(devType, templateName string) (TemplateResource, error) {
+	aiCore, err := GetTemplateAiCore(devType, templateName)
+	if err != nil {
+		return TemplateResource{}, err
+	}
+	resource := TemplateResource{AiCore: aiCore}
+	if match := templateAiCPUReg.FindStringSubmatch(templateName); match != nil {
+		aiCPU, err := strconv.ParseUint(match[1], decimal, bitSize16)
+		if err != nil {
+			return TemplateResource{}, fmt.Errorf("template name %s is invalid: %v", templateName, err)
+		}
+		resource.AiCPU = uint16(aiCPU)
+	}
+	if match := templateMemoryReg.FindStringSubmatch(templateName); match != nil {
+		memory, err := strconv.ParseUint(match[1], decimal, bitSize64)
+		if err != nil {
+			return TemplateResource{}, fmt.Errorf("template name %s is invalid: %v", templateName, err)
+		}
+		resource.MemorySize = memory * mbPerGB
+	}
+	resource.Media = templateMediaReg.MatchString(templateName)
+	return resource, nil
+}
+
+// TemplateCapacity returns how many vNPUs of the template fit in the free resource, the ai cpu and the memory limit
+// the capacity only when the template specifies them and the chip reports their total. The media resources are not
+// counted because their amount is not encoded in the template name
+func TemplateCapacity(need TemplateResource, info VirtualDevInfo) float64 {
+	if need.AiCore <= 0 {
+		return 0
+	}
+	free, total := info.FreeResource.Computing, info.TotalResource.Computing
+	capacity := math.Floor(float64(free.Aic / need.AiCore))
+	if need.AiCPU > 0 && total.DeviceAicpu > 0 {
+		capacity = math.Min(capacity, float64(free.DeviceAicpu/need.AiCPU))
+	}
+	if need.MemorySize > 0 && total.MemorySize > 0 {
+		capacity = math.Min(capacity, float64(free.MemorySize/need.MemorySize))
+	}
+	return math.Max(capacity, 0)
+}
+
 // RemoveDuplicate remove duplicate device
 func RemoveDuplicate(list *[]string) []string {
 	listValueMap := make(map[string]string, len(*list))
@@ -1,3 +1,5 @@
+//go:build !purego
+
 /* Copyright(C) 2021-2023. Huawei Technologies Co.,Ltd. All rights reserved.
    Licensed under the Apache License, Version 2.0 (the "License");
    you may not use this file except in compliance with the License.
@@ -238,6 +240,20 @@ package dcmi
         CALL_FUNC(dcmi_get_device_board_info,card_id,device_id,board_info)
     }
 
+    int (*dcmi_get_hccs_statistic_info_func)(int card_id, int device_id,
+    struct dcmi_hccs_statistic_info *hccs_statistic_info);
+    int dcmi_get_hccs_statistic_info(int card_id, int device_id,
+    struct dcmi_hccs_statistic_info *hccs_statistic_info){
+        CALL_FUNC(dcmi_get_hccs_statistic_info,card_id,device_id,hccs_statistic_info)
+    }
+
+    int (*dcmi_get_hccs_link_bandwidth_info_func)(int card_id, int device_id,
+    struct dcmi_hccs_bandwidth_info *hccs_bandwidth_info);
+    int dcmi_get_hccs_link_bandwidth_info(int card_id, int device_id,
+    struct dcmi_hccs_bandwidth_info *hccs_bandwidth_info){
+        CALL_FUNC(dcmi_get_hccs_link_bandwidth_info,card_id,device_id,hccs_bandwidth_info)
+    }
+
    // load .so files and functions
    static int dcmiInit_dl(const char* dcmiLibPath){
    	if (dcmiLibPath == NULL) {
@@ -324,6 +340,10 @@ package dcmi
 
     dcmi_get_device_board_info_func = dlsym(dcmiHandle, "dcmi_get_device_board_info");
 
+    dcmi_get_hccs_statistic_info_func = dlsym(dcmiHandle, "dcmi_get_hccs_statistic_info");
+
+    dcmi_get_hccs_link_bandwidth_info_func = dlsym(dcmiHandle, "dcmi_get_hccs_link_bandwidth_info");
+
    	return SUCCESS;
    }
 
@@ -336,7 +356,6 @@ package dcmi
 */
 import "C"
 import (
-	"errors"
 	"fmt"
 	"math"
 	"net"
@@ -357,67 +376,6 @@ type CDcmiMemoryInfoV3 = C.struct_dcmi_get_memory_info_stru
 // CDcmiMemoryInfoV1 the c struct of memoryInfo for v1
 type CDcmiMemoryInfoV1 = C.struct_dcmi_memory_info_stru
 
-// DcDriverInterface interface for dcmi
-type DcDriverInterface interface {
-	DcInit() error
-	DcShutDown() error
-
-	DcGetDeviceCount() (int32, error)
-	DcGetLogicIDList() (int32, []int32, error)
-	DcGetDeviceHealth(int32, int32) (int32, error)
-	DcGetDeviceNetWorkHealth(int32, int32) (uint32, error)
-	DcGetDeviceUtilizationRate(int32, int32, common.DeviceType) (int32, error)
-	DcGetDeviceTemperature(int32, int32) (int32, error)
-	DcGetDeviceVoltage(int32, int32) (float32, error)
-	DcGetDevicePowerInfo(int32, int32) (float32, error)
-	DcGetDeviceFrequency(int32, int32, common.DeviceType) (uint32, error)
-	DcGetMemoryInfo(int32, int32) (*common.MemoryInfo, error)
-	DcGetHbmInfo(int32, int32) (*common.HbmInfo, error)
-	DcGetDeviceErrorCode(int32, int32) (int32, int64, error)
-	DcGetChipInfo(int32, int32) (*common.ChipInfo, error)
-	DcGetPhysicIDFromLogicID(int32) (int32, error)
-	DcGetLogicIDFromPhysicID(int32) (int32, error)
-	DcGetDeviceLogicID(int32, int32) (int32, error)
-	DcGetDeviceIPAddress(int32, int32, int32) (string, error)
-	DcGetMcuPowerInfo(int32) (float32, error)
-	DcGetDieID(int32, int32, DcmiDieType) (string, error)
-	DcGetPCIeBusInfo(int32, int32) (string, error)
-
-	DcGetCardList() (int32, []int32, error)
-	DcGetDeviceNumInCard(int32) (int32, error)
-	DcSetDestroyVirtualDevice(int32, int32, uint32) error
-	DcCreateVirtualDevice(int32, int32, common.CgoCreateVDevRes) (common.CgoCreateVDevOut, error)
-	DcGetDeviceVDevResource(int32, int32, uint32) (common.CgoVDevQueryStru, error)
-	DcGetDeviceTotalResource(int32, int32) (common.CgoSocTotalResource, error)
-	DcGetDeviceFreeResource(int32, int32) (common.CgoSocFreeResource, error)
-	DcGetVDevActivityInfo(int32, int32, uint32) (common.VDevActivityInfo, error)
-	DcVGetDeviceInfo(int32, int32) (common.VirtualDevInfo, error)
-	DcGetCardIDDeviceID(int32) (int32, int32, error)
-	DcCreateVDevice(int32, common.CgoCreateVDevRes) (common.CgoCreateVDevOut, error)
-	DcGetVDeviceInfo(int32) (common.VirtualDevInfo, error)
-	DcDestroyVDevice(int32, uint32) error
-	DcGetProductType(int32, int32) (string, error)
-	DcGetNpuWorkMode(int32) (int, error)
-	DcSetDeviceReset(int32, int32) error
-	DcGetDeviceBootStatus(int32) (int, error)
-
-	DcGetDeviceAllErrorCode(int32, int32) (int32, []int64, error)
-	DcSubscribeDeviceFaultEvent(int32, int32) error
-	DcSetFaultEventCallFunc(func(common.DevFaultInfo))
-	DcGetDevProcessInfo(int32, int32) (*common.DevProcessInfo, error)
-	DcGetDeviceBoardInfo(int32, int32) (common.BoardInfo, error)
-}
-
-const (
-	dcmiLibraryName = "libdcmi.so"
-	templateNameLen = 32
-)
-
-var faultEventCallFunc func(common.DevFaultInfo)
-
-// DcManager for manager dcmi interface
-type DcManager struct{}
-
 // DcInit load symbol and initialize dcmi
 func (d *DcManager) DcInit() error {
 	dcmiLibPath, err := utils.GetDriverLibPath(dcmiLibraryName)
@@ -427,10 +385,10 @@ func (d *DcManager) DcInit() error {
 	cDcmiTemplateName := C.CString(dcmiLibPath)
 	defer C.free(unsafe.Pointer(cDcmiTemplateName))
 	if retCode := C.dcmiInit_dl(cDcmiTemplateName); retCode != C.SUCCESS {
-		return fmt.Errorf("dcmi lib load failed, error code: %d", int32(retCode))
+		return fmt.Errorf("dcmi lib load failed, error code: %w", common.DriverError(retCode))
 	}
 	if retCode := C.dcmi_init_new(); retCode != C.SUCCESS {
-		return fmt.Errorf("dcmi init failed, error code: %d", int32(retCode))
+		return fmt.Errorf("dcmi init failed, error code: %w", common.DriverError(retCode))
 	}
 	return nil
 }
@@ -438,7 +396,7 @@ func (d *DcManager) DcInit() error {
 // DcShutDown clean the dynamically loaded resource
 func (d *DcManager) DcShutDown() error {
 	if retCode := C.dcmiShutDown(); retCode != C.SUCCESS {
-		return fmt.Errorf("dcmi shut down failed, error code: %d", int32(retCode))
+		return fmt.Errorf("dcmi shut down failed, error code: %w", common.DriverError(retCode))
 	}
 
 	return nil
@@ -450,11 +408,11 @@ func (d *DcManager) DcGetCardList() (int32, []int32, error) {
 	var cNum C.int
 	if retCode := C.dcmi_get_card_list(&cNum, &ids[0], common.HiAIMaxCardNum); int32(retCode) != common.
 		Success {
-		return common.RetError, nil, fmt.Errorf("get card list failed, error code: %d", int32(retCode))
+		return common.RetError, nil, fmt.Errorf("get card list failed, error code: %w", common.DriverError(retCode))
 	}
 	// checking card's quantity
 	if cNum <= 0 || cNum > common.HiAIMaxCardNum {
-		return common.RetError, nil, fmt.Errorf("get error card quantity: %d", int32(cNum))
+		return common.RetError, nil, common.InvalidErrorf("get error card quantity: %d", int32(cNum))
 	}
 	var cardNum = int32(cNum)
 	var i int32
@@ -473,14 +431,15 @@ func (d *DcManager) DcGetCardList() (int32, []int32, error) {
 // DcGetDeviceNumInCard get device number in the npu card
 func (d *DcManager) DcGetDeviceNumInCard(cardID int32) (int32, error) {
 	if !common.IsValidCardID(cardID) {
-		return common.RetError, fmt.Errorf("cardID(%d) is invalid", cardID)
+		return common.RetError, common.InvalidErrorf("cardID(%d) is invalid", cardID)
 	}
 	var deviceNum C.int
 	if retCode := C.dcmi_get_device_num_in_card_new(C.int(cardID), &deviceNum); int32(retCode) != common.Success {
-		return common.RetError, fmt.Errorf("get device count on the card failed, error code: %d", int32(retCode))
+		return common.RetError, fmt.Errorf("get device count on the card failed, error code: %w",
+			common.DriverError(retCode))
 	}
 	if !common.IsValidDevNumInCard(int32(deviceNum)) {
-		return common.RetError, fmt.Errorf("get error device quantity: %d", int32(deviceNum))
+		return common.RetError, common.InvalidErrorf("get error device quantity: %d", int32(deviceNum))
 	}
 	return int32(deviceNum), nil
 }
@@ -488,18 +447,18 @@ func (d *DcManager) DcGetDeviceNumInCard(cardID int32) (int32, error) {
 // DcGetDeviceLogicID get device logicID
 func (d *DcManager) DcGetDeviceLogicID(cardID, deviceID int32) (int32, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.RetError, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var logicID C.int
 	if retCode := C.dcmi_get_device_logic_id_new(&logicID, C.int(cardID),
 		C.int(deviceID)); int32(retCode) != common.Success {
-		return common.RetError, fmt.Errorf("failed to get logicID by cardID(%d) and deviceID(%d), error code: %d",
-			cardID, deviceID, int32(retCode))
+		return common.RetError, fmt.Errorf("failed to get logicID by cardID(%d) and deviceID(%d), error code: %w",
+			cardID, deviceID, common.DriverError(retCode))
 	}
 
 	// check whether logicID is invalid
 	if !common.IsValidLogicIDOrPhyID(int32(logicID)) {
-		return common.RetError, fmt.Errorf("get invalid logicID: %d", int32(logicID))
+		return common.RetError, common.InvalidErrorf("get invalid logicID: %d", int32(logicID))
 	}
 	return int32(logicID), nil
 }
@@ -507,11 +466,11 @@ func (d *DcManager) DcGetDeviceLogicID(cardID, deviceID int32) (int32, error) {
 // DcSetDestroyVirtualDevice destroy virtual device
 func (d *DcManager) DcSetDestroyVirtualDevice(cardID, deviceID int32, vDevID uint32) error {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	if retCode := C.dcmi_set_destroy_vdevice(C.int(cardID), C.int(deviceID),
 		C.uint(vDevID)); int32(retCode) != common.Success {
-		return fmt.Errorf("destroy virtual device failed, error code: %d", int32(retCode))
+		return fmt.Errorf("destroy virtual device failed, error code: %w", common.DriverError(retCode))
 	}
 	return nil
 }
@@ -531,10 +490,11 @@ func convertCreateVDevOut(cCreateVDevOut C.struct_dcmi_create_vdev_out) common.C
 func (d *DcManager) DcCreateVirtualDevice(cardID, deviceID int32, vDevInfo common.CgoCreateVDevRes) (common.
 	CgoCreateVDevOut, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.CgoCreateVDevOut{}, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.CgoCreateVDevOut{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
 	}
 	if len(vDevInfo.TemplateName) > templateNameLen {
-		return common.CgoCreateVDevOut{}, fmt.Errorf("the length of template name exceeds the upper limit")
+		return common.CgoCreateVDevOut{}, common.InvalidErrorf("the length of template name exceeds the upper limit")
 	}
 	cTemplateName := [templateNameLen]C.char{0}
 	for i := 0; i < len(vDevInfo.TemplateName); i++ {
@@ -549,7 +509,7 @@ func (d *DcManager) DcCreateVirtualDevice(cardID, deviceID int32, vDevInfo commo
 	var createVDevOut C.struct_dcmi_create_vdev_out
 	if retCode := C.dcmi_create_vdevice(C.int(cardID), C.int(deviceID), &deviceCreateStr,
 		&createVDevOut); int32(retCode) != common.Success {
-		return common.CgoCreateVDevOut{}, fmt.Errorf("create vdevice failed, error is: %d", int32(retCode))
+		return common.CgoCreateVDevOut{}, fmt.Errorf("create vdevice failed, error is: %w", common.DriverError(retCode))
 	}
 
 	return convertCreateVDevOut(createVDevOut), nil
@@ -642,7 +602,8 @@ func convertVDevQueryStru(cVDevQueryStru C.struct_dcmi_vdev_query_stru) common.C
 // DcGetDeviceVDevResource get virtual device resource info
 func (d *DcManager) DcGetDeviceVDevResource(cardID, deviceID int32, vDevID uint32) (common.CgoVDevQueryStru, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.CgoVDevQueryStru{}, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.CgoVDevQueryStru{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
 	}
 	var cMainCmd = C.enum_dcmi_main_cmd(MainCmdVDevMng)
 	subCmd := VmngSubCmdGetVDevResource
@@ -651,7 +612,8 @@ func (d *DcManager) DcGetDeviceVDevResource(cardID, deviceID int32, vDevID uint3
 	vDevResource.vdev_id = C.uint(vDevID)
 	if retCode := C.dcmi_get_device_info(C.int(cardID), C.int(deviceID), cMainCmd, C.uint(subCmd),
 		unsafe.Pointer(&vDevResource), &size); int32(retCode) != common.Success {
-		return common.CgoVDevQueryStru{}, fmt.Errorf("get device info failed, error is: %d", int32(retCode))
+		return common.CgoVDevQueryStru{}, fmt.Errorf("get device info failed, error is: %w",
+			common.DriverError(retCode))
 	}
 	return convertVDevQueryStru(vDevResource), nil
 }
@@ -674,7 +636,8 @@ func convertSocTotalResource(cSocTotalResource C.struct_dcmi_soc_total_resource)
 // DcGetDeviceTotalResource get device total resource info
 func (d *DcManager) DcGetDeviceTotalResource(cardID, deviceID int32) (common.CgoSocTotalResource, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.CgoSocTotalResource{}, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.CgoSocTotalResource{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
 	}
 	var cMainCmd = C.enum_dcmi_main_cmd(MainCmdVDevMng)
 	subCmd := VmngSubCmdGetTotalResource
@@ -682,10 +645,11 @@ func (d *DcManager) DcGetDeviceTotalResource(cardID, deviceID int32) (common.Cgo
 	size := C.uint(unsafe.Sizeof(totalResource))
 	if retCode := C.dcmi_get_device_info(C.int(cardID), C.int(deviceID), cMainCmd, C.uint(subCmd),
 		unsafe.Pointer(&totalResource), &size); int32(retCode) != common.Success {
-		return common.CgoSocTotalResource{}, fmt.Errorf("get device info failed, error is: %d", int32(retCode))
+		return common.CgoSocTotalResource{}, fmt.Errorf("get device info failed, error is: %w",
+			common.DriverError(retCode))
 	}
 	if uint32(totalResource.vdev_num) > dcmiMaxVdevNum {
-		return common.CgoSocTotalResource{}, fmt.Errorf("get error virtual quantity: %d",
+		return common.CgoSocTotalResource{}, common.InvalidErrorf("get error virtual quantity: %d",
 			uint32(totalResource.vdev_num))
 	}
 
@@ -706,7 +670,8 @@ func convertSocFreeResource(cSocFreeResource C.struct_dcmi_soc_free_resource) co
 // DcGetDeviceFreeResource get device free resource info
 func (d *DcManager) DcGetDeviceFreeResource(cardID, deviceID int32) (common.CgoSocFreeResource, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.CgoSocFreeResource{}, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.CgoSocFreeResource{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
 	}
 	var cMainCmd = C.enum_dcmi_main_cmd(MainCmdVDevMng)
 	subCmd := VmngSubCmdGetFreeResource
@@ -714,7 +679,8 @@ func (d *DcManager) DcGetDeviceFreeResource(cardID, deviceID int32) (common.CgoS
 	size := C.uint(unsafe.Sizeof(freeResource))
 	if retCode := C.dcmi_get_device_info(C.int(cardID), C.int(deviceID), cMainCmd, C.uint(subCmd),
 		unsafe.Pointer(&freeResource), &size); int32(retCode) != common.Success {
-		return common.CgoSocFreeResource{}, fmt.Errorf("get device info failed, error is: %d", int32(retCode))
+		return common.CgoSocFreeResource{}, fmt.Errorf("get device info failed, error is: %w",
+			common.DriverError(retCode))
 	}
 	return convertSocFreeResource(freeResource), nil
 }
@@ -722,10 +688,11 @@ func (d *DcManager) DcGetDeviceFreeResource(cardID, deviceID int32) (common.CgoS
 // DcGetVDevActivityInfo get vir device activity info by virtual device id
 func (d *DcManager) DcGetVDevActivityInfo(cardID, deviceID int32, vDevID uint32) (common.VDevActivityInfo, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.VDevActivityInfo{}, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.VDevActivityInfo{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
 	}
 	if !common.IsValidVDevID(vDevID) {
-		return common.VDevActivityInfo{}, fmt.Errorf("vDevID(%d) invalid", vDevID)
+		return common.VDevActivityInfo{}, common.InvalidErrorf("vDevID(%d) invalid", vDevID)
 	}
 	var cMainCmd = C.enum_dcmi_main_cmd(MainCmdVDevMng)
 	subCmd := VmngSubCmdGetVDevActivity
@@ -734,12 +701,12 @@ func (d *DcManager) DcGetVDevActivityInfo(cardID, deviceID int32, vDevID uint32)
 	vDevActivityInfo.vdev_id = C.uint(vDevID)
 	if retCode := C.dcmi_get_device_info(C.int(cardID), C.int(deviceID), cMainCmd, C.uint(subCmd),
 		unsafe.Pointer(&vDevActivityInfo), &size); int32(retCode) != common.Success {
-		return common.VDevActivityInfo{}, fmt.Errorf("retCode: %d", int32(retCode))
+		return common.VDevActivityInfo{}, fmt.Errorf("retCode: %w", common.DriverError(retCode))
 	}
 	totalMemSize := uint64(vDevActivityInfo.query_info.computing.vdev_memory_total)
 	usedMemSize := totalMemSize - uint64(vDevActivityInfo.query_info.computing.vdev_memory_free)
 	if usedMemSize < 0 {
-		return common.VDevActivityInfo{}, errors.New("used memory value abnormal")
+		return common.VDevActivityInfo{}, common.InvalidErrorf("used memory value abnormal")
 	}
 	return common.VDevActivityInfo{
 		VDevID:         vDevID,
@@ -750,59 +717,25 @@ func (d *DcManager) DcGetVDevActivityInfo(cardID, deviceID int32, vDevID uint32)
 	}, nil
 }
 
-// DcVGetDeviceInfo get vdevice resource info
-func (d *DcManager) DcVGetDeviceInfo(cardID, deviceID int32) (common.VirtualDevInfo, error) {
-	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.VirtualDevInfo{}, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
-	}
+func (d *DcManager) getDeviceType(cardID, deviceID int32) (int32, error) {
 	var unitType C.enum_dcmi_unit_type
 	if retCode := C.dcmi_get_device_type(C.int(cardID), C.int(deviceID), &unitType); int32(retCode) != 0 {
-		return common.VirtualDevInfo{}, fmt.Errorf("get device type failed, error is: %d", int32(retCode))
-	}
-	if int32(unitType) != common.NpuType {
-		return common.VirtualDevInfo{}, fmt.Errorf("not support unit type: %d", int32(unitType))
-	}
-
-	cgoDcmiSocTotalResource, err := d.DcGetDeviceTotalResource(cardID, deviceID)
-	if err != nil {
-		return common.VirtualDevInfo{}, fmt.Errorf("get device total resource failed, error is: %v", err)
-	}
-
-	cgoDcmiSocFreeResource, err := d.DcGetDeviceFreeResource(cardID, deviceID)
-	if err != nil {
-		return common.VirtualDevInfo{}, fmt.Errorf("get device free resource failed, error is: %v", err)
+		return common.RetError, fmt.Errorf("get device type failed, error is: %w", common.DriverError(retCode))
 	}
-	dcmiVDevInfo := common.VirtualDevInfo{
-		TotalResource: cgoDcmiSocTotalResource,
-		FreeResource:  cgoDcmiSocFreeResource,
-	}
-	for _, vDevID := range cgoDcmiSocTotalResource.VDevID {
-		cgoVDevQueryStru, err := d.DcGetDeviceVDevResource(cardID, deviceID, vDevID)
-		if err != nil {
-			return common.VirtualDevInfo{}, fmt.Errorf("get device virtual resource failed, error is: %v", err)
-		}
-		dcmiVDevInfo.VDevInfo = append(dcmiVDevInfo.VDevInfo, cgoVDevQueryStru)
-		vDevActivityInfo, err := d.DcGetVDevActivityInfo(cardID, deviceID, vDevID)
-		if err != nil {
-			hwlog.RunLog.Warnf("get cur vDev's activity info failed, err: %s", err)
-		}
-		vDevActivityInfo.VDevAiCore = float64(cgoVDevQueryStru.QueryInfo.Computing.Aic)
-		dcmiVDevInfo.VDevActivityInfo = append(dcmiVDevInfo.VDevActivityInfo, vDevActivityInfo)
-	}
-	return dcmiVDevInfo, nil
+	return int32(unitType), nil
 }
 
 // DcGetCardIDDeviceID get card id and device id from logic id
 func (d *DcManager) DcGetCardIDDeviceID(logicID int32) (int32, int32, error) {
 	if !common.IsValidLogicIDOrPhyID(logicID) {
-		return common.RetError, common.RetError, fmt.Errorf("input invalid logicID: %d", logicID)
+		return common.RetError, common.RetError, common.InvalidErrorf("input invalid logicID: %d", logicID)
 	}
 	var cardID, deviceID C.int
 	if retCode := C.dcmi_get_card_id_device_id_from_logicid(&cardID, &deviceID,
 		C.uint(logicID)); int32(retCode) != common.Success {
 		return common.RetError, common.RetError,
-			fmt.Errorf("failed to get card id and device id by logicID(%d), errorcode is: %d", logicID,
-				int32(retCode))
+			fmt.Errorf("failed to get card id and device id by logicID(%d), errorcode is: %w", logicID,
+				common.DriverError(retCode))
 	}
 	if !common.IsValidCardIDAndDeviceID(int32(cardID), int32(deviceID)) {
 		return common.RetError, common.RetError, fmt.Errorf("failed to get card id and device id, "+
@@ -812,70 +745,19 @@ func (d *DcManager) DcGetCardIDDeviceID(logicID int32) (int32, int32, error) {
 	return int32(cardID), int32(deviceID), nil
 }
 
-// DcCreateVDevice create virtual device by logic id
-func (d *DcManager) DcCreateVDevice(logicID int32, vDevInfo common.CgoCreateVDevRes) (common.
-	CgoCreateVDevOut, error) {
-	if !common.IsValidLogicIDOrPhyID(logicID) {
-		return common.CgoCreateVDevOut{}, fmt.Errorf("input invalid logicID: %d", logicID)
-	}
-	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
-	if err != nil {
-		return common.CgoCreateVDevOut{}, fmt.Errorf("get card id and device id failed, error is: %v", err)
-	}
-
-	createVDevOut, err := d.DcCreateVirtualDevice(cardID, deviceID, vDevInfo)
-	if err != nil {
-		return common.CgoCreateVDevOut{}, fmt.Errorf("create virtual device failed, error is: %v", err)
-	}
-	return createVDevOut, nil
-}
-
-// DcGetVDeviceInfo get virtual device info by logic id
-func (d *DcManager) DcGetVDeviceInfo(logicID int32) (common.VirtualDevInfo, error) {
-	if !common.IsValidLogicIDOrPhyID(logicID) {
-		return common.VirtualDevInfo{}, fmt.Errorf("input invalid logicID: %d", logicID)
-	}
-	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
-	if err != nil {
-		return common.VirtualDevInfo{}, fmt.Errorf("get card id and device id failed, error is: %v", err)
-	}
-
-	dcmiVDevInfo, err := d.DcVGetDeviceInfo(cardID, deviceID)
-	if err != nil {
-		return common.VirtualDevInfo{}, fmt.Errorf("get virtual device info failed, error is: %v", err)
-	}
-	return dcmiVDevInfo, nil
-}
-
-// DcDestroyVDevice destroy spec virtual device by logic id
-func (d *DcManager) DcDestroyVDevice(logicID int32, vDevID uint32) error {
-	if !common.IsValidLogicIDOrPhyID(logicID) {
-		return fmt.Errorf("input invalid logicID: %d", logicID)
-	}
-	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
-	if err != nil {
-		return fmt.Errorf("get card id and device id failed, error is: %v", err)
-	}
-
-	if err = d.DcSetDestroyVirtualDevice(cardID, deviceID, vDevID); err != nil {
-		return fmt.Errorf("destroy virtual device failed, error is: %v", err)
-	}
-	return nil
-}
-
 // DcGetDeviceVoltage the accuracy is 0.01v.
 func (d *DcManager) DcGetDeviceVoltage(cardID, deviceID int32) (float32, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.RetError, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var vol C.uint
 	if retCode := C.dcmi_get_device_voltage(C.int(cardID), C.int(deviceID), &vol); int32(retCode) != common.Success {
 		return common.RetError, fmt.Errorf("failed to obtain the voltage based on card_id(%d) and device_id(%d), "+
-			"error code: %d", cardID, deviceID, int32(retCode))
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
 	}
 	// the voltage's value is error if it's greater than or equal to MaxInt32
 	if common.IsGreaterThanOrEqualInt32(int64(vol)) {
-		return common.RetError, fmt.Errorf("voltage value out of range(max is int32), "+
+		return common.RetError, common.InvalidErrorf("voltage value out of range(max is int32), "+
 			"card_id(%d) and device_id(%d), voltage: %d", cardID, deviceID, int64(vol))
 	}
 
@@ -885,17 +767,17 @@ func (d *DcManager) DcGetDeviceVoltage(cardID, deviceID int32) (float32, error)
 // DcGetDevicePowerInfo the accuracy is 0.1w, the result like: 8.2
 func (d *DcManager) DcGetDevicePowerInfo(cardID, deviceID int32) (float32, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.RetError, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var cpower C.int
 	if retCode := C.dcmi_get_device_power_info(C.int(cardID), C.int(deviceID),
 		&cpower); int32(retCode) != common.Success {
 		return common.RetError, fmt.Errorf("failed to obtain the power based on card_id(%d) and device_id(%d), "+
-			"error code: %d", cardID, deviceID, int32(retCode))
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
 	}
 	parsedPower := float32(cpower)
 	if parsedPower < 0 {
-		return common.RetError, fmt.Errorf("get wrong device power, card_id(%d) and device_id(%d), power: %f",
+		return common.RetError, common.InvalidErrorf("get wrong device power, card_id(%d) and device_id(%d), power: %f",
 			cardID, deviceID, parsedPower)
 	}
 
@@ -911,17 +793,17 @@ func (d *DcManager) DcGetDevicePowerInfo(cardID, deviceID int32) (float32, error
 // more information see common.DeviceType
 func (d *DcManager) DcGetDeviceFrequency(cardID, deviceID int32, devType common.DeviceType) (uint32, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.InvalidVal, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.InvalidVal, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var cFrequency C.uint
 	if retCode := C.dcmi_get_device_frequency(C.int(cardID), C.int(deviceID), C.enum_dcmi_freq_type(devType),
 		&cFrequency); int32(retCode) != common.Success {
 		return common.InvalidVal, fmt.Errorf("failed to obtain the frequency based on card_id(%d) and device_id(%d), "+
-			"error code: %d", cardID, deviceID, int32(retCode))
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
 	}
 	// check whether cFrequency is too big
 	if common.IsGreaterThanOrEqualInt32(int64(cFrequency)) || int64(cFrequency) < 0 {
-		return common.InvalidVal, fmt.Errorf("frequency value out of range [0, int32), "+
+		return common.InvalidVal, common.InvalidErrorf("frequency value out of range [0, int32), "+
 			"card_id(%d) and device_id(%d), frequency: %d", cardID, deviceID, int64(cFrequency))
 	}
 	return uint32(cFrequency), nil
@@ -930,13 +812,13 @@ func (d *DcManager) DcGetDeviceFrequency(cardID, deviceID int32, devType common.
 // DcGetMemoryInfo use v3 interface to query memory info
 func (d *DcManager) DcGetMemoryInfo(cardID, deviceID int32) (*common.MemoryInfo, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return nil, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var cmInfoV3 CDcmiMemoryInfoV3
 	if retCode := C.dcmi_get_device_memory_info_v3(C.int(cardID), C.int(deviceID),
 		&cmInfoV3); int32(retCode) != common.Success {
 		return nil, fmt.Errorf("failed to obtain the memory info by v3 interface based on card_id("+
-			"%d) and device_id(%d), error code: %d", cardID, deviceID, int32(retCode))
+			"%d) and device_id(%d), error code: %w", cardID, deviceID, common.DriverError(retCode))
 	}
 
 	if uint64(cmInfoV3.memory_size) < uint64(cmInfoV3.memory_available) {
@@ -956,17 +838,17 @@ func (d *DcManager) DcGetMemoryInfo(cardID, deviceID int32) (*common.MemoryInfo,
 // FuncDcmiGetDeviceHbmInfo dcmi_get_device_hbm_info function for outer invoke, only for Ascend910
 func FuncDcmiGetDeviceHbmInfo(cardID, deviceID int32) (*common.HbmInfo, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return nil, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var cHbmInfo C.struct_dcmi_hbm_info
 	if retCode := C.dcmi_get_device_hbm_info(C.int(cardID), C.int(deviceID),
 		&cHbmInfo); int32(retCode) != common.Success {
 		return nil, fmt.Errorf("failed to obtain the hbm info based on card_id(%d) and device_id(%d), "+
-			"error code: %d", cardID, deviceID, int32(retCode))
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
 	}
 	hbmTemp := int32(cHbmInfo.temp)
 	if hbmTemp < 0 {
-		return nil, fmt.Errorf("get wrong device HBM temporary, card_id(%d) and device_id(%d), HBM.temp: %d",
+		return nil, common.InvalidErrorf("get wrong device HBM temporary, card_id(%d) and device_id(%d), HBM.temp: %d",
 			cardID, deviceID, hbmTemp)
 	}
 	return &common.HbmInfo{
@@ -977,20 +859,10 @@ func FuncDcmiGetDeviceHbmInfo(cardID, deviceID int32) (*common.HbmInfo, error) {
 		BandWidthUtilRate: uint32(cHbmInfo.bandwith_util_rate)}, nil
 }
 
-// DcGetHbmInfo get HBM information A310/A310P not support
-func (d *DcManager) DcGetHbmInfo(cardID, deviceID int32) (*common.HbmInfo, error) {
-	return &common.HbmInfo{
-		MemorySize:        0,
-		Frequency:         0,
-		Usage:             0,
-		Temp:              0,
-		BandWidthUtilRate: 0}, nil
-}
-
 // DcGetDeviceErrorCode get the error count and errorcode of the device,only return the first errorcode
 func (d *DcManager) DcGetDeviceErrorCode(cardID, deviceID int32) (int32, int64, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.RetError, common.RetError, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID,
+		return common.RetError, common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID,
 			deviceID)
 	}
 	var errCount C.int
@@ -998,71 +870,31 @@ func (d *DcManager) DcGetDeviceErrorCode(cardID, deviceID int32) (int32, int64,
 	if retCode := C.dcmi_get_device_errorcode_v2(C.int(cardID), C.int(deviceID), &errCount, &errCodeArray[0],
 		common.MaxErrorCodeCount); int32(retCode) != common.Success {
 		return common.RetError, common.RetError, fmt.Errorf("failed to obtain the device errorcode based on card_id("+
-			"%d) and device_id(%d), error code: %d, error count: %d", cardID, deviceID, int32(retCode),
+			"%d) and device_id(%d), error code: %w, error count: %d", cardID, deviceID, common.DriverError(retCode),
 			int32(errCount))
 	}
 
 	if int32(errCount) < 0 || int32(errCount) > common.MaxErrorCodeCount {
-		return common.RetError, common.RetError, fmt.Errorf("get wrong errorcode count, "+
+		return common.RetError, common.RetError, common.InvalidErrorf("get wrong errorcode count, "+
 			"card_id(%d) and device_id(%d), errorcode count: %d", cardID, deviceID, int32(errCount))
 	}
 
 	return int32(errCount), int64(errCodeArray[0]), nil
 }
 
-// DcGetDeviceCount get device count
-func (d *DcManager) DcGetDeviceCount() (int32, error) {
-	devNum, _, err := d.DcGetLogicIDList()
-	if err != nil {
-		return common.RetError, fmt.Errorf("get device count failed, error: %v", err)
-	}
-	return devNum, nil
-}
-
-// DcGetLogicIDList get device logic id list
-func (d *DcManager) DcGetLogicIDList() (int32, []int32, error) {
-	var logicIDs []int32
-	var totalNum int32
-	_, cardList, err := d.DcGetCardList()
-	if err != nil {
-		return common.RetError, logicIDs, fmt.Errorf("get card list failed, error: %v", err)
-	}
-	for _, cardID := range cardList {
-		devNumInCard, err := d.DcGetDeviceNumInCard(cardID)
-		if err != nil {
-			return common.RetError, logicIDs, fmt.Errorf("get device num by cardID: %d failed, error: %v",
-				cardID, err)
-		}
-		totalNum += devNumInCard
-		if totalNum > common.HiAIMaxDeviceNum*common.HiAIMaxCardNum {
-			return common.RetError, nil, fmt.Errorf("get device num: %d greater than %d",
-				totalNum, common.HiAIMaxDeviceNum*common.HiAIMaxCardNum)
-		}
-		for devID := int32(0); devID < devNumInCard; devID++ {
-			logicID, err := d.DcGetDeviceLogicID(cardID, devID)
-			if err != nil {
-				return common.RetError, nil, fmt.Errorf("get device (cardID: %d, deviceID: %d) logic id "+
-					"failed, error: %v", cardID, devID, err)
-			}
-			logicIDs = append(logicIDs, logicID)
-		}
-	}
-	return totalNum, logicIDs, nil
-}
-
 // DcGetDeviceHealth get device health
 func (d *DcManager) DcGetDeviceHealth(cardID, deviceID int32) (int32, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.RetError, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var health C.uint
 	if retCode := C.dcmi_get_device_health(C.int(cardID), C.int(deviceID),
 		&health); int32(retCode) != common.Success {
 		return common.RetError, fmt.Errorf("get device (cardID: %d, deviceID: %d) health state failed, error "+
-			"code: %d", cardID, deviceID, int32(retCode))
+			"code: %w", cardID, deviceID, common.DriverError(retCode))
 	}
 	if common.IsGreaterThanOrEqualInt32(int64(health)) {
-		return common.RetError, fmt.Errorf("get wrong health state , device (cardID: %d, deviceID: %d) "+
+		return common.RetError, common.InvalidErrorf("get wrong health state , device (cardID: %d, deviceID: %d) "+
 			"health: %d", cardID, deviceID, int64(health))
 	}
 	return int32(health), nil
@@ -1071,16 +903,16 @@ func (d *DcManager) DcGetDeviceHealth(cardID, deviceID int32) (int32, error) {
 // DcGetDeviceUtilizationRate get device utils rate by id
 func (d *DcManager) DcGetDeviceUtilizationRate(cardID, deviceID int32, devType common.DeviceType) (int32, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.RetError, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var rate C.uint
 	if retCode := C.dcmi_get_device_utilization_rate(C.int(cardID), C.int(deviceID), C.int(devType),
 		&rate); int32(retCode) != common.Success {
 		return common.RetError, fmt.Errorf("get device (cardID: %d, deviceID: %d) utilization rate: %d failed, "+
-			"error code: %d", cardID, deviceID, uint32(rate), int32(retCode))
+			"error code: %w", cardID, deviceID, uint32(rate), common.DriverError(retCode))
 	}
 	if !common.IsValidUtilizationRate(uint32(rate)) {
-		return common.RetError, fmt.Errorf("get wrong device (cardID: %d, deviceID: %d) utilization rate: %d",
+		return common.RetError, common.InvalidErrorf("get wrong device (cardID: %d, deviceID: %d) utilization rate: %d",
 			cardID, deviceID, uint32(rate))
 	}
 	return int32(rate), nil
@@ -1089,18 +921,18 @@ func (d *DcManager) DcGetDeviceUtilizationRate(cardID, deviceID int32, devType c
 // DcGetDeviceTemperature get the device temperature
 func (d *DcManager) DcGetDeviceTemperature(cardID, deviceID int32) (int32, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.RetError, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var temp C.int
 	if retCode := C.dcmi_get_device_temperature(C.int(cardID), C.int(deviceID),
 		&temp); int32(retCode) != common.Success {
 		return common.RetError, fmt.Errorf("get device (cardID: %d, deviceID: %d) temperature failed, error "+
-			"code is : %d", cardID, deviceID, int32(retCode))
+			"code is : %w", cardID, deviceID, common.DriverError(retCode))
 	}
 	parsedTemp := int32(temp)
 	if parsedTemp < int32(common.DefaultTemperatureWhenQueryFailed) {
-		return common.RetError, fmt.Errorf("get wrong device temperature, devcie (cardID: %d, deviceID: %d), "+
-			"temperature: %d", cardID, deviceID, parsedTemp)
+		return common.RetError, common.InvalidErrorf("get wrong device temperature, devcie (cardID: %d, "+
+			"deviceID: %d), temperature: %d", cardID, deviceID, parsedTemp)
 	}
 	return parsedTemp, nil
 }
@@ -1119,12 +951,12 @@ func convertUCharToCharArr(cgoArr [maxChipNameLen]C.uchar) []byte {
 // DcGetChipInfo get the chip info by cardID and deviceID
 func (d *DcManager) DcGetChipInfo(cardID, deviceID int32) (*common.ChipInfo, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return nil, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var chipInfo C.struct_dcmi_chip_info
 	if rCode := C.dcmi_get_device_chip_info(C.int(cardID), C.int(deviceID), &chipInfo); int32(rCode) != common.Success {
 		return nil, fmt.Errorf("get device ChipInfo information failed, cardID(%d), deviceID(%d),"+
-			" error code: %d", cardID, deviceID, int32(rCode))
+			" error code: %w", cardID, deviceID, common.DriverError(rCode))
 	}
 
 	name := convertUCharToCharArr(chipInfo.chip_name)
@@ -1147,14 +979,16 @@ func (d *DcManager) DcGetChipInfo(cardID, deviceID int32) (*common.ChipInfo, err
 // DcGetPhysicIDFromLogicID get physicID from logicID
 func (d *DcManager) DcGetPhysicIDFromLogicID(logicID int32) (int32, error) {
 	if !common.IsValidLogicIDOrPhyID(logicID) {
-		return common.RetError, fmt.Errorf("logicID(%d) is invalid", logicID)
+		return common.RetError, common.InvalidErrorf("logicID(%d) is invalid", logicID)
 	}
 	var physicID C.uint
 	if rCode := C.dcmi_get_device_phyid_from_logicid(C.uint(logicID), &physicID); int32(rCode) != common.Success {
-		return common.RetError, fmt.Errorf("get physic id from logicID(%d) failed, error code: %d", logicID, int32(rCode))
+		return common.RetError, fmt.Errorf("get physic id from logicID(%d) failed, error code: %w", logicID,
+			common.DriverError(rCode))
 	}
 	if !common.IsValidLogicIDOrPhyID(int32(physicID)) {
-		return common.RetError, fmt.Errorf("get wrong physicID(%d) from logicID(%d)", uint32(physicID), logicID)
+		return common.RetError, common.InvalidErrorf("get wrong physicID(%d) from logicID(%d)",
+			uint32(physicID), logicID)
 	}
 	return int32(physicID), nil
 }
@@ -1162,7 +996,7 @@ func (d *DcManager) DcGetPhysicIDFromLogicID(logicID int32) (int32, error) {
 // DcGetDeviceIPAddress get device IP address by cardID and deviceID
 func (d *DcManager) DcGetDeviceIPAddress(cardID, deviceID, ipType int32) (string, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return "", fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return "", common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var portType C.enum_dcmi_port_type = 1
 	var portID C.int
@@ -1173,8 +1007,8 @@ func (d *DcManager) DcGetDeviceIPAddress(cardID, deviceID, ipType int32) (string
 	}
 	rCode := C.dcmi_get_device_ip(C.int(cardID), C.int(deviceID), portType, portID, &ipAddress, &maskAddress)
 	if int32(rCode) != common.Success {
-		return "", fmt.Errorf("get device IP address failed, cardID(%d), deviceID(%d), error code: %d",
-			cardID, deviceID, int32(rCode))
+		return "", fmt.Errorf("get device IP address failed, cardID(%d), deviceID(%d), error code: %w",
+			cardID, deviceID, common.DriverError(rCode))
 	}
 	if ipType == ipAddrTypeV6 {
 		return d.buildIPv6Addr(ipAddress)
@@ -1213,17 +1047,17 @@ func (d *DcManager) buildIPv6Addr(ipAddress C.struct_dcmi_ip_addr) (string, erro
 // DcGetDeviceNetWorkHealth get device network health by cardID and deviceID
 func (d *DcManager) DcGetDeviceNetWorkHealth(cardID, deviceID int32) (uint32, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.UnRetError, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.UnRetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 
 	var healthCode C.enum_dcmi_rdfx_detect_result
 	rCode := C.dcmi_get_device_network_health(C.int(cardID), C.int(deviceID), &healthCode)
 	if int32(rCode) != common.Success {
 		return common.UnRetError, fmt.Errorf("get device network healthCode failed, cardID(%d),"+
-			" deviceID(%d), error code: %d", cardID, deviceID, int32(rCode))
+			" deviceID(%d), error code: %w", cardID, deviceID, common.DriverError(rCode))
 	}
 	if int32(healthCode) < 0 || int32(healthCode) > int32(math.MaxInt8) {
-		return common.UnRetError, fmt.Errorf("get wrong device network healthCode, cardID(%d), deviceID(%d),"+
+		return common.UnRetError, common.InvalidErrorf("get wrong device network healthCode, cardID(%d), deviceID(%d),"+
 			" error healthCode: %d", cardID, deviceID, int32(healthCode))
 	}
 	return uint32(healthCode), nil
@@ -1232,16 +1066,17 @@ func (d *DcManager) DcGetDeviceNetWorkHealth(cardID, deviceID int32) (uint32, er
 // DcGetLogicIDFromPhysicID get logicID from physicID
 func (d *DcManager) DcGetLogicIDFromPhysicID(physicID int32) (int32, error) {
 	if !common.IsValidLogicIDOrPhyID(physicID) {
-		return common.RetError, fmt.Errorf("physicID(%d) is invalid", physicID)
+		return common.RetError, common.InvalidErrorf("physicID(%d) is invalid", physicID)
 	}
 	var logicID C.uint
 	if rCode := C.dcmi_get_device_logicid_from_phyid(C.uint(physicID), &logicID); int32(rCode) != common.Success {
-		return common.RetError, fmt.Errorf("get logicID from physicID(%d) failed, error code: %d",
-			physicID, int32(rCode))
+		return common.RetError, fmt.Errorf("get logicID from physicID(%d) failed, error code: %w",
+			physicID, common.DriverError(rCode))
 	}
 
 	if !common.IsValidLogicIDOrPhyID(int32(logicID)) {
-		return common.RetError, fmt.Errorf("get wrong logicID(%d) from physicID(%d)", uint32(logicID), physicID)
+		return common.RetError, common.InvalidErrorf("get wrong logicID(%d) from physicID(%d)",
+			uint32(logicID), physicID)
 	}
 	return int32(logicID), nil
 }
@@ -1250,28 +1085,23 @@ func (d *DcManager) DcGetLogicIDFromPhysicID(physicID int32) (int32, error) {
 func FuncDcmiMcuGetPowerInfo(cardID int32) (float32, error) {
 	var power C.int
 	if retCode := C.dcmi_mcu_get_power_info_new(C.int(cardID), &power); int32(retCode) != common.Success {
-		return common.RetError, fmt.Errorf("mcu_get_power_info failed, error code is:%d", int32(retCode))
+		return common.RetError, fmt.Errorf("mcu_get_power_info failed, error code is:%w", common.DriverError(retCode))
 	}
 	parsedPower := float32(power)
 	if parsedPower < 0 {
-		return common.RetError, fmt.Errorf("get wrong mcu_get_power_info, cardID: %d, power: %f", cardID,
+		return common.RetError, common.InvalidErrorf("get wrong mcu_get_power_info, cardID: %d, power: %f", cardID,
 			parsedPower)
 	}
 	return parsedPower * common.ReduceTenth, nil
 }
 
-// DcGetMcuPowerInfo this function is only for Ascend310P, A910/A310 not support
-func (d *DcManager) DcGetMcuPowerInfo(cardID int32) (float32, error) {
-	return 0, nil
-}
-
 // DcGetProductType get product type by dcmi interface
 func (d *DcManager) DcGetProductType(cardID, deviceID int32) (string, error) {
 	cProductType := C.CString(string(make([]byte, productTypeLen)))
 	defer C.free(unsafe.Pointer(cProductType))
 	err := C.dcmi_get_product_type(C.int(cardID), C.int(deviceID), (*C.char)(cProductType), productTypeLen)
 	if err != 0 {
-		return "", fmt.Errorf("get product type failed, errCode: %d", err)
+		return "", fmt.Errorf("get product type failed, errCode: %w", common.DriverError(err))
 	}
 	return C.GoString(cProductType), nil
 }
@@ -1281,7 +1111,7 @@ func (d *DcManager) DcGetNpuWorkMode(cardID int32) (int, error) {
 	var cWorkMode C.uchar
 	err := C.dcmi_get_npu_work_mode(C.int(cardID), &cWorkMode)
 	if err != 0 {
-		return common.RetError, fmt.Errorf("get npu work mode failed, errCode: %d", err)
+		return common.RetError, fmt.Errorf("get npu work mode failed, errCode: %w", common.DriverError(err))
 	}
 	return int(cWorkMode), nil
 }
@@ -1289,11 +1119,12 @@ func (d *DcManager) DcGetNpuWorkMode(cardID int32) (int, error) {
 // DcSetDeviceReset reset spec device chip
 func (d *DcManager) DcSetDeviceReset(cardID, deviceID int32) error {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var channelType C.enum_dcmi_reset_channel = C.INBAND_CHANNEL
 	if errCode := C.dcmi_set_device_reset(C.int(cardID), C.int(deviceID), channelType); errCode != 0 {
-		return fmt.Errorf("cardID(%d) and deviceID(%d) hot reset errCode: %v", cardID, deviceID, errCode)
+		return fmt.Errorf("cardID(%d) and deviceID(%d) hot reset errCode: %w", cardID, deviceID,
+			common.DriverError(errCode))
 	}
 	return nil
 }
@@ -1301,18 +1132,18 @@ func (d *DcManager) DcSetDeviceReset(cardID, deviceID int32) error {
 // DcGetDeviceBootStatus get NPU boot status
 func (d *DcManager) DcGetDeviceBootStatus(logicID int32) (int, error) {
 	if !common.IsValidLogicIDOrPhyID(logicID) {
-		return common.RetError, fmt.Errorf("input invalid logicID: %d", logicID)
+		return common.RetError, common.InvalidErrorf("input invalid logicID: %d", logicID)
 	}
 	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		return common.RetError, fmt.Errorf("failed to get cardID and deviceID by logicID(%d)", logicID)
+		return common.RetError, fmt.Errorf("failed to get cardID and deviceID by logicID(%d), %w", logicID, err)
 	}
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.RetError, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 	var bootStatus C.enum_dcmi_boot_status = C.DCMI_BOOT_STATUS_FINISH
 	if errCode := C.dcmi_get_device_boot_status(C.int(cardID), C.int(deviceID), &bootStatus); errCode != 0 {
-		return common.RetError, fmt.Errorf("device boot status errCode: %v", errCode)
+		return common.RetError, fmt.Errorf("device boot status errCode: %w", common.DriverError(errCode))
 	}
 	return int(bootStatus), nil
 }
@@ -1320,7 +1151,7 @@ func (d *DcManager) DcGetDeviceBootStatus(logicID int32) (int, error) {
 // DcGetDeviceAllErrorCode get the error count and all error codes of the device
 func (d *DcManager) DcGetDeviceAllErrorCode(cardID, deviceID int32) (int32, []int64, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.RetError, nil, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID,
+		return common.RetError, nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID,
 			deviceID)
 	}
 	var errCount C.int
@@ -1328,12 +1159,12 @@ func (d *DcManager) DcGetDeviceAllErrorCode(cardID, deviceID int32) (int32, []in
 	if retCode := C.dcmi_get_device_errorcode_v2(C.int(cardID), C.int(deviceID), &errCount, &errCodeArray[0],
 		common.MaxErrorCodeCount); int32(retCode) != common.Success {
 		return common.RetError, nil, fmt.Errorf("failed to obtain the device errorcode based on cardID("+
-			"%d) and deviceID(%d), error code: %d, error count: %d", cardID, deviceID, int32(retCode),
+			"%d) and deviceID(%d), error code: %w, error count: %d", cardID, deviceID, common.DriverError(retCode),
 			int32(errCount))
 	}
 
 	if int32(errCount) < 0 || int32(errCount) > common.MaxErrorCodeCount {
-		return common.RetError, nil, fmt.Errorf("get wrong errorcode count, "+
+		return common.RetError, nil, common.InvalidErrorf("get wrong errorcode count, "+
 			"cardID(%d) and deviceID(%d), errorcode count: %d", cardID, deviceID, int32(errCount))
 	}
 	errCodes := make([]int64, 0, len(errCodeArray))
@@ -1348,22 +1179,17 @@ func (d *DcManager) DcGetDeviceAllErrorCode(cardID, deviceID int32) (int32, []in
 // DcSubscribeDeviceFaultEvent subscribe device fault, callback with func 'faultEventCallFunc'
 func (d *DcManager) DcSubscribeDeviceFaultEvent(cardID, deviceID int32) error {
 	if faultEventCallFunc == nil {
-		return errors.New("callFunc is invalid, can't start subscribe")
+		return common.InvalidErrorf("callFunc is invalid, can't start subscribe")
 	}
 
 	var filter C.struct_dcmi_event_filter
 	if rCode := C.dcmi_subscribe_fault_event(C.int(cardID), C.int(deviceID), filter); int32(rCode) != common.Success {
-		return fmt.Errorf("subscribe fault event failed, cardID(%d) and deviceID(%d), error code: %d",
-			cardID, deviceID, int32(rCode))
+		return fmt.Errorf("subscribe fault event failed, cardID(%d) and deviceID(%d), error code: %w",
+			cardID, deviceID, common.DriverError(rCode))
 	}
 	return nil
 }
 
-// DcSetFaultEventCallFunc set fault event call back func
-func (d *DcManager) DcSetFaultEventCallFunc(businessFunc func(common.DevFaultInfo)) {
-	faultEventCallFunc = businessFunc
-}
-
 //export goEventFaultCallBack
 func goEventFaultCallBack(event C.struct_dcmi_dms_fault_event) {
 	if faultEventCallFunc == nil {
@@ -1385,18 +1211,18 @@ func goEventFaultCallBack(event C.struct_dcmi_dms_fault_event) {
 // DcGetDieID get chip die ID, like VDieID or NDieID, only Ascend910 has NDieID
 func (d *DcManager) DcGetDieID(cardID, deviceID int32, dcmiDieType DcmiDieType) (string, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return "", fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return "", common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 
 	if dcmiDieType != VDIE && dcmiDieType != NDIE {
-		return "", fmt.Errorf("dcmi die type can only be one of %d or %d", VDIE, NDIE)
+		return "", common.InvalidErrorf("dcmi die type can only be one of %d or %d", VDIE, NDIE)
 	}
 
 	var dieIDObj C.struct_dcmi_die_id
 	if rCode := C.dcmi_get_device_die_v2(C.int(cardID), C.int(deviceID),
 		C.enum_dcmi_die_type(dcmiDieType), &dieIDObj); int32(rCode) != common.Success {
-		return "", fmt.Errorf("get chip die ID faied, cardID(%d) and deviceID(%d), error code: %d",
-			cardID, deviceID, int32(rCode))
+		return "", fmt.Errorf("get chip die ID faied, cardID(%d) and deviceID(%d), error code: %w",
+			cardID, deviceID, common.DriverError(rCode))
 	}
 
 	const hexBase = 16
@@ -1416,7 +1242,7 @@ func (d *DcManager) DcGetDieID(cardID, deviceID int32, dcmiDieType DcmiDieType)
 // DcGetDevProcessInfo chip process info
 func (d *DcManager) DcGetDevProcessInfo(cardID, deviceID int32) (*common.DevProcessInfo, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return nil, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 
 	var procList [common.MaxProcNum]C.struct_dcmi_proc_mem_info
@@ -1424,13 +1250,13 @@ func (d *DcManager) DcGetDevProcessInfo(cardID, deviceID int32) (*common.DevProc
 
 	if retCode := C.dcmi_get_device_resource_info(C.int(cardID), C.int(deviceID), &procList[0],
 		&procNum); int32(retCode) != common.Success {
-		return nil, fmt.Errorf("get device resource info failed, cardID(%d) and deviceID(%d) , error code: %d",
-			cardID, deviceID, int32(retCode))
+		return nil, fmt.Errorf("get device resource info failed, cardID(%d) and deviceID(%d) , error code: %w",
+			cardID, deviceID, common.DriverError(retCode))
 	}
 
 	if int32(procNum) < 0 || int32(procNum) > common.MaxProcNum {
-		return nil, fmt.Errorf("get invalid proccess num (%d), cardID(%d) and deviceID(%d)", int32(procNum), cardID,
-			deviceID)
+		return nil, common.InvalidErrorf("get invalid proccess num (%d), cardID(%d) and deviceID(%d)",
+			int32(procNum), cardID, deviceID)
 	}
 
 	return convertToDevResourceInfo(procList, int32(procNum)), nil
@@ -1458,14 +1284,14 @@ func convertToDevResourceInfo(procList [common.MaxProcNum]C.struct_dcmi_proc_mem
 // DcGetPCIeBusInfo pcie bus info
 func (d *DcManager) DcGetPCIeBusInfo(cardID, deviceID int32) (string, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return "", fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return "", common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 
 	var pcieInfo C.struct_dcmi_pcie_info_all
 
 	if retCode := C.dcmi_get_device_pcie_info_v2(C.int(cardID), C.int(deviceID), &pcieInfo); int32(retCode) != common.Success {
-		return "", fmt.Errorf("get pcie bus info failed, cardID(%d) and deviceID(%d) , error code: %d",
-			cardID, deviceID, int32(retCode))
+		return "", fmt.Errorf("get pcie bus info failed, cardID(%d) and deviceID(%d) , error code: %w",
+			cardID, deviceID, common.DriverError(retCode))
 	}
 
 	info := fmt.Sprintf("%04X:%02X:%02X.%-4X", int32(pcieInfo.domain), uint32(pcieInfo.bdf_busid),
@@ -1478,15 +1304,15 @@ func (d *DcManager) DcGetPCIeBusInfo(cardID, deviceID int32) (string, error) {
 // DcGetDeviceBoardInfo return board info of device
 func (d *DcManager) DcGetDeviceBoardInfo(cardID, deviceID int32) (common.BoardInfo, error) {
 	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
-		return common.BoardInfo{}, fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+		return common.BoardInfo{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
 	}
 
 	var cBoardInfo C.struct_dcmi_board_info
 
 	if retCode := C.dcmi_get_device_board_info(C.int(cardID), C.int(deviceID),
 		&cBoardInfo); int32(retCode) != common.Success {
-		return common.BoardInfo{}, fmt.Errorf("get board info failed, cardID(%d) and deviceID(%d) , error code: %d",
-			cardID, deviceID, int32(retCode))
+		return common.BoardInfo{}, fmt.Errorf("get board info failed, cardID(%d) and deviceID(%d) , error code: %w",
+			cardID, deviceID, common.DriverError(retCode))
 	}
 
 	return common.BoardInfo{
@@ -1496,3 +1322,34 @@ func (d *DcManager) DcGetDeviceBoardInfo(cardID, deviceID int32) (common.BoardIn
 		SlotId:  uint32(cBoardInfo.slot_id),
 	}, nil
 }
+
+// DcGetHccsLaneInfo return the bandwidth and statistics of the HCCS lanes of device
+func (d *DcManager) DcGetHccsLaneInfo(cardID, deviceID int32) (common.HccsLaneInfo, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.HccsLaneInfo{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+
+	var cStatisticInfo C.struct_dcmi_hccs_statistic_info
+	if retCode := C.dcmi_get_hccs_statistic_info(C.int(cardID), C.int(deviceID),
+		&cStatisticInfo); int32(retCode) != common.Success {
+		return common.HccsLaneInfo{}, fmt.Errorf("get hccs statistic info failed, cardID(%d) and deviceID(%d) , "+
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
+	}
+	cBandwidthInfo := C.struct_dcmi_hccs_bandwidth_info{profiling_time: C.int(common.HccsProfilingTime)}
+	if retCode := C.dcmi_get_hccs_link_bandwidth_info(C.int(cardID), C.int(deviceID),
+		&cBandwidthInfo); int32(retCode) != common.Success {
+		return common.HccsLaneInfo{}, fmt.Errorf("get hccs bandwidth info failed, cardID(%d) and deviceID(%d) , "+
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
+	}
+
+	laneInfo := common.HccsLaneInfo{}
+	for i := 0; i < common.HccsMaxPcsNum && i < C.HCCS_MAX_PCS_NUM; i++ {
+		laneInfo.TxBandwidth[i] = float64(cBandwidthInfo.tx_bandwidth[i])
+		laneInfo.RxBandwidth[i] = float64(cBandwidthInfo.rx_bandwidth[i])
+		laneInfo.TxCnt[i] = uint64(cStatisticInfo.tx_cnt[i])
+		laneInfo.RxCnt[i] = uint64(cStatisticInfo.rx_cnt[i])
+		laneInfo.CrcErrCnt[i] = uint64(cStatisticInfo.crc_err_cnt[i])
+		laneInfo.RetryCnt[i] = uint64(cStatisticInfo.retry_cnt[i])
+	}
+	return laneInfo, nil
+}
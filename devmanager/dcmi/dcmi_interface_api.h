@@ -26,6 +26,7 @@ extern "C" {
 #define MAX_CHIP_NAME_LEN 32  // Maximum length of chip name
 #define TEMPLATE_NAME_LEN 32
 #define DIE_ID_COUNT 5  // Number of die ID characters
+#define HCCS_MAX_PCS_NUM 16  // Maximum number of HCCS lanes of a chip
 
 /*----------------------------------------------*
  * Structure description                        *
@@ -332,6 +333,27 @@ struct dcmi_board_info {
     unsigned int slot_id; // slot_id indicates pcie slot ID of the chip
 };
 
+// the HCCS structures and interfaces are those of the dcmi_interface_api.h shipped with the driver which supports
+// dcmi_get_hccs_statistic_info and dcmi_get_hccs_link_bandwidth_info, the lanes are the pcs of the chip, dcmi does not
+// tell which chip a lane is wired to
+struct dcmi_hccs_statistic_info {
+    unsigned int tx_cnt[HCCS_MAX_PCS_NUM];
+    unsigned int rx_cnt[HCCS_MAX_PCS_NUM];
+    unsigned int crc_err_cnt[HCCS_MAX_PCS_NUM];
+    unsigned int retry_cnt[HCCS_MAX_PCS_NUM];
+    unsigned int reserved_field_cnt[64];
+};
+
+struct dcmi_hccs_bandwidth_info {
+    // input, the sampling window in ms
+    int profiling_time;
+    // unit is MB/s
+    double total_txbw;
+    double total_rxbw;
+    double tx_bandwidth[HCCS_MAX_PCS_NUM];
+    double rx_bandwidth[HCCS_MAX_PCS_NUM];
+};
+
 #define DCMI_VERSION_1
 #define DCMI_VERSION_2
 
@@ -414,6 +436,12 @@ DCMIDLLEXPORT int dcmi_get_device_resource_info (int card_id, int device_id, str
 
 DCMIDLLEXPORT int dcmi_get_device_board_info (int card_id, int device_id, struct dcmi_board_info *board_info);
 
+DCMIDLLEXPORT int dcmi_get_hccs_statistic_info(int card_id, int device_id,
+    struct dcmi_hccs_statistic_info *hccs_statistic_info);
+
+DCMIDLLEXPORT int dcmi_get_hccs_link_bandwidth_info(int card_id, int device_id,
+    struct dcmi_hccs_bandwidth_info *hccs_bandwidth_info);
+
 
 #endif
 
@@ -0,0 +1,1018 @@
+//go:build purego
+
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package dcmi this for dcmi manager, which loads libdcmi by purego without cgo
+package dcmi
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/common-utils/utils"
+
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+const (
+	// functionNotFound the return code of the function which is not exported by libdcmi, same as the cgo driver
+	functionNotFound = -99998
+	// inbandChannel the in-band reset channel of dcmi_set_device_reset, which is INBAND_CHANNEL
+	inbandChannel = 1
+	// portTypeRoce the port type of dcmi_get_device_ip, which is DCMI_ROCE_PORT
+	portTypeRoce = 1
+)
+
+// the functions of libdcmi, a function returns functionNotFound when its symbol is not exported by the library
+var (
+	dcmiInit                         func() int32
+	dcmiGetCardList                  func(cardNum *int32, cardList *int32, listLen int32) int32
+	dcmiGetDeviceNumInCard           func(card int32, deviceNum *int32) int32
+	dcmiGetDeviceLogicID             func(logicID *int32, card, dev int32) int32
+	dcmiCreateVDevice                func(card, dev int32, vDev *dcmiCreateVDevResStru, out *dcmiCreateVDevOut) int32
+	dcmiGetDeviceInfo                func(card, dev int32, main, sub uint32, buf unsafe.Pointer, size *uint32) int32
+	dcmiSetDestroyVDevice            func(card, dev int32, vDevID uint32) int32
+	dcmiGetDeviceType                func(card, dev int32, unitType *int32) int32
+	dcmiGetDeviceHealth              func(card, dev int32, health *uint32) int32
+	dcmiGetDeviceUtilizationRate     func(card, dev, inputType int32, rate *uint32) int32
+	dcmiGetDeviceTemperature         func(card, dev int32, temp *int32) int32
+	dcmiGetDeviceVoltage             func(card, dev int32, vol *uint32) int32
+	dcmiGetDevicePowerInfo           func(card, dev int32, power *int32) int32
+	dcmiGetDeviceFrequency           func(card, dev, freqType int32, freq *uint32) int32
+	dcmiGetDeviceMemoryInfoV3        func(card, dev int32, info *dcmiGetMemoryInfoStru) int32
+	dcmiGetDeviceHbmInfo             func(card, dev int32, info *dcmiHbmInfo) int32
+	dcmiGetDeviceErrorCodeV2         func(card, dev int32, errCount *int32, errCodes *uint32, listLen uint32) int32
+	dcmiGetDeviceChipInfo            func(card, dev int32, info *dcmiChipInfo) int32
+	dcmiGetDevicePhyIDFromLogicID    func(logicID uint32, phyID *uint32) int32
+	dcmiGetDeviceLogicIDFromPhyID    func(phyID uint32, logicID *uint32) int32
+	dcmiGetDeviceIP                  func(card, dev, portType, portID int32, ip, mask *dcmiIPAddr) int32
+	dcmiGetDeviceNetworkHealth       func(card, dev int32, result *int32) int32
+	dcmiGetCardIDDeviceIDFromLogicID func(card, dev *int32, logicID uint32) int32
+	dcmiMcuGetPowerInfo              func(card int32, power *int32) int32
+	dcmiGetProductType               func(card, dev int32, productType *byte, bufSize int32) int32
+	dcmiSetDeviceReset               func(card, dev, channelType int32) int32
+	dcmiGetDeviceBootStatus          func(card, dev int32, bootStatus *int32) int32
+	dcmiGetNpuWorkMode               func(card int32, workMode *uint8) int32
+	dcmiGetDeviceDieV2               func(card, dev, dieType int32, dieID *dcmiDieID) int32
+	dcmiGetDeviceResourceInfo        func(card, dev int32, procList *dcmiProcMemInfo, procNum *int32) int32
+	dcmiGetDevicePcieInfoV2          func(card, dev int32, info *dcmiPcieInfoAll) int32
+	dcmiGetDeviceBoardInfo           func(card, dev int32, info *dcmiBoardInfo) int32
+	dcmiGetHccsStatisticInfo         func(card, dev int32, info *dcmiHccsStatisticInfo) int32
+	dcmiGetHccsLinkBandwidthInfo     func(card, dev int32, info *dcmiHccsBandwidthInfo) int32
+	dcmiSubscribeFaultEvent          func(card, dev int32, filter dcmiEventFilter, handler uintptr) int32
+)
+
+var (
+	dcmiHandle uintptr
+	// faultEventHandler the c function pointer of eventHandler, which is created once because the callbacks
+	// created by purego are never released
+	faultEventHandler     uintptr
+	faultEventHandlerOnce sync.Once
+)
+
+// dcmiSymbols the function pointers and the symbols which are loaded into them
+var dcmiSymbols = []struct {
+	fptr interface{}
+	name string
+}{
+	{&dcmiInit, "dcmi_init"},
+	{&dcmiGetCardList, "dcmi_get_card_list"},
+	{&dcmiGetDeviceNumInCard, "dcmi_get_device_num_in_card"},
+	{&dcmiGetDeviceLogicID, "dcmi_get_device_logic_id"},
+	{&dcmiCreateVDevice, "dcmi_create_vdevice"},
+	{&dcmiGetDeviceInfo, "dcmi_get_device_info"},
+	{&dcmiSetDestroyVDevice, "dcmi_set_destroy_vdevice"},
+	{&dcmiGetDeviceType, "dcmi_get_device_type"},
+	{&dcmiGetDeviceHealth, "dcmi_get_device_health"},
+	{&dcmiGetDeviceUtilizationRate, "dcmi_get_device_utilization_rate"},
+	{&dcmiGetDeviceTemperature, "dcmi_get_device_temperature"},
+	{&dcmiGetDeviceVoltage, "dcmi_get_device_voltage"},
+	{&dcmiGetDevicePowerInfo, "dcmi_get_device_power_info"},
+	{&dcmiGetDeviceFrequency, "dcmi_get_device_frequency"},
+	{&dcmiGetDeviceMemoryInfoV3, "dcmi_get_device_memory_info_v3"},
+	{&dcmiGetDeviceHbmInfo, "dcmi_get_device_hbm_info"},
+	{&dcmiGetDeviceErrorCodeV2, "dcmi_get_device_errorcode_v2"},
+	{&dcmiGetDeviceChipInfo, "dcmi_get_device_chip_info"},
+	{&dcmiGetDevicePhyIDFromLogicID, "dcmi_get_device_phyid_from_logicid"},
+	{&dcmiGetDeviceLogicIDFromPhyID, "dcmi_get_device_logicid_from_phyid"},
+	{&dcmiGetDeviceIP, "dcmi_get_device_ip"},
+	{&dcmiGetDeviceNetworkHealth, "dcmi_get_device_network_health"},
+	{&dcmiGetCardIDDeviceIDFromLogicID, "dcmi_get_card_id_device_id_from_logicid"},
+	{&dcmiMcuGetPowerInfo, "dcmi_mcu_get_power_info"},
+	{&dcmiGetProductType, "dcmi_get_product_type"},
+	{&dcmiSetDeviceReset, "dcmi_set_device_reset"},
+	{&dcmiGetDeviceBootStatus, "dcmi_get_device_boot_status"},
+	{&dcmiGetNpuWorkMode, "dcmi_get_npu_work_mode"},
+	{&dcmiGetDeviceDieV2, "dcmi_get_device_die_v2"},
+	{&dcmiGetDeviceResourceInfo, "dcmi_get_device_resource_info"},
+	{&dcmiGetDevicePcieInfoV2, "dcmi_get_device_pcie_info_v2"},
+	{&dcmiGetDeviceBoardInfo, "dcmi_get_device_board_info"},
+	{&dcmiGetHccsStatisticInfo, "dcmi_get_hccs_statistic_info"},
+	{&dcmiGetHccsLinkBandwidthInfo, "dcmi_get_hccs_link_bandwidth_info"},
+	{&dcmiSubscribeFaultEvent, "dcmi_subscribe_fault_event"},
+}
+
+func init() {
+	unloadSymbols()
+}
+
+// unloadSymbols points all the functions to the stub which returns functionNotFound
+func unloadSymbols() {
+	for _, symbol := range dcmiSymbols {
+		fn := reflect.ValueOf(symbol.fptr).Elem()
+		fn.Set(reflect.MakeFunc(fn.Type(), func([]reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(int32(functionNotFound))}
+		}))
+	}
+}
+
+// loadSymbols loads the functions exported by libdcmi, the others are kept as the stub
+func loadSymbols(handle uintptr) {
+	for _, symbol := range dcmiSymbols {
+		addr, err := purego.Dlsym(handle, symbol.name)
+		if err != nil {
+			hwlog.RunLog.Debugf("symbol %s is not found in %s", symbol.name, dcmiLibraryName)
+			continue
+		}
+		purego.RegisterFunc(symbol.fptr, addr)
+	}
+}
+
+// DcInit load symbol and initialize dcmi
+func (d *DcManager) DcInit() error {
+	dcmiLibPath, err := utils.GetDriverLibPath(dcmiLibraryName)
+	if err != nil {
+		return err
+	}
+	handle, err := purego.Dlopen(dcmiLibPath, purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+	if err != nil {
+		return fmt.Errorf("dcmi lib load failed, error: %v", err)
+	}
+	dcmiHandle = handle
+	loadSymbols(handle)
+	if retCode := dcmiInit(); retCode != common.Success {
+		return fmt.Errorf("dcmi init failed, error code: %w", common.DriverError(retCode))
+	}
+	return nil
+}
+
+// DcShutDown clean the dynamically loaded resource
+func (d *DcManager) DcShutDown() error {
+	if dcmiHandle == 0 {
+		return nil
+	}
+	unloadSymbols()
+	if err := purego.Dlclose(dcmiHandle); err != nil {
+		return fmt.Errorf("dcmi shut down failed, error: %v", err)
+	}
+	dcmiHandle = 0
+	return nil
+}
+
+// DcGetCardList get card list
+func (d *DcManager) DcGetCardList() (int32, []int32, error) {
+	var ids [common.HiAIMaxCardNum]int32
+	var cNum int32
+	if retCode := dcmiGetCardList(&cNum, &ids[0], common.HiAIMaxCardNum); retCode != common.Success {
+		return common.RetError, nil, fmt.Errorf("get card list failed, error code: %w", common.DriverError(retCode))
+	}
+	// checking card's quantity
+	if cNum <= 0 || cNum > common.HiAIMaxCardNum {
+		return common.RetError, nil, common.InvalidErrorf("get error card quantity: %d", cNum)
+	}
+	var cardIDList []int32
+	for i := int32(0); i < cNum; i++ {
+		cardID := ids[i]
+		if cardID < 0 {
+			hwlog.RunLog.Errorf("get invalid card ID: %d", cardID)
+			continue
+		}
+		cardIDList = append(cardIDList, cardID)
+	}
+	return cNum, cardIDList, nil
+}
+
+// DcGetDeviceNumInCard get device number in the npu card
+func (d *DcManager) DcGetDeviceNumInCard(cardID int32) (int32, error) {
+	if !common.IsValidCardID(cardID) {
+		return common.RetError, common.InvalidErrorf("cardID(%d) is invalid", cardID)
+	}
+	var deviceNum int32
+	if retCode := dcmiGetDeviceNumInCard(cardID, &deviceNum); retCode != common.Success {
+		return common.RetError, fmt.Errorf("get device count on the card failed, error code: %w",
+			common.DriverError(retCode))
+	}
+	if !common.IsValidDevNumInCard(deviceNum) {
+		return common.RetError, common.InvalidErrorf("get error device quantity: %d", deviceNum)
+	}
+	return deviceNum, nil
+}
+
+// DcGetDeviceLogicID get device logicID
+func (d *DcManager) DcGetDeviceLogicID(cardID, deviceID int32) (int32, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var logicID int32
+	if retCode := dcmiGetDeviceLogicID(&logicID, cardID, deviceID); retCode != common.Success {
+		return common.RetError, fmt.Errorf("failed to get logicID by cardID(%d) and deviceID(%d), error code: %w",
+			cardID, deviceID, common.DriverError(retCode))
+	}
+
+	// check whether logicID is invalid
+	if !common.IsValidLogicIDOrPhyID(logicID) {
+		return common.RetError, common.InvalidErrorf("get invalid logicID: %d", logicID)
+	}
+	return logicID, nil
+}
+
+// DcSetDestroyVirtualDevice destroy virtual device
+func (d *DcManager) DcSetDestroyVirtualDevice(cardID, deviceID int32, vDevID uint32) error {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	if retCode := dcmiSetDestroyVDevice(cardID, deviceID, vDevID); retCode != common.Success {
+		return fmt.Errorf("destroy virtual device failed, error code: %w", common.DriverError(retCode))
+	}
+	return nil
+}
+
+func convertCreateVDevOut(createVDevOut dcmiCreateVDevOut) common.CgoCreateVDevOut {
+	return common.CgoCreateVDevOut{
+		VDevID:     createVDevOut.VDevID,
+		PcieBus:    createVDevOut.PcieBus,
+		PcieDevice: createVDevOut.PcieDevice,
+		PcieFunc:   createVDevOut.PcieFunc,
+		VfgID:      createVDevOut.VfgID,
+	}
+}
+
+// DcCreateVirtualDevice create virtual device
+func (d *DcManager) DcCreateVirtualDevice(cardID, deviceID int32, vDevInfo common.CgoCreateVDevRes) (common.
+	CgoCreateVDevOut, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.CgoCreateVDevOut{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
+	}
+	if len(vDevInfo.TemplateName) > templateNameLen {
+		return common.CgoCreateVDevOut{}, common.InvalidErrorf("the length of template name exceeds the upper limit")
+	}
+	deviceCreateStr := dcmiCreateVDevResStru{
+		VDevID: vDevInfo.VDevID,
+		VfgID:  vDevInfo.VfgID,
+	}
+	copy(deviceCreateStr.TemplateName[:], vDevInfo.TemplateName)
+
+	var createVDevOut dcmiCreateVDevOut
+	if retCode := dcmiCreateVDevice(cardID, deviceID, &deviceCreateStr, &createVDevOut); retCode != common.Success {
+		return common.CgoCreateVDevOut{}, fmt.Errorf("create vdevice failed, error is: %w", common.DriverError(retCode))
+	}
+
+	return convertCreateVDevOut(createVDevOut), nil
+}
+
+// convertToString converts the char array which ends with 0 to string
+func convertToString(charArr []byte) string {
+	if end := bytes.IndexByte(charArr, 0); end >= 0 {
+		charArr = charArr[:end]
+	}
+	return string(charArr)
+}
+
+func convertBaseResource(baseResource dcmiBaseResource) common.CgoBaseResource {
+	return common.CgoBaseResource{
+		Token:       baseResource.Token,
+		TokenMax:    baseResource.TokenMax,
+		TaskTimeout: baseResource.TaskTimeout,
+		VfgID:       baseResource.VfgID,
+		VipMode:     baseResource.VipMode,
+	}
+}
+
+func convertComputingResource(computingResource dcmiComputingResource) common.CgoComputingResource {
+	return common.CgoComputingResource{
+		Aic:                computingResource.Aic,
+		Aiv:                computingResource.Aiv,
+		Dsa:                computingResource.Dsa,
+		Rtsq:               computingResource.Rtsq,
+		Acsq:               computingResource.Acsq,
+		Cdqm:               computingResource.Cdqm,
+		CCore:              computingResource.CCore,
+		Ffts:               computingResource.Ffts,
+		Sdma:               computingResource.Sdma,
+		PcieDma:            computingResource.PcieDma,
+		MemorySize:         computingResource.MemorySize,
+		EventID:            computingResource.EventID,
+		NotifyID:           computingResource.NotifyID,
+		StreamID:           computingResource.StreamID,
+		ModelID:            computingResource.ModelID,
+		TopicScheduleAicpu: computingResource.TopicScheduleAicpu,
+		HostCtrlCPU:        computingResource.HostCtrlCPU,
+		HostAicpu:          computingResource.HostAicpu,
+		DeviceAicpu:        computingResource.DeviceAicpu,
+		TopicCtrlCPUSlot:   computingResource.TopicCtrlCPUSlot,
+	}
+}
+
+func convertMediaResource(mediaResource dcmiMediaResource) common.CgoMediaResource {
+	return common.CgoMediaResource{
+		Jpegd: mediaResource.Jpegd,
+		Jpege: mediaResource.Jpege,
+		Vpc:   mediaResource.Vpc,
+		Vdec:  mediaResource.Vdec,
+		Pngd:  mediaResource.Pngd,
+		Venc:  mediaResource.Venc,
+	}
+}
+
+func convertVDevQueryInfo(vDevQueryInfo dcmiVDevQueryInfo) common.CgoVDevQueryInfo {
+	return common.CgoVDevQueryInfo{
+		Name:            convertToString(vDevQueryInfo.Name[:]),
+		Status:          vDevQueryInfo.Status,
+		IsContainerUsed: vDevQueryInfo.IsContainerUsed,
+		Vfid:            vDevQueryInfo.Vfid,
+		VfgID:           vDevQueryInfo.VfgID,
+		ContainerID:     vDevQueryInfo.ContainerID,
+		Base:            convertBaseResource(vDevQueryInfo.Base),
+		Computing:       convertComputingResource(vDevQueryInfo.Computing),
+		Media:           convertMediaResource(vDevQueryInfo.Media),
+	}
+}
+
+// DcGetDeviceVDevResource get virtual device resource info
+func (d *DcManager) DcGetDeviceVDevResource(cardID, deviceID int32, vDevID uint32) (common.CgoVDevQueryStru, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.CgoVDevQueryStru{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
+	}
+	vDevResource := dcmiVDevQueryStru{VDevID: vDevID}
+	size := uint32(unsafe.Sizeof(vDevResource))
+	if retCode := dcmiGetDeviceInfo(cardID, deviceID, uint32(MainCmdVDevMng), uint32(VmngSubCmdGetVDevResource),
+		unsafe.Pointer(&vDevResource), &size); retCode != common.Success {
+		return common.CgoVDevQueryStru{}, fmt.Errorf("get device info failed, error is: %w",
+			common.DriverError(retCode))
+	}
+	return common.CgoVDevQueryStru{
+		VDevID:    vDevResource.VDevID,
+		QueryInfo: convertVDevQueryInfo(vDevResource.QueryInfo),
+	}, nil
+}
+
+func convertSocTotalResource(socTotalResource dcmiSocTotalResource) common.CgoSocTotalResource {
+	totalResource := common.CgoSocTotalResource{
+		VDevNum:   socTotalResource.VDevNum,
+		VfgNum:    socTotalResource.VfgNum,
+		VfgBitmap: socTotalResource.VfgBitmap,
+		Base:      convertBaseResource(socTotalResource.Base),
+		Computing: convertComputingResource(socTotalResource.Computing),
+		Media:     convertMediaResource(socTotalResource.Media),
+	}
+	for i := uint32(0); i < socTotalResource.VDevNum && i < dcmiMaxVdevNum; i++ {
+		totalResource.VDevID = append(totalResource.VDevID, socTotalResource.VDevID[i])
+	}
+	return totalResource
+}
+
+// DcGetDeviceTotalResource get device total resource info
+func (d *DcManager) DcGetDeviceTotalResource(cardID, deviceID int32) (common.CgoSocTotalResource, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.CgoSocTotalResource{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
+	}
+	var totalResource dcmiSocTotalResource
+	size := uint32(unsafe.Sizeof(totalResource))
+	if retCode := dcmiGetDeviceInfo(cardID, deviceID, uint32(MainCmdVDevMng), uint32(VmngSubCmdGetTotalResource),
+		unsafe.Pointer(&totalResource), &size); retCode != common.Success {
+		return common.CgoSocTotalResource{}, fmt.Errorf("get device info failed, error is: %w",
+			common.DriverError(retCode))
+	}
+	if totalResource.VDevNum > dcmiMaxVdevNum {
+		return common.CgoSocTotalResource{}, common.InvalidErrorf("get error virtual quantity: %d",
+			totalResource.VDevNum)
+	}
+
+	return convertSocTotalResource(totalResource), nil
+}
+
+// DcGetDeviceFreeResource get device free resource info
+func (d *DcManager) DcGetDeviceFreeResource(cardID, deviceID int32) (common.CgoSocFreeResource, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.CgoSocFreeResource{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
+	}
+	var freeResource dcmiSocFreeResource
+	size := uint32(unsafe.Sizeof(freeResource))
+	if retCode := dcmiGetDeviceInfo(cardID, deviceID, uint32(MainCmdVDevMng), uint32(VmngSubCmdGetFreeResource),
+		unsafe.Pointer(&freeResource), &size); retCode != common.Success {
+		return common.CgoSocFreeResource{}, fmt.Errorf("get device info failed, error is: %w",
+			common.DriverError(retCode))
+	}
+	return common.CgoSocFreeResource{
+		VfgNum:    freeResource.VfgNum,
+		VfgBitmap: freeResource.VfgBitmap,
+		Base:      convertBaseResource(freeResource.Base),
+		Computing: convertComputingResource(freeResource.Computing),
+		Media:     convertMediaResource(freeResource.Media),
+	}, nil
+}
+
+// DcGetVDevActivityInfo get vir device activity info by virtual device id
+func (d *DcManager) DcGetVDevActivityInfo(cardID, deviceID int32, vDevID uint32) (common.VDevActivityInfo, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.VDevActivityInfo{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
+	}
+	if !common.IsValidVDevID(vDevID) {
+		return common.VDevActivityInfo{}, common.InvalidErrorf("vDevID(%d) invalid", vDevID)
+	}
+	vDevActivityInfo := dcmiVDevQueryStru{VDevID: vDevID}
+	size := uint32(unsafe.Sizeof(vDevActivityInfo))
+	if retCode := dcmiGetDeviceInfo(cardID, deviceID, uint32(MainCmdVDevMng), uint32(VmngSubCmdGetVDevActivity),
+		unsafe.Pointer(&vDevActivityInfo), &size); retCode != common.Success {
+		return common.VDevActivityInfo{}, fmt.Errorf("retCode: %w", common.DriverError(retCode))
+	}
+	computing := vDevActivityInfo.QueryInfo.Computing
+	if computing.VDevMemoryTotal < computing.VDevMemoryFree {
+		return common.VDevActivityInfo{}, common.InvalidErrorf("used memory value abnormal")
+	}
+	return common.VDevActivityInfo{
+		VDevID:         vDevID,
+		VDevAiCoreRate: computing.VDevAicoreUtilization,
+		VDevTotalMem:   computing.VDevMemoryTotal,
+		VDevUsedMem:    computing.VDevMemoryTotal - computing.VDevMemoryFree,
+		IsVirtualDev:   true,
+	}, nil
+}
+
+func (d *DcManager) getDeviceType(cardID, deviceID int32) (int32, error) {
+	var unitType int32
+	if retCode := dcmiGetDeviceType(cardID, deviceID, &unitType); retCode != 0 {
+		return common.RetError, fmt.Errorf("get device type failed, error is: %w", common.DriverError(retCode))
+	}
+	return unitType, nil
+}
+
+// DcGetCardIDDeviceID get card id and device id from logic id
+func (d *DcManager) DcGetCardIDDeviceID(logicID int32) (int32, int32, error) {
+	if !common.IsValidLogicIDOrPhyID(logicID) {
+		return common.RetError, common.RetError, common.InvalidErrorf("input invalid logicID: %d", logicID)
+	}
+	var cardID, deviceID int32
+	if retCode := dcmiGetCardIDDeviceIDFromLogicID(&cardID, &deviceID, uint32(logicID)); retCode != common.Success {
+		return common.RetError, common.RetError,
+			fmt.Errorf("failed to get card id and device id by logicID(%d), errorcode is: %w",
+				logicID, common.DriverError(retCode))
+	}
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.RetError, common.RetError, fmt.Errorf("failed to get card id and device id, "+
+			"cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+
+	return cardID, deviceID, nil
+}
+
+// DcGetDeviceVoltage the accuracy is 0.01v.
+func (d *DcManager) DcGetDeviceVoltage(cardID, deviceID int32) (float32, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var vol uint32
+	if retCode := dcmiGetDeviceVoltage(cardID, deviceID, &vol); retCode != common.Success {
+		return common.RetError, fmt.Errorf("failed to obtain the voltage based on card_id(%d) and device_id(%d), "+
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
+	}
+	// the voltage's value is error if it's greater than or equal to MaxInt32
+	if common.IsGreaterThanOrEqualInt32(int64(vol)) {
+		return common.RetError, common.InvalidErrorf("voltage value out of range(max is int32), "+
+			"card_id(%d) and device_id(%d), voltage: %d", cardID, deviceID, int64(vol))
+	}
+
+	return float32(vol) * common.ReduceOnePercent, nil
+}
+
+// DcGetDevicePowerInfo the accuracy is 0.1w, the result like: 8.2
+func (d *DcManager) DcGetDevicePowerInfo(cardID, deviceID int32) (float32, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var power int32
+	if retCode := dcmiGetDevicePowerInfo(cardID, deviceID, &power); retCode != common.Success {
+		return common.RetError, fmt.Errorf("failed to obtain the power based on card_id(%d) and device_id(%d), "+
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
+	}
+	parsedPower := float32(power)
+	if parsedPower < 0 {
+		return common.RetError, common.InvalidErrorf("get wrong device power, card_id(%d) and device_id(%d), power: %f",
+			cardID, deviceID, parsedPower)
+	}
+
+	return parsedPower * common.ReduceTenth, nil
+}
+
+// DcGetDeviceFrequency get device frequency, unit MHz, more information see the cgo driver
+func (d *DcManager) DcGetDeviceFrequency(cardID, deviceID int32, devType common.DeviceType) (uint32, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.InvalidVal, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var frequency uint32
+	if retCode := dcmiGetDeviceFrequency(cardID, deviceID, int32(devType), &frequency); retCode != common.Success {
+		return common.InvalidVal, fmt.Errorf("failed to obtain the frequency based on card_id(%d) and device_id(%d), "+
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
+	}
+	// check whether frequency is too big
+	if common.IsGreaterThanOrEqualInt32(int64(frequency)) {
+		return common.InvalidVal, common.InvalidErrorf("frequency value out of range [0, int32), "+
+			"card_id(%d) and device_id(%d), frequency: %d", cardID, deviceID, int64(frequency))
+	}
+	return frequency, nil
+}
+
+// DcGetMemoryInfo use v3 interface to query memory info
+func (d *DcManager) DcGetMemoryInfo(cardID, deviceID int32) (*common.MemoryInfo, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var mInfoV3 dcmiGetMemoryInfoStru
+	if retCode := dcmiGetDeviceMemoryInfoV3(cardID, deviceID, &mInfoV3); retCode != common.Success {
+		return nil, fmt.Errorf("failed to obtain the memory info by v3 interface based on card_id("+
+			"%d) and device_id(%d), error code: %w", cardID, deviceID, common.DriverError(retCode))
+	}
+
+	if mInfoV3.MemorySize < mInfoV3.MemoryAvailable {
+		return nil, fmt.Errorf("failed to obtain the memory info by v3 interface based on card_id("+
+			"%d) and device_id(%d), total memory is less than available memory", cardID, deviceID)
+	}
+
+	return &common.MemoryInfo{
+		MemorySize:      mInfoV3.MemorySize,
+		MemoryAvailable: mInfoV3.MemoryAvailable,
+		Frequency:       mInfoV3.Freq,
+		Utilization:     mInfoV3.Utiliza,
+	}, nil
+}
+
+// FuncDcmiGetDeviceHbmInfo dcmi_get_device_hbm_info function for outer invoke, only for Ascend910
+func FuncDcmiGetDeviceHbmInfo(cardID, deviceID int32) (*common.HbmInfo, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var hbmInfo dcmiHbmInfo
+	if retCode := dcmiGetDeviceHbmInfo(cardID, deviceID, &hbmInfo); retCode != common.Success {
+		return nil, fmt.Errorf("failed to obtain the hbm info based on card_id(%d) and device_id(%d), "+
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
+	}
+	if hbmInfo.Temp < 0 {
+		return nil, common.InvalidErrorf("get wrong device HBM temporary, card_id(%d) and device_id(%d), HBM.temp: %d",
+			cardID, deviceID, hbmInfo.Temp)
+	}
+	return &common.HbmInfo{
+		MemorySize:        hbmInfo.MemorySize,
+		Frequency:         hbmInfo.Freq,
+		Usage:             hbmInfo.MemoryUsage,
+		Temp:              hbmInfo.Temp,
+		BandWidthUtilRate: hbmInfo.BandwithUtilRate}, nil
+}
+
+// getDeviceErrorCodes get the error count and the error code array of the device
+func getDeviceErrorCodes(cardID, deviceID int32) (int32, [common.MaxErrorCodeCount]uint32, error) {
+	var errCount int32
+	var errCodeArray [common.MaxErrorCodeCount]uint32
+	if retCode := dcmiGetDeviceErrorCodeV2(cardID, deviceID, &errCount, &errCodeArray[0],
+		common.MaxErrorCodeCount); retCode != common.Success {
+		return common.RetError, errCodeArray, fmt.Errorf("failed to obtain the device errorcode based on card_id("+
+			"%d) and device_id(%d), error code: %w, error count: %d",
+			cardID, deviceID, common.DriverError(retCode), errCount)
+	}
+	if errCount < 0 || errCount > common.MaxErrorCodeCount {
+		return common.RetError, errCodeArray, common.InvalidErrorf("get wrong errorcode count, "+
+			"card_id(%d) and device_id(%d), errorcode count: %d", cardID, deviceID, errCount)
+	}
+	return errCount, errCodeArray, nil
+}
+
+// DcGetDeviceErrorCode get the error count and errorcode of the device,only return the first errorcode
+func (d *DcManager) DcGetDeviceErrorCode(cardID, deviceID int32) (int32, int64, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.RetError, common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID,
+			deviceID)
+	}
+	errCount, errCodeArray, err := getDeviceErrorCodes(cardID, deviceID)
+	if err != nil {
+		return common.RetError, common.RetError, err
+	}
+	return errCount, int64(errCodeArray[0]), nil
+}
+
+// DcGetDeviceAllErrorCode get the error count and all error codes of the device
+func (d *DcManager) DcGetDeviceAllErrorCode(cardID, deviceID int32) (int32, []int64, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.RetError, nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID,
+			deviceID)
+	}
+	errCount, errCodeArray, err := getDeviceErrorCodes(cardID, deviceID)
+	if err != nil {
+		return common.RetError, nil, err
+	}
+	errCodes := make([]int64, 0, len(errCodeArray))
+	for _, errCode := range errCodeArray {
+		if errCode != 0 {
+			errCodes = append(errCodes, int64(errCode))
+		}
+	}
+	return errCount, errCodes, nil
+}
+
+// DcGetDeviceHealth get device health
+func (d *DcManager) DcGetDeviceHealth(cardID, deviceID int32) (int32, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var health uint32
+	if retCode := dcmiGetDeviceHealth(cardID, deviceID, &health); retCode != common.Success {
+		return common.RetError, fmt.Errorf("get device (cardID: %d, deviceID: %d) health state failed, error "+
+			"code: %w", cardID, deviceID, common.DriverError(retCode))
+	}
+	if common.IsGreaterThanOrEqualInt32(int64(health)) {
+		return common.RetError, common.InvalidErrorf("get wrong health state , device (cardID: %d, deviceID: %d) "+
+			"health: %d", cardID, deviceID, int64(health))
+	}
+	return int32(health), nil
+}
+
+// DcGetDeviceUtilizationRate get device utils rate by id
+func (d *DcManager) DcGetDeviceUtilizationRate(cardID, deviceID int32, devType common.DeviceType) (int32, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var rate uint32
+	if retCode := dcmiGetDeviceUtilizationRate(cardID, deviceID, int32(devType), &rate); retCode != common.Success {
+		return common.RetError, fmt.Errorf("get device (cardID: %d, deviceID: %d) utilization rate: %d failed, "+
+			"error code: %w", cardID, deviceID, rate, common.DriverError(retCode))
+	}
+	if !common.IsValidUtilizationRate(rate) {
+		return common.RetError, common.InvalidErrorf("get wrong device (cardID: %d, deviceID: %d) utilization rate: %d",
+			cardID, deviceID, rate)
+	}
+	return int32(rate), nil
+}
+
+// DcGetDeviceTemperature get the device temperature
+func (d *DcManager) DcGetDeviceTemperature(cardID, deviceID int32) (int32, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.RetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var temp int32
+	if retCode := dcmiGetDeviceTemperature(cardID, deviceID, &temp); retCode != common.Success {
+		return common.RetError, fmt.Errorf("get device (cardID: %d, deviceID: %d) temperature failed, error "+
+			"code is : %w", cardID, deviceID, common.DriverError(retCode))
+	}
+	if temp < int32(common.DefaultTemperatureWhenQueryFailed) {
+		return common.RetError, common.InvalidErrorf("get wrong device temperature, devcie (cardID: %d, "+
+			"deviceID: %d), temperature: %d", cardID, deviceID, temp)
+	}
+	return temp, nil
+}
+
+// DcGetChipInfo get the chip info by cardID and deviceID
+func (d *DcManager) DcGetChipInfo(cardID, deviceID int32) (*common.ChipInfo, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var chipInfo dcmiChipInfo
+	if rCode := dcmiGetDeviceChipInfo(cardID, deviceID, &chipInfo); rCode != common.Success {
+		return nil, fmt.Errorf("get device ChipInfo information failed, cardID(%d), deviceID(%d),"+
+			" error code: %w", cardID, deviceID, common.DriverError(rCode))
+	}
+
+	chip := &common.ChipInfo{
+		Name:    convertToString(chipInfo.ChipName[:]),
+		Type:    convertToString(chipInfo.ChipType[:]),
+		Version: convertToString(chipInfo.ChipVer[:]),
+	}
+	if !common.IsValidChipInfo(chip) {
+		return nil, fmt.Errorf("get device ChipInfo information failed, chip info is empty,"+
+			" cardID(%d), deviceID(%d)", cardID, deviceID)
+	}
+
+	return chip, nil
+}
+
+// DcGetPhysicIDFromLogicID get physicID from logicID
+func (d *DcManager) DcGetPhysicIDFromLogicID(logicID int32) (int32, error) {
+	if !common.IsValidLogicIDOrPhyID(logicID) {
+		return common.RetError, common.InvalidErrorf("logicID(%d) is invalid", logicID)
+	}
+	var physicID uint32
+	if rCode := dcmiGetDevicePhyIDFromLogicID(uint32(logicID), &physicID); rCode != common.Success {
+		return common.RetError, fmt.Errorf("get physic id from logicID(%d) failed, error code: %w",
+			logicID, common.DriverError(rCode))
+	}
+	if !common.IsValidLogicIDOrPhyID(int32(physicID)) {
+		return common.RetError, common.InvalidErrorf("get wrong physicID(%d) from logicID(%d)", physicID, logicID)
+	}
+	return int32(physicID), nil
+}
+
+// DcGetLogicIDFromPhysicID get logicID from physicID
+func (d *DcManager) DcGetLogicIDFromPhysicID(physicID int32) (int32, error) {
+	if !common.IsValidLogicIDOrPhyID(physicID) {
+		return common.RetError, common.InvalidErrorf("physicID(%d) is invalid", physicID)
+	}
+	var logicID uint32
+	if rCode := dcmiGetDeviceLogicIDFromPhyID(uint32(physicID), &logicID); rCode != common.Success {
+		return common.RetError, fmt.Errorf("get logicID from physicID(%d) failed, error code: %w",
+			physicID, common.DriverError(rCode))
+	}
+
+	if !common.IsValidLogicIDOrPhyID(int32(logicID)) {
+		return common.RetError, common.InvalidErrorf("get wrong logicID(%d) from physicID(%d)", logicID, physicID)
+	}
+	return int32(logicID), nil
+}
+
+// DcGetDeviceIPAddress get device IP address by cardID and deviceID
+func (d *DcManager) DcGetDeviceIPAddress(cardID, deviceID, ipType int32) (string, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return "", common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	var ipAddress, maskAddress dcmiIPAddr
+	if ipType == ipAddrTypeV6 {
+		ipAddress.IPType = ipAddrTypeV6
+	}
+	if rCode := dcmiGetDeviceIP(cardID, deviceID, portTypeRoce, 0, &ipAddress, &maskAddress); rCode != common.Success {
+		return "", fmt.Errorf("get device IP address failed, cardID(%d), deviceID(%d), error code: %w",
+			cardID, deviceID, common.DriverError(rCode))
+	}
+	if ipType == ipAddrTypeV6 {
+		return net.IP(ipAddress.UAddr[:net.IPv6len]).String(), nil
+	}
+	if netIP := net.IP(ipAddress.UAddr[:net.IPv4len]).To4(); netIP != nil {
+		return netIP.String(), nil
+	}
+	return "", fmt.Errorf("the device IPv4 address is invalid, value: %v", ipAddress.UAddr[:net.IPv4len])
+}
+
+// DcGetDeviceNetWorkHealth get device network health by cardID and deviceID
+func (d *DcManager) DcGetDeviceNetWorkHealth(cardID, deviceID int32) (uint32, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.UnRetError, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+
+	var healthCode int32
+	if rCode := dcmiGetDeviceNetworkHealth(cardID, deviceID, &healthCode); rCode != common.Success {
+		return common.UnRetError, fmt.Errorf("get device network healthCode failed, cardID(%d),"+
+			" deviceID(%d), error code: %w", cardID, deviceID, common.DriverError(rCode))
+	}
+	if healthCode < 0 || healthCode > int32(math.MaxInt8) {
+		return common.UnRetError, common.InvalidErrorf("get wrong device network healthCode, cardID(%d), deviceID(%d),"+
+			" error healthCode: %d", cardID, deviceID, healthCode)
+	}
+	return uint32(healthCode), nil
+}
+
+// FuncDcmiMcuGetPowerInfo dcmi_mcu_get_power_info function for outer invoke
+func FuncDcmiMcuGetPowerInfo(cardID int32) (float32, error) {
+	var power int32
+	if retCode := dcmiMcuGetPowerInfo(cardID, &power); retCode != common.Success {
+		return common.RetError, fmt.Errorf("mcu_get_power_info failed, error code is:%w", common.DriverError(retCode))
+	}
+	parsedPower := float32(power)
+	if parsedPower < 0 {
+		return common.RetError, common.InvalidErrorf("get wrong mcu_get_power_info, cardID: %d, power: %f", cardID,
+			parsedPower)
+	}
+	return parsedPower * common.ReduceTenth, nil
+}
+
+// DcGetProductType get product type by dcmi interface
+func (d *DcManager) DcGetProductType(cardID, deviceID int32) (string, error) {
+	productType := make([]byte, productTypeLen)
+	if err := dcmiGetProductType(cardID, deviceID, &productType[0], productTypeLen); err != 0 {
+		return "", fmt.Errorf("get product type failed, errCode: %w", common.DriverError(err))
+	}
+	return convertToString(productType), nil
+}
+
+// DcGetNpuWorkMode get npu work mode, this function is only for Ascend910, A310/310P not support
+func (d *DcManager) DcGetNpuWorkMode(cardID int32) (int, error) {
+	var workMode uint8
+	if err := dcmiGetNpuWorkMode(cardID, &workMode); err != 0 {
+		return common.RetError, fmt.Errorf("get npu work mode failed, errCode: %w", common.DriverError(err))
+	}
+	return int(workMode), nil
+}
+
+// DcSetDeviceReset reset spec device chip
+func (d *DcManager) DcSetDeviceReset(cardID, deviceID int32) error {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	if errCode := dcmiSetDeviceReset(cardID, deviceID, inbandChannel); errCode != 0 {
+		return fmt.Errorf("cardID(%d) and deviceID(%d) hot reset errCode: %w", cardID, deviceID,
+			common.DriverError(errCode))
+	}
+	return nil
+}
+
+// DcGetDeviceBootStatus get NPU boot status
+func (d *DcManager) DcGetDeviceBootStatus(logicID int32) (int, error) {
+	if !common.IsValidLogicIDOrPhyID(logicID) {
+		return common.RetError, common.InvalidErrorf("input invalid logicID: %d", logicID)
+	}
+	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		return common.RetError, fmt.Errorf("failed to get cardID and deviceID by logicID(%d), %w", logicID, err)
+	}
+	var bootStatus int32 = common.BootStartFinish
+	if errCode := dcmiGetDeviceBootStatus(cardID, deviceID, &bootStatus); errCode != 0 {
+		return common.RetError, fmt.Errorf("device boot status errCode: %w", common.DriverError(errCode))
+	}
+	return int(bootStatus), nil
+}
+
+// DcSubscribeDeviceFaultEvent subscribe device fault, callback with func 'faultEventCallFunc'
+func (d *DcManager) DcSubscribeDeviceFaultEvent(cardID, deviceID int32) error {
+	if faultEventCallFunc == nil {
+		return common.InvalidErrorf("callFunc is invalid, can't start subscribe")
+	}
+
+	faultEventHandlerOnce.Do(func() {
+		faultEventHandler = purego.NewCallback(eventHandler)
+	})
+	var filter dcmiEventFilter
+	if rCode := dcmiSubscribeFaultEvent(cardID, deviceID, filter, faultEventHandler); rCode != common.Success {
+		return fmt.Errorf("subscribe fault event failed, cardID(%d) and deviceID(%d), error code: %w",
+			cardID, deviceID, common.DriverError(rCode))
+	}
+	return nil
+}
+
+// eventHandler is called by libdcmi in its own thread when a fault event occurs
+func eventHandler(event *dcmiEvent) {
+	if event == nil {
+		return
+	}
+	if faultEventCallFunc == nil {
+		hwlog.RunLog.Errorf("no fault event call back func")
+		return
+	}
+	// recovery event recorded fault event occurrence time, the recovery event time cannot be obtained.
+	// Therefore, all event occurrence time is recorded as the current host time when the event is received.
+	faultEventCallFunc(common.DevFaultInfo{
+		EventID:         int64(event.EventT.EventID),
+		LogicID:         int32(event.EventT.DeviceID),
+		Severity:        int8(event.EventT.Severity),
+		Assertion:       int8(event.EventT.Assertion),
+		AlarmRaisedTime: time.Now().UnixMilli(),
+	})
+}
+
+// DcGetDieID get chip die ID, like VDieID or NDieID, only Ascend910 has NDieID
+func (d *DcManager) DcGetDieID(cardID, deviceID int32, dcmiDieType DcmiDieType) (string, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return "", common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+
+	if dcmiDieType != VDIE && dcmiDieType != NDIE {
+		return "", common.InvalidErrorf("dcmi die type can only be one of %d or %d", VDIE, NDIE)
+	}
+
+	var dieIDObj dcmiDieID
+	if rCode := dcmiGetDeviceDieV2(cardID, deviceID, int32(dcmiDieType), &dieIDObj); rCode != common.Success {
+		return "", fmt.Errorf("get chip die ID faied, cardID(%d) and deviceID(%d), error code: %w",
+			cardID, deviceID, common.DriverError(rCode))
+	}
+
+	const hexBase = 16
+	dieIDStr := make([]string, DieIDCount)
+
+	hwlog.RunLog.Debugf("cardID(%d), deviceID(%d) get die type(%d) value %v", cardID, deviceID, dcmiDieType,
+		dieIDObj.SocDie)
+	for i := 0; i < DieIDCount; i++ {
+		s := strconv.FormatUint(uint64(dieIDObj.SocDie[i]), hexBase)
+		// Each part of the die id consists of 8 characters, and if the length is not enough,
+		//zero is added at the beginning
+		dieIDStr[i] = fmt.Sprintf("%08s", s)
+	}
+	return strings.ToUpper(strings.Join(dieIDStr, "-")), nil
+}
+
+// DcGetDevProcessInfo chip process info
+func (d *DcManager) DcGetDevProcessInfo(cardID, deviceID int32) (*common.DevProcessInfo, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+
+	var procList [common.MaxProcNum]dcmiProcMemInfo
+	var procNum int32
+	if retCode := dcmiGetDeviceResourceInfo(cardID, deviceID, &procList[0], &procNum); retCode != common.Success {
+		return nil, fmt.Errorf("get device resource info failed, cardID(%d) and deviceID(%d) , error code: %w",
+			cardID, deviceID, common.DriverError(retCode))
+	}
+
+	if procNum < 0 || procNum > common.MaxProcNum {
+		return nil, common.InvalidErrorf("get invalid proccess num (%d), cardID(%d) and deviceID(%d)", procNum, cardID,
+			deviceID)
+	}
+
+	info := new(common.DevProcessInfo)
+	info.ProcNum = procNum
+	for i := int32(0); i < procNum; i++ {
+		info.DevProcArray = append(info.DevProcArray, common.DevProcInfo{
+			Pid:      procList[i].ProcID,
+			MemUsage: float64(procList[i].ProcMemUsage) / common.UnitMB, // convert byte to MB
+		})
+	}
+	return info, nil
+}
+
+// DcGetPCIeBusInfo pcie bus info
+func (d *DcManager) DcGetPCIeBusInfo(cardID, deviceID int32) (string, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return "", common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+
+	var pcieInfo dcmiPcieInfoAll
+	if retCode := dcmiGetDevicePcieInfoV2(cardID, deviceID, &pcieInfo); retCode != common.Success {
+		return "", fmt.Errorf("get pcie bus info failed, cardID(%d) and deviceID(%d) , error code: %w",
+			cardID, deviceID, common.DriverError(retCode))
+	}
+
+	info := fmt.Sprintf("%04X:%02X:%02X.%-4X", pcieInfo.Domain, pcieInfo.BdfBusID, pcieInfo.BdfDeviceID,
+		pcieInfo.BdfFuncID)
+	hwlog.RunLog.Debugf("pcie bus info is: '%s'", info)
+
+	return strings.TrimRight(info, " "), nil
+}
+
+// DcGetDeviceBoardInfo return board info of device
+func (d *DcManager) DcGetDeviceBoardInfo(cardID, deviceID int32) (common.BoardInfo, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.BoardInfo{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+
+	var boardInfo dcmiBoardInfo
+	if retCode := dcmiGetDeviceBoardInfo(cardID, deviceID, &boardInfo); retCode != common.Success {
+		return common.BoardInfo{}, fmt.Errorf("get board info failed, cardID(%d) and deviceID(%d) , error code: %w",
+			cardID, deviceID, common.DriverError(retCode))
+	}
+
+	return common.BoardInfo{
+		BoardId: boardInfo.BoardID,
+		PcbId:   boardInfo.PcbID,
+		BomId:   boardInfo.BomID,
+		SlotId:  boardInfo.SlotID,
+	}, nil
+}
+
+// DcGetHccsLaneInfo return the bandwidth and statistics of the HCCS lanes of device
+func (d *DcManager) DcGetHccsLaneInfo(cardID, deviceID int32) (common.HccsLaneInfo, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.HccsLaneInfo{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+
+	var statisticInfo dcmiHccsStatisticInfo
+	if retCode := dcmiGetHccsStatisticInfo(cardID, deviceID, &statisticInfo); retCode != common.Success {
+		return common.HccsLaneInfo{}, fmt.Errorf("get hccs statistic info failed, cardID(%d) and deviceID(%d) , "+
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
+	}
+	bandwidthInfo := dcmiHccsBandwidthInfo{ProfilingTime: common.HccsProfilingTime}
+	if retCode := dcmiGetHccsLinkBandwidthInfo(cardID, deviceID, &bandwidthInfo); retCode != common.Success {
+		return common.HccsLaneInfo{}, fmt.Errorf("get hccs bandwidth info failed, cardID(%d) and deviceID(%d) , "+
+			"error code: %w", cardID, deviceID, common.DriverError(retCode))
+	}
+
+	laneInfo := common.HccsLaneInfo{}
+	for i := 0; i < common.HccsMaxPcsNum && i < hccsMaxPcsNum; i++ {
+		laneInfo.TxBandwidth[i] = bandwidthInfo.TxBandwidth[i]
+		laneInfo.RxBandwidth[i] = bandwidthInfo.RxBandwidth[i]
+		laneInfo.TxCnt[i] = uint64(statisticInfo.TxCnt[i])
+		laneInfo.RxCnt[i] = uint64(statisticInfo.RxCnt[i])
+		laneInfo.CrcErrCnt[i] = uint64(statisticInfo.CrcErrCnt[i])
+		laneInfo.RetryCnt[i] = uint64(statisticInfo.RetryCnt[i])
+	}
+	return laneInfo, nil
+}
@@ -0,0 +1,199 @@
+//go:build cgo
+
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package clayout provides the layout of the c structs of dcmi_interface_api.h, which is used to check the go
+// structs of the purego dcmi driver. The layout is computed by cgo from the header, so it is only for the tests.
+package clayout
+
+/*
+   #include "../../dcmi_interface_api.h"
+*/
+import "C"
+import "unsafe"
+
+// Layout the size of a c struct and the offsets of its fields in the order of declaration
+type Layout struct {
+	Size    uintptr
+	Offsets []uintptr
+}
+
+// Layouts returns the layouts of the c structs keyed by the struct name
+func Layouts() map[string]Layout {
+	layouts := make(map[string]Layout, 23)
+	{
+		var s C.struct_dcmi_chip_info
+		layouts["dcmi_chip_info"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.chip_type), unsafe.Offsetof(s.chip_name), unsafe.Offsetof(s.chip_ver),
+			unsafe.Offsetof(s.aicore_cnt),
+		}}
+	}
+	{
+		var s C.struct_dcmi_pcie_info_all
+		layouts["dcmi_pcie_info_all"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.venderid), unsafe.Offsetof(s.subvenderid), unsafe.Offsetof(s.deviceid),
+			unsafe.Offsetof(s.subdeviceid), unsafe.Offsetof(s.domain), unsafe.Offsetof(s.bdf_busid),
+			unsafe.Offsetof(s.bdf_deviceid), unsafe.Offsetof(s.bdf_funcid), unsafe.Offsetof(s.reserve),
+		}}
+	}
+	{
+		var s C.struct_dcmi_die_id
+		layouts["dcmi_die_id"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.soc_die),
+		}}
+	}
+	{
+		var s C.struct_dcmi_hbm_info
+		layouts["dcmi_hbm_info"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.memory_size), unsafe.Offsetof(s.freq), unsafe.Offsetof(s.memory_usage),
+			unsafe.Offsetof(s.temp), unsafe.Offsetof(s.bandwith_util_rate),
+		}}
+	}
+	{
+		var s C.struct_dcmi_get_memory_info_stru
+		layouts["dcmi_get_memory_info_stru"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.memory_size), unsafe.Offsetof(s.memory_available), unsafe.Offsetof(s.freq),
+			unsafe.Offsetof(s.hugepagesize), unsafe.Offsetof(s.hugepages_total), unsafe.Offsetof(s.hugepages_free),
+			unsafe.Offsetof(s.utiliza), unsafe.Offsetof(s.reserve),
+		}}
+	}
+	{
+		var s C.struct_dcmi_ip_addr
+		layouts["dcmi_ip_addr"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.u_addr), unsafe.Offsetof(s.ip_type),
+		}}
+	}
+	{
+		var s C.struct_dcmi_base_resource
+		layouts["dcmi_base_resource"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.token), unsafe.Offsetof(s.token_max), unsafe.Offsetof(s.task_timeout),
+			unsafe.Offsetof(s.vfg_id), unsafe.Offsetof(s.vip_mode), unsafe.Offsetof(s.reserved),
+		}}
+	}
+	{
+		var s C.struct_dcmi_computing_resource
+		layouts["dcmi_computing_resource"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.aic), unsafe.Offsetof(s.aiv), unsafe.Offsetof(s.dsa), unsafe.Offsetof(s.rtsq),
+			unsafe.Offsetof(s.acsq), unsafe.Offsetof(s.cdqm), unsafe.Offsetof(s.c_core), unsafe.Offsetof(s.ffts),
+			unsafe.Offsetof(s.sdma), unsafe.Offsetof(s.pcie_dma), unsafe.Offsetof(s.memory_size),
+			unsafe.Offsetof(s.event_id), unsafe.Offsetof(s.notify_id), unsafe.Offsetof(s.stream_id),
+			unsafe.Offsetof(s.model_id), unsafe.Offsetof(s.topic_schedule_aicpu), unsafe.Offsetof(s.host_ctrl_cpu),
+			unsafe.Offsetof(s.host_aicpu), unsafe.Offsetof(s.device_aicpu), unsafe.Offsetof(s.topic_ctrl_cpu_slot),
+			unsafe.Offsetof(s.vdev_aicore_utilization), unsafe.Offsetof(s.vdev_memory_total),
+			unsafe.Offsetof(s.vdev_memory_free), unsafe.Offsetof(s.reserved),
+		}}
+	}
+	{
+		var s C.struct_dcmi_media_resource
+		layouts["dcmi_media_resource"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.jpegd), unsafe.Offsetof(s.jpege), unsafe.Offsetof(s.vpc), unsafe.Offsetof(s.vdec),
+			unsafe.Offsetof(s.pngd), unsafe.Offsetof(s.venc), unsafe.Offsetof(s.reserved),
+		}}
+	}
+	{
+		var s C.struct_dcmi_create_vdev_out
+		layouts["dcmi_create_vdev_out"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.vdev_id), unsafe.Offsetof(s.pcie_bus), unsafe.Offsetof(s.pcie_device),
+			unsafe.Offsetof(s.pcie_func), unsafe.Offsetof(s.vfg_id), unsafe.Offsetof(s.reserved),
+		}}
+	}
+	{
+		var s C.struct_dcmi_create_vdev_res_stru
+		layouts["dcmi_create_vdev_res_stru"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.vdev_id), unsafe.Offsetof(s.vfg_id), unsafe.Offsetof(s.template_name),
+			unsafe.Offsetof(s.reserved),
+		}}
+	}
+	{
+		var s C.struct_dcmi_vdev_query_info
+		layouts["dcmi_vdev_query_info"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.name), unsafe.Offsetof(s.status), unsafe.Offsetof(s.is_container_used),
+			unsafe.Offsetof(s.vfid), unsafe.Offsetof(s.vfg_id), unsafe.Offsetof(s.container_id),
+			unsafe.Offsetof(s.base), unsafe.Offsetof(s.computing), unsafe.Offsetof(s.media),
+		}}
+	}
+	{
+		var s C.struct_dcmi_vdev_query_stru
+		layouts["dcmi_vdev_query_stru"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.vdev_id), unsafe.Offsetof(s.query_info),
+		}}
+	}
+	{
+		var s C.struct_dcmi_soc_free_resource
+		layouts["dcmi_soc_free_resource"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.vfg_num), unsafe.Offsetof(s.vfg_bitmap), unsafe.Offsetof(s.base),
+			unsafe.Offsetof(s.computing), unsafe.Offsetof(s.media),
+		}}
+	}
+	{
+		var s C.struct_dcmi_soc_total_resource
+		layouts["dcmi_soc_total_resource"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.vdev_num), unsafe.Offsetof(s.vdev_id), unsafe.Offsetof(s.vfg_num),
+			unsafe.Offsetof(s.vfg_bitmap), unsafe.Offsetof(s.base), unsafe.Offsetof(s.computing),
+			unsafe.Offsetof(s.media),
+		}}
+	}
+	{
+		var s C.struct_dcmi_proc_mem_info
+		layouts["dcmi_proc_mem_info"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.proc_id), unsafe.Offsetof(s.proc_mem_usage),
+		}}
+	}
+	{
+		var s C.struct_dcmi_board_info
+		layouts["dcmi_board_info"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.board_id), unsafe.Offsetof(s.pcb_id), unsafe.Offsetof(s.bom_id),
+			unsafe.Offsetof(s.slot_id),
+		}}
+	}
+	{
+		var s C.struct_dcmi_hccs_statistic_info
+		layouts["dcmi_hccs_statistic_info"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.tx_cnt), unsafe.Offsetof(s.rx_cnt), unsafe.Offsetof(s.crc_err_cnt),
+			unsafe.Offsetof(s.retry_cnt), unsafe.Offsetof(s.reserved_field_cnt),
+		}}
+	}
+	{
+		var s C.struct_dcmi_hccs_bandwidth_info
+		layouts["dcmi_hccs_bandwidth_info"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.profiling_time), unsafe.Offsetof(s.total_txbw), unsafe.Offsetof(s.total_rxbw),
+			unsafe.Offsetof(s.tx_bandwidth), unsafe.Offsetof(s.rx_bandwidth),
+		}}
+	}
+	{
+		var s C.struct_dcmi_dms_fault_event
+		layouts["dcmi_dms_fault_event"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.event_id), unsafe.Offsetof(s.deviceid), unsafe.Offsetof(s.node_type),
+			unsafe.Offsetof(s.node_id), unsafe.Offsetof(s.sub_node_type), unsafe.Offsetof(s.sub_node_id),
+			unsafe.Offsetof(s.severity), unsafe.Offsetof(s.assertion), unsafe.Offsetof(s.event_serial_num),
+			unsafe.Offsetof(s.notify_serial_num), unsafe.Offsetof(s.alarm_raised_time), unsafe.Offsetof(s.event_name),
+			unsafe.Offsetof(s.additional_info), unsafe.Offsetof(s.resv),
+		}}
+	}
+	{
+		var s C.struct_dcmi_event
+		layouts["dcmi_event"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s._type), unsafe.Offsetof(s.event_t),
+		}}
+	}
+	{
+		var s C.struct_dcmi_event_filter
+		layouts["dcmi_event_filter"] = Layout{Size: unsafe.Sizeof(s), Offsets: []uintptr{
+			unsafe.Offsetof(s.filter_flag), unsafe.Offsetof(s.event_id), unsafe.Offsetof(s.severity),
+			unsafe.Offsetof(s.node_type), unsafe.Offsetof(s.resv),
+		}}
+	}
+	return layouts
+}
@@ -0,0 +1,244 @@
+//go:build cgo || purego
+
+/* Copyright(C) 2021-2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package dcmi this for dcmi manager
+package dcmi
+
+import (
+	"fmt"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+// DcDriverInterface interface for dcmi
+type DcDriverInterface interface {
+	DcInit() error
+	DcShutDown() error
+
+	DcGetDeviceCount() (int32, error)
+	DcGetLogicIDList() (int32, []int32, error)
+	DcGetDeviceHealth(int32, int32) (int32, error)
+	DcGetDeviceNetWorkHealth(int32, int32) (uint32, error)
+	DcGetDeviceUtilizationRate(int32, int32, common.DeviceType) (int32, error)
+	DcGetDeviceTemperature(int32, int32) (int32, error)
+	DcGetDeviceVoltage(int32, int32) (float32, error)
+	DcGetDevicePowerInfo(int32, int32) (float32, error)
+	DcGetDeviceFrequency(int32, int32, common.DeviceType) (uint32, error)
+	DcGetMemoryInfo(int32, int32) (*common.MemoryInfo, error)
+	DcGetHbmInfo(int32, int32) (*common.HbmInfo, error)
+	DcGetDeviceErrorCode(int32, int32) (int32, int64, error)
+	DcGetChipInfo(int32, int32) (*common.ChipInfo, error)
+	DcGetPhysicIDFromLogicID(int32) (int32, error)
+	DcGetLogicIDFromPhysicID(int32) (int32, error)
+	DcGetDeviceLogicID(int32, int32) (int32, error)
+	DcGetDeviceIPAddress(int32, int32, int32) (string, error)
+	DcGetMcuPowerInfo(int32) (float32, error)
+	DcGetDieID(int32, int32, DcmiDieType) (string, error)
+	DcGetPCIeBusInfo(int32, int32) (string, error)
+
+	DcGetCardList() (int32, []int32, error)
+	DcGetDeviceNumInCard(int32) (int32, error)
+	DcSetDestroyVirtualDevice(int32, int32, uint32) error
+	DcCreateVirtualDevice(int32, int32, common.CgoCreateVDevRes) (common.CgoCreateVDevOut, error)
+	DcGetDeviceVDevResource(int32, int32, uint32) (common.CgoVDevQueryStru, error)
+	DcGetDeviceTotalResource(int32, int32) (common.CgoSocTotalResource, error)
+	DcGetDeviceFreeResource(int32, int32) (common.CgoSocFreeResource, error)
+	DcGetVDevActivityInfo(int32, int32, uint32) (common.VDevActivityInfo, error)
+	DcVGetDeviceInfo(int32, int32) (common.VirtualDevInfo, error)
+	DcGetCardIDDeviceID(int32) (int32, int32, error)
+	DcCreateVDevice(int32, common.CgoCreateVDevRes) (common.CgoCreateVDevOut, error)
+	DcGetVDeviceInfo(int32) (common.VirtualDevInfo, error)
+	DcDestroyVDevice(int32, uint32) error
+	DcGetProductType(int32, int32) (string, error)
+	DcGetNpuWorkMode(int32) (int, error)
+	DcSetDeviceReset(int32, int32) error
+	DcGetDeviceBootStatus(int32) (int, error)
+
+	DcGetDeviceAllErrorCode(int32, int32) (int32, []int64, error)
+	DcSubscribeDeviceFaultEvent(int32, int32) error
+	DcSetFaultEventCallFunc(func(common.DevFaultInfo))
+	DcGetDevProcessInfo(int32, int32) (*common.DevProcessInfo, error)
+	DcGetDeviceBoardInfo(int32, int32) (common.BoardInfo, error)
+	DcGetHccsLaneInfo(int32, int32) (common.HccsLaneInfo, error)
+}
+
+const (
+	dcmiLibraryName = "libdcmi.so"
+	templateNameLen = 32
+)
+
+var faultEventCallFunc func(common.DevFaultInfo)
+
+// DcManager for manager dcmi interface
+type DcManager struct{}
+
+// DcVGetDeviceInfo get vdevice resource info
+func (d *DcManager) DcVGetDeviceInfo(cardID, deviceID int32) (common.VirtualDevInfo, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return common.VirtualDevInfo{}, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid",
+			cardID, deviceID)
+	}
+	unitType, err := d.getDeviceType(cardID, deviceID)
+	if err != nil {
+		return common.VirtualDevInfo{}, err
+	}
+	if unitType != common.NpuType {
+		return common.VirtualDevInfo{}, fmt.Errorf("not support unit type: %d", unitType)
+	}
+
+	cgoDcmiSocTotalResource, err := d.DcGetDeviceTotalResource(cardID, deviceID)
+	if err != nil {
+		return common.VirtualDevInfo{}, fmt.Errorf("get device total resource failed, error is: %w", err)
+	}
+
+	cgoDcmiSocFreeResource, err := d.DcGetDeviceFreeResource(cardID, deviceID)
+	if err != nil {
+		return common.VirtualDevInfo{}, fmt.Errorf("get device free resource failed, error is: %w", err)
+	}
+	dcmiVDevInfo := common.VirtualDevInfo{
+		TotalResource: cgoDcmiSocTotalResource,
+		FreeResource:  cgoDcmiSocFreeResource,
+	}
+	for _, vDevID := range cgoDcmiSocTotalResource.VDevID {
+		cgoVDevQueryStru, err := d.DcGetDeviceVDevResource(cardID, deviceID, vDevID)
+		if err != nil {
+			return common.VirtualDevInfo{}, fmt.Errorf("get device virtual resource failed, error is: %w", err)
+		}
+		dcmiVDevInfo.VDevInfo = append(dcmiVDevInfo.VDevInfo, cgoVDevQueryStru)
+		vDevActivityInfo, err := d.DcGetVDevActivityInfo(cardID, deviceID, vDevID)
+		if err != nil {
+			// the vNPU is still reported and mapped to its container, but its activity is marked unavailable
+			hwlog.RunLog.Warnf("get cur vDev's activity info failed, err: %s", err)
+			vDevActivityInfo = common.VDevActivityInfo{VDevID: vDevID, IsVirtualDev: true, ActivityUnavailable: true}
+		}
+		vDevActivityInfo.VDevAiCore = float64(cgoVDevQueryStru.QueryInfo.Computing.Aic)
+		dcmiVDevInfo.VDevActivityInfo = append(dcmiVDevInfo.VDevActivityInfo, vDevActivityInfo)
+	}
+	return dcmiVDevInfo, nil
+}
+
+// DcCreateVDevice create virtual device by logic id
+func (d *DcManager) DcCreateVDevice(logicID int32, vDevInfo common.CgoCreateVDevRes) (common.
+	CgoCreateVDevOut, error) {
+	if !common.IsValidLogicIDOrPhyID(logicID) {
+		return common.CgoCreateVDevOut{}, common.InvalidErrorf("input invalid logicID: %d", logicID)
+	}
+	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		return common.CgoCreateVDevOut{}, fmt.Errorf("get card id and device id failed, error is: %w", err)
+	}
+
+	createVDevOut, err := d.DcCreateVirtualDevice(cardID, deviceID, vDevInfo)
+	if err != nil {
+		return common.CgoCreateVDevOut{}, fmt.Errorf("create virtual device failed, error is: %w", err)
+	}
+	return createVDevOut, nil
+}
+
+// DcGetVDeviceInfo get virtual device info by logic id
+func (d *DcManager) DcGetVDeviceInfo(logicID int32) (common.VirtualDevInfo, error) {
+	if !common.IsValidLogicIDOrPhyID(logicID) {
+		return common.VirtualDevInfo{}, common.InvalidErrorf("input invalid logicID: %d", logicID)
+	}
+	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		return common.VirtualDevInfo{}, fmt.Errorf("get card id and device id failed, error is: %w", err)
+	}
+
+	dcmiVDevInfo, err := d.DcVGetDeviceInfo(cardID, deviceID)
+	if err != nil {
+		return common.VirtualDevInfo{}, fmt.Errorf("get virtual device info failed, error is: %w", err)
+	}
+	return dcmiVDevInfo, nil
+}
+
+// DcDestroyVDevice destroy spec virtual device by logic id
+func (d *DcManager) DcDestroyVDevice(logicID int32, vDevID uint32) error {
+	if !common.IsValidLogicIDOrPhyID(logicID) {
+		return common.InvalidErrorf("input invalid logicID: %d", logicID)
+	}
+	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		return fmt.Errorf("get card id and device id failed, error is: %w", err)
+	}
+
+	if err = d.DcSetDestroyVirtualDevice(cardID, deviceID, vDevID); err != nil {
+		return fmt.Errorf("destroy virtual device failed, error is: %w", err)
+	}
+	return nil
+}
+
+// DcGetHbmInfo get HBM information A310/A310P not support
+func (d *DcManager) DcGetHbmInfo(cardID, deviceID int32) (*common.HbmInfo, error) {
+	return &common.HbmInfo{
+		MemorySize:        0,
+		Frequency:         0,
+		Usage:             0,
+		Temp:              0,
+		BandWidthUtilRate: 0}, nil
+}
+
+// DcGetDeviceCount get device count
+func (d *DcManager) DcGetDeviceCount() (int32, error) {
+	devNum, _, err := d.DcGetLogicIDList()
+	if err != nil {
+		return common.RetError, fmt.Errorf("get device count failed, error: %w", err)
+	}
+	return devNum, nil
+}
+
+// DcGetLogicIDList get device logic id list
+func (d *DcManager) DcGetLogicIDList() (int32, []int32, error) {
+	var logicIDs []int32
+	var totalNum int32
+	_, cardList, err := d.DcGetCardList()
+	if err != nil {
+		return common.RetError, logicIDs, fmt.Errorf("get card list failed, error: %w", err)
+	}
+	for _, cardID := range cardList {
+		devNumInCard, err := d.DcGetDeviceNumInCard(cardID)
+		if err != nil {
+			return common.RetError, logicIDs, fmt.Errorf("get device num by cardID: %d failed, error: %w",
+				cardID, err)
+		}
+		totalNum += devNumInCard
+		if totalNum > common.HiAIMaxDeviceNum*common.HiAIMaxCardNum {
+			return common.RetError, nil, common.InvalidErrorf("get device num: %d greater than %d",
+				totalNum, common.HiAIMaxDeviceNum*common.HiAIMaxCardNum)
+		}
+		for devID := int32(0); devID < devNumInCard; devID++ {
+			logicID, err := d.DcGetDeviceLogicID(cardID, devID)
+			if err != nil {
+				return common.RetError, nil, fmt.Errorf("get device (cardID: %d, deviceID: %d) logic id "+
+					"failed, error: %w", cardID, devID, err)
+			}
+			logicIDs = append(logicIDs, logicID)
+		}
+	}
+	return totalNum, logicIDs, nil
+}
+
+// DcGetMcuPowerInfo this function is only for Ascend310P, A910/A310 not support
+func (d *DcManager) DcGetMcuPowerInfo(cardID int32) (float32, error) {
+	return 0, nil
+}
+
+// DcSetFaultEventCallFunc set fault event call back func
+func (d *DcManager) DcSetFaultEventCallFunc(businessFunc func(common.DevFaultInfo)) {
+	faultEventCallFunc = businessFunc
+}
@@ -0,0 +1,22 @@
+//go:build !cgo && !purego
+
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package dcmi this for dcmi manager, which needs cgo or the purego build tag to load libdcmi
+package dcmi
+
+// libdcmi is loaded by cgo, or by purego when cgo is disabled, so the build fails with the undefined name below
+// unless it is built with CGO_ENABLED=1 or go build -tags purego
+var _ = dcmiNeedsCgoOrPuregoBuildTag
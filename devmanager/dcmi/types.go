@@ -0,0 +1,241 @@
+//go:build cgo || purego
+
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package dcmi this for the go structs of dcmi
+package dcmi
+
+// The following structs mirror the structs of dcmi_interface_api.h field by field, they are passed to libdcmi by
+// the purego driver which has no cgo. The layout of each struct must be the same as the c struct on the 64-bit
+// linux, which is checked by the layout test, so do not reorder the fields. The unsigned long of c is 64-bit there.
+
+const (
+	hccsMaxPcsNum          = 16
+	dcmiVDevForReserve     = 32
+	dcmiVDevSize           = 20
+	dcmiHccsStatReserveNum = 64
+	pcieInfoReserveLen     = 32
+	createVDevReserveLen   = 64
+	memoryInfoReserveLen   = 60
+	ipAddrLen              = 16
+	eventNameLen           = 256
+	eventDataLen           = 32
+	eventResvLen           = 32
+)
+
+type dcmiChipInfo struct {
+	ChipType  [maxChipNameLen]byte
+	ChipName  [maxChipNameLen]byte
+	ChipVer   [maxChipNameLen]byte
+	AicoreCnt uint32
+}
+
+type dcmiPcieInfoAll struct {
+	VenderID    uint32
+	SubVenderID uint32
+	DeviceID    uint32
+	SubDeviceID uint32
+	Domain      int32
+	BdfBusID    uint32
+	BdfDeviceID uint32
+	BdfFuncID   uint32
+	Reserve     [pcieInfoReserveLen]byte
+}
+
+type dcmiDieID struct {
+	SocDie [DieIDCount]uint32
+}
+
+type dcmiHbmInfo struct {
+	MemorySize       uint64
+	Freq             uint32
+	MemoryUsage      uint64
+	Temp             int32
+	BandwithUtilRate uint32
+}
+
+type dcmiGetMemoryInfoStru struct {
+	MemorySize      uint64
+	MemoryAvailable uint64
+	Freq            uint32
+	HugePageSize    uint64
+	HugePagesTotal  uint64
+	HugePagesFree   uint64
+	Utiliza         uint32
+	Reserve         [memoryInfoReserveLen]byte
+}
+
+type dcmiIPAddr struct {
+	// UAddr the union of the ip6 and ip4 address
+	UAddr  [ipAddrLen]byte
+	IPType int32
+}
+
+type dcmiBaseResource struct {
+	Token       uint64
+	TokenMax    uint64
+	TaskTimeout uint64
+	VfgID       uint32
+	VipMode     uint8
+	Reserved    [dcmiVDevForReserve - 1]byte
+}
+
+type dcmiComputingResource struct {
+	Aic                   float32
+	Aiv                   float32
+	Dsa                   uint16
+	Rtsq                  uint16
+	Acsq                  uint16
+	Cdqm                  uint16
+	CCore                 uint16
+	Ffts                  uint16
+	Sdma                  uint16
+	PcieDma               uint16
+	MemorySize            uint64
+	EventID               uint32
+	NotifyID              uint32
+	StreamID              uint32
+	ModelID               uint32
+	TopicScheduleAicpu    uint16
+	HostCtrlCPU           uint16
+	HostAicpu             uint16
+	DeviceAicpu           uint16
+	TopicCtrlCPUSlot      uint16
+	VDevAicoreUtilization uint32
+	VDevMemoryTotal       uint64
+	VDevMemoryFree        uint64
+	Reserved              [dcmiVDevForReserve - dcmiVDevSize]byte
+}
+
+type dcmiMediaResource struct {
+	Jpegd    float32
+	Jpege    float32
+	Vpc      float32
+	Vdec     float32
+	Pngd     float32
+	Venc     float32
+	Reserved [dcmiVDevForReserve]byte
+}
+
+type dcmiCreateVDevOut struct {
+	VDevID     uint32
+	PcieBus    uint32
+	PcieDevice uint32
+	PcieFunc   uint32
+	VfgID      uint32
+	Reserved   [dcmiVDevForReserve]byte
+}
+
+type dcmiCreateVDevResStru struct {
+	VDevID       uint32
+	VfgID        uint32
+	TemplateName [templateNameLen]byte
+	Reserved     [createVDevReserveLen]byte
+}
+
+type dcmiVDevQueryInfo struct {
+	Name            [dcmiVDevResNameLen]byte
+	Status          uint32
+	IsContainerUsed uint32
+	Vfid            uint32
+	VfgID           uint32
+	ContainerID     uint64
+	Base            dcmiBaseResource
+	Computing       dcmiComputingResource
+	Media           dcmiMediaResource
+}
+
+type dcmiVDevQueryStru struct {
+	VDevID    uint32
+	QueryInfo dcmiVDevQueryInfo
+}
+
+type dcmiSocFreeResource struct {
+	VfgNum    uint32
+	VfgBitmap uint32
+	Base      dcmiBaseResource
+	Computing dcmiComputingResource
+	Media     dcmiMediaResource
+}
+
+type dcmiSocTotalResource struct {
+	VDevNum   uint32
+	VDevID    [dcmiMaxVdevNum]uint32
+	VfgNum    uint32
+	VfgBitmap uint32
+	Base      dcmiBaseResource
+	Computing dcmiComputingResource
+	Media     dcmiMediaResource
+}
+
+type dcmiProcMemInfo struct {
+	ProcID int32
+	// ProcMemUsage unit is byte
+	ProcMemUsage uint64
+}
+
+type dcmiBoardInfo struct {
+	BoardID uint32
+	PcbID   uint32
+	BomID   uint32
+	SlotID  uint32
+}
+
+type dcmiHccsStatisticInfo struct {
+	TxCnt            [hccsMaxPcsNum]uint32
+	RxCnt            [hccsMaxPcsNum]uint32
+	CrcErrCnt        [hccsMaxPcsNum]uint32
+	RetryCnt         [hccsMaxPcsNum]uint32
+	ReservedFieldCnt [dcmiHccsStatReserveNum]uint32
+}
+
+type dcmiHccsBandwidthInfo struct {
+	ProfilingTime int32
+	TotalTxbw     float64
+	TotalRxbw     float64
+	TxBandwidth   [hccsMaxPcsNum]float64
+	RxBandwidth   [hccsMaxPcsNum]float64
+}
+
+type dcmiDmsFaultEvent struct {
+	EventID         uint32
+	DeviceID        uint16
+	NodeType        uint8
+	NodeID          uint8
+	SubNodeType     uint8
+	SubNodeID       uint8
+	Severity        uint8
+	Assertion       uint8
+	EventSerialNum  int32
+	NotifySerialNum int32
+	AlarmRaisedTime uint64
+	EventName       [eventNameLen]byte
+	AdditionalInfo  [eventDataLen]byte
+	Resv            [eventResvLen]byte
+}
+
+type dcmiEvent struct {
+	Type int32
+	// EventT the union of the event content, which only has the dms fault event
+	EventT dcmiDmsFaultEvent
+}
+
+type dcmiEventFilter struct {
+	FilterFlag uint64
+	EventID    uint32
+	Severity   uint8
+	NodeType   uint8
+	Resv       [eventResvLen]byte
+}
@@ -0,0 +1,72 @@
+//go:build cgo
+
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package dcmi this for the go structs of dcmi
+package dcmi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/devmanager/dcmi/internal/clayout"
+)
+
+// TestStructLayout test the go structs have the same layout as the c structs of dcmi_interface_api.h
+func TestStructLayout(t *testing.T) {
+	goStructs := map[string]interface{}{
+		"dcmi_chip_info":            dcmiChipInfo{},
+		"dcmi_pcie_info_all":        dcmiPcieInfoAll{},
+		"dcmi_die_id":               dcmiDieID{},
+		"dcmi_hbm_info":             dcmiHbmInfo{},
+		"dcmi_get_memory_info_stru": dcmiGetMemoryInfoStru{},
+		"dcmi_ip_addr":              dcmiIPAddr{},
+		"dcmi_base_resource":        dcmiBaseResource{},
+		"dcmi_computing_resource":   dcmiComputingResource{},
+		"dcmi_media_resource":       dcmiMediaResource{},
+		"dcmi_create_vdev_out":      dcmiCreateVDevOut{},
+		"dcmi_create_vdev_res_stru": dcmiCreateVDevResStru{},
+		"dcmi_vdev_query_info":      dcmiVDevQueryInfo{},
+		"dcmi_vdev_query_stru":      dcmiVDevQueryStru{},
+		"dcmi_soc_free_resource":    dcmiSocFreeResource{},
+		"dcmi_soc_total_resource":   dcmiSocTotalResource{},
+		"dcmi_proc_mem_info":        dcmiProcMemInfo{},
+		"dcmi_board_info":           dcmiBoardInfo{},
+		"dcmi_hccs_statistic_info":  dcmiHccsStatisticInfo{},
+		"dcmi_hccs_bandwidth_info":  dcmiHccsBandwidthInfo{},
+		"dcmi_dms_fault_event":      dcmiDmsFaultEvent{},
+		"dcmi_event":                dcmiEvent{},
+		"dcmi_event_filter":         dcmiEventFilter{},
+	}
+	layouts := clayout.Layouts()
+	assert.Len(t, goStructs, len(layouts))
+	for name, layout := range layouts {
+		t.Run("should have same size and field offsets when go struct mirrors "+name, func(t *testing.T) {
+			goStruct, ok := goStructs[name]
+			if !ok {
+				t.Fatalf("no go struct for %s", name)
+			}
+			typ := reflect.TypeOf(goStruct)
+			assert.Equal(t, layout.Size, typ.Size())
+			offsets := make([]uintptr, 0, typ.NumField())
+			for i := 0; i < typ.NumField(); i++ {
+				offsets = append(offsets, typ.Field(i).Offset)
+			}
+			assert.Equal(t, layout.Offsets, offsets)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package devmanager this for the errors of dcmi calls
+package devmanager
+
+import (
+	"sync"
+	"time"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+// unsupportedCallTTL the time the not supported result of a call is cached, the call is retried after it because the
+// result may change after the driver or firmware is upgraded
+const unsupportedCallTTL = 10 * time.Minute
+
+// DcmiErrorKey the call of device manager and the class of its error
+type DcmiErrorKey struct {
+	// Call the method name of DeviceManager, such as GetDeviceTemperature
+	Call string
+	// Class the error class, such as common.ErrClassNotSupported
+	Class string
+}
+
+var dcmiErrors = struct {
+	sync.Mutex
+	counts map[DcmiErrorKey]uint64
+}{counts: make(map[DcmiErrorKey]uint64)}
+
+// GetDcmiErrorCounts return the number of errors of each call and error class since the process started
+func GetDcmiErrorCounts() map[DcmiErrorKey]uint64 {
+	dcmiErrors.Lock()
+	defer dcmiErrors.Unlock()
+	counts := make(map[DcmiErrorKey]uint64, len(dcmiErrors.counts))
+	for key, count := range dcmiErrors.counts {
+		counts[key] = count
+	}
+	return counts
+}
+
+// callKey locates the call on a chip, the not supported result of the call is cached by it
+type callKey struct {
+	call string
+	// id the logic id of the chip, or the card id for the calls of card
+	id int32
+	// sub the sub type of the call, such as the device type of utilization
+	sub int32
+}
+
+// unsupportedCall the cached not supported error of a call and the time it expires
+type unsupportedCall struct {
+	err        error
+	expireTime time.Time
+}
+
+// unsupportedErr return the cached error if the call is not supported on the chip, the expired error is removed
+func (d *DeviceManager) unsupportedErr(key callKey) error {
+	value, ok := d.unsupportedCalls.Load(key)
+	if !ok {
+		return nil
+	}
+	call, ok := value.(unsupportedCall)
+	if !ok || time.Now().After(call.expireTime) {
+		d.unsupportedCalls.Delete(key)
+		return nil
+	}
+	return call.err
+}
+
+// clearUnsupportedCalls removes all the cached not supported errors, the calls are retried after the chips are reset
+func (d *DeviceManager) clearUnsupportedCalls() {
+	d.unsupportedCalls.Range(func(key, _ interface{}) bool {
+		d.unsupportedCalls.Delete(key)
+		return true
+	})
+}
+
+// countErr count the error by the call and its class, the not supported error is cached for unsupportedCallTTL so
+// that the call is not retried every collection cycle, it returns whether the call is not supported
+func (d *DeviceManager) countErr(key callKey, err error) bool {
+	class := common.ErrorClass(err)
+	dcmiErrors.Lock()
+	dcmiErrors.counts[DcmiErrorKey{Call: key.call, Class: class}]++
+	dcmiErrors.Unlock()
+	if class != common.ErrClassNotSupported {
+		return false
+	}
+	d.unsupportedCalls.Store(key, unsupportedCall{err: err, expireTime: time.Now().Add(unsupportedCallTTL)})
+	return true
+}
+
+// recordErr count and log the error, the not supported error is only logged once
+func (d *DeviceManager) recordErr(key callKey, err error) {
+	if d.countErr(key, err) {
+		hwlog.RunLog.Warnf("%s(%d) is not supported and will not be called again in %v, err: %v", key.call, key.id,
+			unsupportedCallTTL, err)
+		return
+	}
+	hwlog.RunLog.Error(err)
+}
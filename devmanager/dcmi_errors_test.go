@@ -0,0 +1,58 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package devmanager this for the errors of dcmi calls
+package devmanager
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+// TestUnsupportedCalls test the not supported error of a call is cached until it expires or is cleared
+func TestUnsupportedCalls(t *testing.T) {
+	key := callKey{call: "GetDeviceBootStatus", id: 0}
+	t.Run("should cache error when call is not supported", func(t *testing.T) {
+		d := &DeviceManager{}
+		assert.True(t, d.countErr(key, common.ErrNotSupported))
+		assert.ErrorIs(t, d.unsupportedErr(key), common.ErrNotSupported)
+	})
+	t.Run("should not cache error when call fails for other reasons", func(t *testing.T) {
+		d := &DeviceManager{}
+		assert.False(t, d.countErr(key, errors.New("busy")))
+		assert.Nil(t, d.unsupportedErr(key))
+	})
+	t.Run("should retry call when cached error expires", func(t *testing.T) {
+		d := &DeviceManager{}
+		d.unsupportedCalls.Store(key, unsupportedCall{err: common.ErrNotSupported,
+			expireTime: time.Now().Add(-time.Second)})
+		assert.Nil(t, d.unsupportedErr(key))
+		_, ok := d.unsupportedCalls.Load(key)
+		assert.False(t, ok)
+	})
+	t.Run("should retry all calls when cached errors are cleared", func(t *testing.T) {
+		d := &DeviceManager{}
+		otherKey := callKey{call: "GetDeviceTemperature", id: 1}
+		d.countErr(key, common.ErrNotSupported)
+		d.countErr(otherKey, common.ErrNotSupported)
+		d.clearUnsupportedCalls()
+		assert.Nil(t, d.unsupportedErr(key))
+		assert.Nil(t, d.unsupportedErr(otherKey))
+	})
+}
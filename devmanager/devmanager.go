@@ -55,6 +55,8 @@ type DeviceInterface interface {
 	GetVirtualDeviceInfo(logicID int32) (common.VirtualDevInfo, error)
 	DestroyVirtualDevice(logicID int32, vDevID uint32) error
 	GetDevType() string
+	GetCardType(cardID int32) string
+	GetCardProductType(cardID int32) string
 	GetProductTypeArray() []string
 	GetProductType(cardID, deviceID int32) (string, error)
 	GetAllProductType() ([]string, error)
@@ -68,8 +70,9 @@ type DeviceInterface interface {
 	GetDevProcessInfo(logicID int32) (*common.DevProcessInfo, error)
 	GetPCIeBusInfo(logicID int32) (string, error)
 	GetBoardInfo(logicID int32) (common.BoardInfo, error)
+	GetHccsInfo(logicID int32) (common.HccsInfo, error)
 	SetIsTrainingCard() error
-	IsTrainingCard() bool
+	IsTrainingCard(logicID int32) bool
 }
 
 var (
@@ -81,13 +84,13 @@ var (
 func GetDeviceManager() (*DeviceManager, error) {
 	devManagerOnce.Do(func() {
 		// a common dcmi Manager is initiated for init dcmi interface, you can specify an specific manager in later
-		dcMgr := dcmi.DcManager{}
+		dcMgr := newDcDriver()
 		if err := dcMgr.DcInit(); err != nil {
 			hwlog.RunLog.Errorf("deviceManager init failed, prepare dcmi failed, err: %v", err)
 			return
 		}
 		devManager = &DeviceManager{}
-		devManager.DcMgr = &dcMgr
+		devManager.DcMgr = dcMgr
 	})
 	if devManager == nil {
 		return nil, errors.New("device Manager is nil, may encounter an exception during initialization. " +
@@ -106,8 +109,17 @@ type DeviceManager struct {
 	DevType string
 	// ProductTypes product type in server, multi type will be in 310P mix scene
 	ProductTypes []string
-	// isTrainingCard whether the device is used for training
-	isTrainingCard bool
+	// cardTypes the device type of each card, the cards of a heterogeneous server may differ from DevType
+	cardTypes map[int32]string
+	// cardProductTypes the product type of each card
+	cardProductTypes map[int32]string
+	// cardMgrs the dcmi driver of each card, which is created by the device type of the card
+	cardMgrs map[int32]dcmi.DcDriverInterface
+	// trainingCards whether each card is used for training
+	trainingCards map[int32]bool
+	// unsupportedCalls the not supported error of each call on each chip, the call is not retried until the error
+	// expires or the chips are reset
+	unsupportedCalls sync.Map
 }
 
 // GetProductTypeArray return product types
@@ -120,6 +132,19 @@ func (d *DeviceManager) GetDevType() string {
 	return d.DevType
 }
 
+// GetCardType return the device type of the card, DevType is returned if the card is not recognized
+func (d *DeviceManager) GetCardType(cardID int32) string {
+	if cardType, ok := d.cardTypes[cardID]; ok {
+		return cardType
+	}
+	return d.DevType
+}
+
+// GetCardProductType return the product type of the card, empty if the card is not recognized
+func (d *DeviceManager) GetCardProductType(cardID int32) string {
+	return d.cardProductTypes[cardID]
+}
+
 // AutoInit auto detect npu chip type and return the corresponding processing object
 func AutoInit(dType string) (*DeviceManager, error) {
 	chipInfo, err := getChipInfoForInit()
@@ -130,22 +155,17 @@ func AutoInit(dType string) (*DeviceManager, error) {
 	if devMgr, err = GetDeviceManager(); err != nil {
 		return nil, err
 	}
+	base := devMgr.DcMgr
 	devType := common.GetDeviceTypeByChipName(chipInfo.Name)
-	switch devType {
-	case common.Ascend910, common.Ascend910B:
-		devMgr.DcMgr = &A910Manager{}
-	case common.Ascend310P:
-		devMgr.DcMgr = &A310PManager{}
-	case common.Ascend310, common.Ascend310B:
-		devMgr.DcMgr = &A310Manager{}
-	default:
-		return nil, fmt.Errorf("unsupport device type (%s)", devType)
+	if devMgr.DcMgr, err = newChipDriver(devType, base); err != nil {
+		return nil, err
 	}
 	if dType != "" && devType != dType {
 		return nil, fmt.Errorf("the value of dType(%s) is inconsistent with the actual chip type(%s)",
 			dType, devType)
 	}
 	devMgr.DevType = devType
+	devMgr.initCardTypes(base)
 	if err := devMgr.SetIsTrainingCard(); err != nil {
 		hwlog.RunLog.Errorf("auto recognize training card failed, err: %s", err)
 	}
@@ -158,6 +178,20 @@ func AutoInit(dType string) (*DeviceManager, error) {
 	return devMgr, nil
 }
 
+// newChipDriver wraps the common dcmi driver in the device manager of the chip type
+func newChipDriver(devType string, base dcmi.DcDriverInterface) (dcmi.DcDriverInterface, error) {
+	switch devType {
+	case common.Ascend910, common.Ascend910B:
+		return &A910Manager{DcDriverInterface: base}, nil
+	case common.Ascend310P:
+		return &A310PManager{DcDriverInterface: base}, nil
+	case common.Ascend310, common.Ascend310B:
+		return &A310Manager{DcDriverInterface: base}, nil
+	default:
+		return nil, fmt.Errorf("unsupport device type (%s)", devType)
+	}
+}
+
 func getChipInfoForInit() (common.ChipInfo, error) {
 	var mgr *DeviceManager
 	var err error
@@ -176,28 +210,103 @@ func getChipInfoForInit() (common.ChipInfo, error) {
 	}
 	// get device in card, then get chip info by cardID and deviceID
 	for _, cardID := range cardList {
-		devNum, err := dcMgr.DcGetDeviceNumInCard(cardID)
-		if err != nil || devNum == 0 {
-			hwlog.RunLog.Debugf("get device num by cardID(%d) failed, error: %v", cardID, err)
+		if chipInfo, _, err := getCardChipInfo(dcMgr, cardID); err == nil {
+			return chipInfo, nil
+		}
+	}
+
+	return common.ChipInfo{}, errors.New("cannot get valid chip info")
+}
+
+// getCardChipInfo return the chip info and the device id of the first valid chip in the card
+func getCardChipInfo(dcMgr dcmi.DcDriverInterface, cardID int32) (common.ChipInfo, int32, error) {
+	devNum, err := dcMgr.DcGetDeviceNumInCard(cardID)
+	if err != nil || devNum == 0 {
+		hwlog.RunLog.Debugf("get device num by cardID(%d) failed, error: %v", cardID, err)
+		return common.ChipInfo{}, common.RetError, fmt.Errorf("no device found on card %d", cardID)
+	}
+	for devID := int32(0); devID < devNum; devID++ {
+		chipInfo, err := dcMgr.DcGetChipInfo(cardID, devID)
+		if err != nil {
+			hwlog.RunLog.Debugf("get chip info failed by cardID(%d), deviceID(%d), error: %v", cardID, devID,
+				err)
 			continue
 		}
-		for devID := int32(0); devID < devNum; devID++ {
-			chipInfo, err := dcMgr.DcGetChipInfo(cardID, devID)
-			if err != nil {
-				hwlog.RunLog.Debugf("get chip info failed by cardID(%d), deviceID(%d), error: %v", cardID, devID,
-					err)
-				continue
-			}
-			if !common.IsValidChipInfo(chipInfo) {
-				hwlog.RunLog.Debugf("invalid chip info by cardID(%d), deviceID(%d), error: %v", cardID, devID,
-					err)
+		if !common.IsValidChipInfo(chipInfo) {
+			hwlog.RunLog.Debugf("invalid chip info by cardID(%d), deviceID(%d), error: %v", cardID, devID,
+				err)
+			continue
+		}
+		return *chipInfo, devID, nil
+	}
+	return common.ChipInfo{}, common.RetError, fmt.Errorf("cannot get valid chip info on card %d", cardID)
+}
+
+// initCardTypes recognizes the device type and the product type of each card and creates the dcmi driver of each
+// device type, so that the cards of a heterogeneous server are handled by the driver of their own device type
+func (d *DeviceManager) initCardTypes(base dcmi.DcDriverInterface) {
+	cardTypes := make(map[int32]string)
+	cardProductTypes := make(map[int32]string)
+	cardMgrs := make(map[int32]dcmi.DcDriverInterface)
+	drivers := map[string]dcmi.DcDriverInterface{d.DevType: d.DcMgr}
+	_, cardList, err := base.DcGetCardList()
+	if err != nil {
+		hwlog.RunLog.Errorf("get card list failed when recognize card types, err: %v", err)
+	}
+	for _, cardID := range cardList {
+		chipInfo, devID, err := getCardChipInfo(base, cardID)
+		if err != nil {
+			hwlog.RunLog.Debugf("recognize type of card %d failed, err: %v", cardID, err)
+			continue
+		}
+		cardType := common.GetDeviceTypeByChipName(chipInfo.Name)
+		driver, ok := drivers[cardType]
+		if !ok {
+			if driver, err = newChipDriver(cardType, base); err != nil {
+				hwlog.RunLog.Warnf("card %d with chip %s is ignored, err: %v", cardID, chipInfo.Name, err)
 				continue
 			}
-			return *chipInfo, nil
+			drivers[cardType] = driver
+		}
+		if cardType != d.DevType {
+			hwlog.RunLog.Infof("card %d is %s, which differs from the device type %s", cardID, cardType,
+				d.DevType)
+		}
+		cardTypes[cardID] = cardType
+		cardMgrs[cardID] = driver
+		if productType, err := base.DcGetProductType(cardID, devID); err == nil {
+			cardProductTypes[cardID] = productType
 		}
 	}
+	d.cardTypes, d.cardProductTypes, d.cardMgrs = cardTypes, cardProductTypes, cardMgrs
+}
 
-	return common.ChipInfo{}, errors.New("cannot get valid chip info")
+// dcMgrOfCard return the dcmi driver of the card, DcMgr is returned if the card is not recognized
+func (d *DeviceManager) dcMgrOfCard(cardID int32) dcmi.DcDriverInterface {
+	if dcMgr, ok := d.cardMgrs[cardID]; ok {
+		return dcMgr
+	}
+	return d.DcMgr
+}
+
+// dcMgrOfLogicID return the dcmi driver of the card which the chip belongs to
+func (d *DeviceManager) dcMgrOfLogicID(logicID int32) dcmi.DcDriverInterface {
+	cardID, _, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		hwlog.RunLog.Debugf("get cardID by logicID(%d) failed, err: %v", logicID, err)
+		return d.DcMgr
+	}
+	return d.dcMgrOfCard(cardID)
+}
+
+// devTypeOfLogicID return the device type of the card which the chip belongs to
+func (d *DeviceManager) devTypeOfLogicID(logicID int32) string {
+	cardID, _, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		hwlog.RunLog.Debugf("get cardID by logicID(%d) failed, err: %v", logicID, err)
+		return d.DevType
+	}
+	return d.GetCardType(cardID)
 }
 
 // Init load symbol and initialize dcmi
@@ -222,7 +331,7 @@ func (d *DeviceManager) GetCardList() (int32, []int32, error) {
 
 // GetDeviceNumInCard  get all device list in one card
 func (d *DeviceManager) GetDeviceNumInCard(cardID int32) (int32, error) {
-	return d.DcMgr.DcGetDeviceNumInCard(cardID)
+	return d.dcMgrOfCard(cardID).DcGetDeviceNumInCard(cardID)
 }
 
 // GetDeviceList get all device logicID list
@@ -232,15 +341,19 @@ func (d *DeviceManager) GetDeviceList() (int32, []int32, error) {
 
 // GetDeviceHealth query npu device health status
 func (d *DeviceManager) GetDeviceHealth(logicID int32) (uint32, error) {
+	key := callKey{call: "GetDeviceHealth", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.UnRetError, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.UnRetError, fmt.Errorf("failed to get health code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.UnRetError, fmt.Errorf("failed to get health code by logicID(%d), %w", logicID, err)
 	}
-	healthCode, err := d.DcMgr.DcGetDeviceHealth(cardID, deviceID)
+	healthCode, err := d.dcMgrOfCard(cardID).DcGetDeviceHealth(cardID, deviceID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.UnRetError, fmt.Errorf("failed to get health code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.UnRetError, fmt.Errorf("failed to get health code by logicID(%d), %w", logicID, err)
 	}
 
 	return uint32(healthCode), nil
@@ -248,15 +361,19 @@ func (d *DeviceManager) GetDeviceHealth(logicID int32) (uint32, error) {
 
 // GetDeviceNetWorkHealth query npu device network health status
 func (d *DeviceManager) GetDeviceNetWorkHealth(logicID int32) (uint32, error) {
+	key := callKey{call: "GetDeviceNetWorkHealth", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.UnRetError, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.UnRetError, fmt.Errorf("failed to get network health code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.UnRetError, fmt.Errorf("failed to get network health code by logicID(%d), %w", logicID, err)
 	}
-	healthCode, err := d.DcMgr.DcGetDeviceNetWorkHealth(cardID, deviceID)
+	healthCode, err := d.dcMgrOfCard(cardID).DcGetDeviceNetWorkHealth(cardID, deviceID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.UnRetError, fmt.Errorf("failed to get network health code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.UnRetError, fmt.Errorf("failed to get network health code by logicID(%d), %w", logicID, err)
 	}
 
 	return healthCode, nil
@@ -264,15 +381,19 @@ func (d *DeviceManager) GetDeviceNetWorkHealth(logicID int32) (uint32, error) {
 
 // GetDeviceUtilizationRate get npu device utilization
 func (d *DeviceManager) GetDeviceUtilizationRate(logicID int32, deviceType common.DeviceType) (uint32, error) {
+	key := callKey{call: "GetDeviceUtilizationRate", id: logicID, sub: int32(deviceType)}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.UnRetError, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.UnRetError, fmt.Errorf("failed to get utilization by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.UnRetError, fmt.Errorf("failed to get utilization by logicID(%d), %w", logicID, err)
 	}
-	rate, err := d.DcMgr.DcGetDeviceUtilizationRate(cardID, deviceID, deviceType)
+	rate, err := d.dcMgrOfCard(cardID).DcGetDeviceUtilizationRate(cardID, deviceID, deviceType)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.UnRetError, fmt.Errorf("failed to get utilization by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.UnRetError, fmt.Errorf("failed to get utilization by logicID(%d), %w", logicID, err)
 	}
 
 	return uint32(rate), nil
@@ -280,15 +401,19 @@ func (d *DeviceManager) GetDeviceUtilizationRate(logicID int32, deviceType commo
 
 // GetDeviceTemperature get npu device temperature
 func (d *DeviceManager) GetDeviceTemperature(logicID int32) (int32, error) {
+	key := callKey{call: "GetDeviceTemperature", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.RetError, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.RetError, fmt.Errorf("failed to get temperature by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.RetError, fmt.Errorf("failed to get temperature by logicID(%d), %w", logicID, err)
 	}
-	temp, err := d.DcMgr.DcGetDeviceTemperature(cardID, deviceID)
+	temp, err := d.dcMgrOfCard(cardID).DcGetDeviceTemperature(cardID, deviceID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.RetError, fmt.Errorf("failed to get temperature by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.RetError, fmt.Errorf("failed to get temperature by logicID(%d), %w", logicID, err)
 	}
 
 	return temp, nil
@@ -296,15 +421,19 @@ func (d *DeviceManager) GetDeviceTemperature(logicID int32) (int32, error) {
 
 // GetDeviceVoltage get npu device voltage
 func (d *DeviceManager) GetDeviceVoltage(logicID int32) (float32, error) {
+	key := callKey{call: "GetDeviceVoltage", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.UnRetError, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.UnRetError, fmt.Errorf("failed to get voltage by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.UnRetError, fmt.Errorf("failed to get voltage by logicID(%d), %w", logicID, err)
 	}
-	voltage, err := d.DcMgr.DcGetDeviceVoltage(cardID, deviceID)
+	voltage, err := d.dcMgrOfCard(cardID).DcGetDeviceVoltage(cardID, deviceID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.UnRetError, fmt.Errorf("failed to get voltage by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.UnRetError, fmt.Errorf("failed to get voltage by logicID(%d), %w", logicID, err)
 	}
 
 	return voltage, nil
@@ -312,15 +441,19 @@ func (d *DeviceManager) GetDeviceVoltage(logicID int32) (float32, error) {
 
 // GetDevicePowerInfo get npu device power info
 func (d *DeviceManager) GetDevicePowerInfo(logicID int32) (float32, error) {
+	key := callKey{call: "GetDevicePowerInfo", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.UnRetError, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.UnRetError, fmt.Errorf("failed to get power by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.UnRetError, fmt.Errorf("failed to get power by logicID(%d), %w", logicID, err)
 	}
-	power, err := d.DcMgr.DcGetDevicePowerInfo(cardID, deviceID)
+	power, err := d.dcMgrOfCard(cardID).DcGetDevicePowerInfo(cardID, deviceID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.UnRetError, fmt.Errorf("failed to get power by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.UnRetError, fmt.Errorf("failed to get power by logicID(%d), %w", logicID, err)
 	}
 
 	return power, nil
@@ -328,15 +461,19 @@ func (d *DeviceManager) GetDevicePowerInfo(logicID int32) (float32, error) {
 
 // GetDeviceFrequency get npu device work frequency
 func (d *DeviceManager) GetDeviceFrequency(logicID int32, deviceType common.DeviceType) (uint32, error) {
+	key := callKey{call: "GetDeviceFrequency", id: logicID, sub: int32(deviceType)}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.InvalidVal, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.InvalidVal, fmt.Errorf("failed to get frequency by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.InvalidVal, fmt.Errorf("failed to get frequency by logicID(%d), %w", logicID, err)
 	}
-	frequency, err := d.DcMgr.DcGetDeviceFrequency(cardID, deviceID, deviceType)
+	frequency, err := d.dcMgrOfCard(cardID).DcGetDeviceFrequency(cardID, deviceID, deviceType)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.InvalidVal, fmt.Errorf("failed to get frequency by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.InvalidVal, fmt.Errorf("failed to get frequency by logicID(%d), %w", logicID, err)
 	}
 
 	return frequency, nil
@@ -344,14 +481,18 @@ func (d *DeviceManager) GetDeviceFrequency(logicID int32, deviceType common.Devi
 
 // GetDeviceMemoryInfo get npu memory information
 func (d *DeviceManager) GetDeviceMemoryInfo(logicID int32) (*common.MemoryInfo, error) {
+	key := callKey{call: "GetDeviceMemoryInfo", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return nil, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return nil, fmt.Errorf("failed to get memory info by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return nil, fmt.Errorf("failed to get memory info by logicID(%d), %w", logicID, err)
 	}
 
 	// 910B does not support query info of DDR
-	if d.DevType == common.Ascend910B {
+	if d.GetCardType(cardID) == common.Ascend910B {
 		return &common.MemoryInfo{
 			MemorySize:      0,
 			MemoryAvailable: 0,
@@ -360,10 +501,10 @@ func (d *DeviceManager) GetDeviceMemoryInfo(logicID int32) (*common.MemoryInfo,
 		}, nil
 	}
 
-	memInfo, err := d.DcMgr.DcGetMemoryInfo(cardID, deviceID)
+	memInfo, err := d.dcMgrOfCard(cardID).DcGetMemoryInfo(cardID, deviceID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return nil, fmt.Errorf("failed to get memory info by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return nil, fmt.Errorf("failed to get memory info by logicID(%d), %w", logicID, err)
 	}
 
 	return memInfo, nil
@@ -371,15 +512,19 @@ func (d *DeviceManager) GetDeviceMemoryInfo(logicID int32) (*common.MemoryInfo,
 
 // GetDeviceHbmInfo get npu HBM module memory and frequency information
 func (d *DeviceManager) GetDeviceHbmInfo(logicID int32) (*common.HbmInfo, error) {
+	key := callKey{call: "GetDeviceHbmInfo", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return nil, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return nil, fmt.Errorf("failed to get hbm info by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return nil, fmt.Errorf("failed to get hbm info by logicID(%d), %w", logicID, err)
 	}
-	hbmInfo, err := d.DcMgr.DcGetHbmInfo(cardID, deviceID)
+	hbmInfo, err := d.dcMgrOfCard(cardID).DcGetHbmInfo(cardID, deviceID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return nil, fmt.Errorf("failed to get hbm info by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return nil, fmt.Errorf("failed to get hbm info by logicID(%d), %w", logicID, err)
 	}
 
 	return hbmInfo, nil
@@ -387,17 +532,21 @@ func (d *DeviceManager) GetDeviceHbmInfo(logicID int32) (*common.HbmInfo, error)
 
 // GetDeviceErrorCode get npu device error code
 func (d *DeviceManager) GetDeviceErrorCode(logicID int32) (int32, int64, error) {
+	key := callKey{call: "GetDeviceErrorCode", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.RetError, common.RetError, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.RetError, common.RetError, fmt.Errorf("failed to get device error code by logicID(%d)",
-			logicID)
+		d.recordErr(key, err)
+		return common.RetError, common.RetError, fmt.Errorf("failed to get device error code by logicID(%d), %w",
+			logicID, err)
 	}
-	errCount, errCode, err := d.DcMgr.DcGetDeviceErrorCode(cardID, deviceID)
+	errCount, errCode, err := d.dcMgrOfCard(cardID).DcGetDeviceErrorCode(cardID, deviceID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.RetError, common.RetError, fmt.Errorf("failed to get device error code by logicID(%d)",
-			logicID)
+		d.recordErr(key, err)
+		return common.RetError, common.RetError, fmt.Errorf("failed to get device error code by logicID(%d), %w",
+			logicID, err)
 	}
 
 	return errCount, errCode, nil
@@ -405,15 +554,19 @@ func (d *DeviceManager) GetDeviceErrorCode(logicID int32) (int32, int64, error)
 
 // GetChipInfo get npu device error code
 func (d *DeviceManager) GetChipInfo(logicID int32) (*common.ChipInfo, error) {
+	key := callKey{call: "GetChipInfo", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return nil, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return nil, fmt.Errorf("failed to get chip info code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return nil, fmt.Errorf("failed to get chip info code by logicID(%d), %w", logicID, err)
 	}
-	chipInfo, err := d.DcMgr.DcGetChipInfo(cardID, deviceID)
+	chipInfo, err := d.dcMgrOfCard(cardID).DcGetChipInfo(cardID, deviceID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return nil, fmt.Errorf("failed to get chip info code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return nil, fmt.Errorf("failed to get chip info code by logicID(%d), %w", logicID, err)
 	}
 
 	return chipInfo, nil
@@ -421,10 +574,14 @@ func (d *DeviceManager) GetChipInfo(logicID int32) (*common.ChipInfo, error) {
 
 // GetPhysicIDFromLogicID get device physic id from logic id
 func (d *DeviceManager) GetPhysicIDFromLogicID(logicID int32) (int32, error) {
+	key := callKey{call: "GetPhysicIDFromLogicID", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.RetError, err
+	}
 	physicID, err := d.DcMgr.DcGetPhysicIDFromLogicID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.RetError, fmt.Errorf("failed to get physicID by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.RetError, fmt.Errorf("failed to get physicID by logicID(%d), %w", logicID, err)
 	}
 
 	return physicID, nil
@@ -432,10 +589,14 @@ func (d *DeviceManager) GetPhysicIDFromLogicID(logicID int32) (int32, error) {
 
 // GetLogicIDFromPhysicID get device logic id from physic id
 func (d *DeviceManager) GetLogicIDFromPhysicID(physicID int32) (int32, error) {
+	key := callKey{call: "GetLogicIDFromPhysicID", id: physicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.RetError, err
+	}
 	logicID, err := d.DcMgr.DcGetLogicIDFromPhysicID(physicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.RetError, fmt.Errorf("failed to get logicID by physicID(%d)", physicID)
+		d.recordErr(key, err)
+		return common.RetError, fmt.Errorf("failed to get logicID by physicID(%d), %w", physicID, err)
 	}
 
 	return logicID, nil
@@ -443,37 +604,53 @@ func (d *DeviceManager) GetLogicIDFromPhysicID(physicID int32) (int32, error) {
 
 // GetDeviceLogicID get device logic id from card id and device id
 func (d *DeviceManager) GetDeviceLogicID(cardID, deviceID int32) (int32, error) {
-	return d.DcMgr.DcGetDeviceLogicID(cardID, deviceID)
+	return d.dcMgrOfCard(cardID).DcGetDeviceLogicID(cardID, deviceID)
 }
 
 // GetDeviceIPAddress get device ip address
 func (d *DeviceManager) GetDeviceIPAddress(logicID, ipType int32) (string, error) {
+	key := callKey{call: "GetDeviceIPAddress", id: logicID, sub: ipType}
+	if err := d.unsupportedErr(key); err != nil {
+		return "", err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
+		d.recordErr(key, err)
 		return "", fmt.Errorf("failed to get cardID and deviceID by logicID(%d), %w", logicID, err)
 	}
-	return d.DcMgr.DcGetDeviceIPAddress(cardID, deviceID, ipType)
+	ip, err := d.dcMgrOfCard(cardID).DcGetDeviceIPAddress(cardID, deviceID, ipType)
+	if err != nil {
+		d.recordErr(key, err)
+		return "", err
+	}
+	return ip, nil
 }
 
 // CreateVirtualDevice create virtual device
 func (d *DeviceManager) CreateVirtualDevice(logicID int32, vDevInfo common.CgoCreateVDevRes) (common.
 	CgoCreateVDevOut, error) {
-	if !common.IsValidTemplateName(d.DevType, vDevInfo.TemplateName) {
+	if !common.IsValidTemplateName(d.devTypeOfLogicID(logicID), vDevInfo.TemplateName) {
 		return common.CgoCreateVDevOut{}, fmt.Errorf("input invalid template name: %s", vDevInfo.TemplateName)
 	}
-	return d.DcMgr.DcCreateVDevice(logicID, vDevInfo)
+	return d.dcMgrOfLogicID(logicID).DcCreateVDevice(logicID, vDevInfo)
 }
 
 // GetVirtualDeviceInfo get virtual device info
 func (d *DeviceManager) GetVirtualDeviceInfo(logicID int32) (common.VirtualDevInfo, error) {
-	cgoVDevInfo, err := d.DcMgr.DcGetVDeviceInfo(logicID)
+	key := callKey{call: "GetVirtualDeviceInfo", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.VirtualDevInfo{}, err
+	}
+	cgoVDevInfo, err := d.dcMgrOfLogicID(logicID).DcGetVDeviceInfo(logicID)
 	if err != nil {
+		d.countErr(key, err)
 		hwlog.RunLog.Debug(err)
-		return common.VirtualDevInfo{}, fmt.Errorf("get virtual device info failed, error is: %v "+
+		return common.VirtualDevInfo{}, fmt.Errorf("get virtual device info failed, error is: %w "+
 			"and vdev num is: %d", err, int32(cgoVDevInfo.TotalResource.VDevNum))
 	}
+	devType := d.devTypeOfLogicID(logicID)
 	for _, vDevInfo := range cgoVDevInfo.VDevInfo {
-		if !common.IsValidTemplateName(d.DevType, vDevInfo.QueryInfo.Name) {
+		if !common.IsValidTemplateName(devType, vDevInfo.QueryInfo.Name) {
 			return common.VirtualDevInfo{}, fmt.Errorf("vdevice id %d, it's template name is invalid: %s",
 				vDevInfo.VDevID, vDevInfo.QueryInfo.Name)
 		}
@@ -483,12 +660,21 @@ func (d *DeviceManager) GetVirtualDeviceInfo(logicID int32) (common.VirtualDevIn
 
 // DestroyVirtualDevice destroy virtual device
 func (d *DeviceManager) DestroyVirtualDevice(logicID int32, vDevID uint32) error {
-	return d.DcMgr.DcDestroyVDevice(logicID, vDevID)
+	return d.dcMgrOfLogicID(logicID).DcDestroyVDevice(logicID, vDevID)
 }
 
 // GetMcuPowerInfo get mcu power info for cardID
 func (d *DeviceManager) GetMcuPowerInfo(cardID int32) (float32, error) {
-	return d.DcMgr.DcGetMcuPowerInfo(cardID)
+	key := callKey{call: "GetMcuPowerInfo", id: cardID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.RetError, err
+	}
+	power, err := d.dcMgrOfCard(cardID).DcGetMcuPowerInfo(cardID)
+	if err != nil {
+		d.recordErr(key, err)
+		return common.RetError, err
+	}
+	return power, nil
 }
 
 // GetCardIDDeviceID get cardID and deviceID by logicID
@@ -498,7 +684,7 @@ func (d *DeviceManager) GetCardIDDeviceID(logicID int32) (int32, int32, error) {
 
 // GetProductType get product type by cardID and deviceID
 func (d *DeviceManager) GetProductType(cardID, deviceID int32) (string, error) {
-	return d.DcMgr.DcGetProductType(cardID, deviceID)
+	return d.dcMgrOfCard(cardID).DcGetProductType(cardID, deviceID)
 }
 
 // GetAllProductType get all product type
@@ -563,26 +749,44 @@ func (d *DeviceManager) GetNpuWorkMode() string {
 
 // SetDeviceReset reset spec device
 func (d *DeviceManager) SetDeviceReset(cardID, deviceID int32) error {
-	return d.DcMgr.DcSetDeviceReset(cardID, deviceID)
+	if err := d.dcMgrOfCard(cardID).DcSetDeviceReset(cardID, deviceID); err != nil {
+		return err
+	}
+	// the reset may reset other chips than the requested one, so the cached errors of all the chips are cleared
+	d.clearUnsupportedCalls()
+	return nil
 }
 
 // GetDeviceBootStatus get device boot status
 func (d *DeviceManager) GetDeviceBootStatus(logicID int32) (int, error) {
-	return d.DcMgr.DcGetDeviceBootStatus(logicID)
+	key := callKey{call: "GetDeviceBootStatus", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.RetError, err
+	}
+	bootStatus, err := d.dcMgrOfLogicID(logicID).DcGetDeviceBootStatus(logicID)
+	if err != nil {
+		d.recordErr(key, err)
+		return common.RetError, err
+	}
+	return bootStatus, nil
 }
 
 // GetDeviceAllErrorCode get npu device all error code
 func (d *DeviceManager) GetDeviceAllErrorCode(logicID int32) (int32, []int64, error) {
+	key := callKey{call: "GetDeviceAllErrorCode", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.RetError, nil, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.RetError, nil, fmt.Errorf("failed to get cardID in get device error code by logicID(%d)",
-			logicID)
+		d.recordErr(key, err)
+		return common.RetError, nil, fmt.Errorf("failed to get cardID in get device error code by logicID(%d), %w",
+			logicID, err)
 	}
-	errCount, errCodes, err := d.DcMgr.DcGetDeviceAllErrorCode(cardID, deviceID)
+	errCount, errCodes, err := d.dcMgrOfCard(cardID).DcGetDeviceAllErrorCode(cardID, deviceID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.RetError, nil, fmt.Errorf("failed to get device error code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.RetError, nil, fmt.Errorf("failed to get device error code by logicID(%d), %w", logicID, err)
 	}
 	return errCount, errCodes, nil
 }
@@ -601,7 +805,7 @@ func (d *DeviceManager) SubscribeDeviceFaultEvent(logicID int32) error {
 			return fmt.Errorf("failed to get cardID in subscribe device error code by logicID(%d)", logicID)
 		}
 	}
-	if err := d.DcMgr.DcSubscribeDeviceFaultEvent(cardID, deviceID); err != nil {
+	if err := d.dcMgrOfCard(cardID).DcSubscribeDeviceFaultEvent(cardID, deviceID); err != nil {
 		hwlog.RunLog.Error(err)
 		return fmt.Errorf("failed to subscribe device error code by logicID(%d)", logicID)
 	}
@@ -619,96 +823,174 @@ func (d *DeviceManager) SetFaultEventCallFunc(businessFunc func(common.DevFaultI
 
 // GetDieID return die id by dcmi die type, vdie id or ndie id
 func (d *DeviceManager) GetDieID(logicID int32, dcmiDieType dcmi.DcmiDieType) (string, error) {
+	key := callKey{call: "GetDieID", id: logicID, sub: int32(dcmiDieType)}
+	if err := d.unsupportedErr(key); err != nil {
+		return "", err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return "", fmt.Errorf("failed to get cardID in get device error code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return "", fmt.Errorf("failed to get cardID in get device error code by logicID(%d), %w", logicID, err)
 	}
-
-	return d.DcMgr.DcGetDieID(cardID, deviceID, dcmiDieType)
+	dieID, err := d.dcMgrOfCard(cardID).DcGetDieID(cardID, deviceID, dcmiDieType)
+	if err != nil {
+		d.recordErr(key, err)
+		return "", err
+	}
+	return dieID, nil
 }
 
 // GetDevProcessInfo get process and process memory in device side
 func (d *DeviceManager) GetDevProcessInfo(logicID int32) (*common.DevProcessInfo, error) {
+	key := callKey{call: "GetDevProcessInfo", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return nil, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return nil, fmt.Errorf("failed to get cardID in get device error code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return nil, fmt.Errorf("failed to get cardID in get device error code by logicID(%d), %w", logicID, err)
 	}
-
-	return d.DcMgr.DcGetDevProcessInfo(cardID, deviceID)
+	procInfo, err := d.dcMgrOfCard(cardID).DcGetDevProcessInfo(cardID, deviceID)
+	if err != nil {
+		d.recordErr(key, err)
+		return nil, err
+	}
+	return procInfo, nil
 }
 
 // GetPCIeBusInfo pcie bus info
 func (d *DeviceManager) GetPCIeBusInfo(logicID int32) (string, error) {
+	key := callKey{call: "GetPCIeBusInfo", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return "", err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return "", fmt.Errorf("failed to get cardID in get device error code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return "", fmt.Errorf("failed to get cardID in get device error code by logicID(%d), %w", logicID, err)
 	}
-
-	return d.DcMgr.DcGetPCIeBusInfo(cardID, deviceID)
+	pcieInfo, err := d.dcMgrOfCard(cardID).DcGetPCIeBusInfo(cardID, deviceID)
+	if err != nil {
+		d.recordErr(key, err)
+		return "", err
+	}
+	return pcieInfo, nil
 }
 
 // GetBoardInfo return board info of device
 func (d *DeviceManager) GetBoardInfo(logicID int32) (common.BoardInfo, error) {
+	key := callKey{call: "GetBoardInfo", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.BoardInfo{}, err
+	}
 	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
 	if err != nil {
-		hwlog.RunLog.Error(err)
-		return common.BoardInfo{}, fmt.Errorf("failed to get cardID in get device error code by logicID(%d)", logicID)
+		d.recordErr(key, err)
+		return common.BoardInfo{}, fmt.Errorf("failed to get cardID in get device error code by logicID(%d), %w",
+			logicID, err)
 	}
-
-	return d.DcMgr.DcGetDeviceBoardInfo(cardID, deviceID)
+	boardInfo, err := d.dcMgrOfCard(cardID).DcGetDeviceBoardInfo(cardID, deviceID)
+	if err != nil {
+		d.recordErr(key, err)
+		return common.BoardInfo{}, err
+	}
+	return boardInfo, nil
 }
 
-// SetIsTrainingCard identifies whether it is a training card according to the usage of card
+// SetIsTrainingCard identifies whether each card is a training card according to the device type and the usage of
+// the card, the cards of a heterogeneous server may differ
 func (d *DeviceManager) SetIsTrainingCard() error {
-	devType := d.GetDevType()
-	if strings.HasPrefix(devType, common.Ascend310) {
-		d.isTrainingCard = false
-		return nil
-	}
-
-	boardInfo := common.BoardInfo{}
 	cardNum, cardList, err := d.GetCardList()
 	if err != nil || cardNum == 0 {
 		hwlog.RunLog.Errorf("failed to get card list when set 'IsTrainingCard' err: %v", err)
 		return err
 	}
+	trainingCards := make(map[int32]bool, len(cardList))
 	for _, cardID := range cardList {
-		devNum, err := d.GetDeviceNumInCard(cardID)
+		trainingCards[cardID] = d.isTrainingCardOf(cardID)
+	}
+	d.trainingCards = trainingCards
+	return nil
+}
+
+func (d *DeviceManager) isTrainingCardOf(cardID int32) bool {
+	cardType := d.GetCardType(cardID)
+	if strings.HasPrefix(cardType, common.Ascend310) {
+		return false
+	}
+	if cardType != common.Ascend910B {
+		return true
+	}
+	devNum, err := d.GetDeviceNumInCard(cardID)
+	if err != nil || devNum == 0 {
+		hwlog.RunLog.Warnf("not found device on card %d when set 'IsTrainingCard', error: %v", cardID, err)
+		return true
+	}
+	for devID := int32(0); devID < devNum; devID++ {
+		boardInfo, err := d.dcMgrOfCard(cardID).DcGetDeviceBoardInfo(cardID, devID)
 		if err != nil {
-			hwlog.RunLog.Warnf("get device num by cardID(%d) failed when set 'IsTrainingCard', error: %v", cardID, err)
+			hwlog.RunLog.Warnf("get board info by card %d deviceID %d failed, err: %v", cardID, devID, err)
 			continue
 		}
-		if devNum == 0 {
-			hwlog.RunLog.Warnf("not found device on card %d when set 'IsTrainingCard',", cardID)
-			continue
-		}
-
-		for devID := int32(0); devID < devNum; devID++ {
-			boardInfo, err = d.DcMgr.DcGetDeviceBoardInfo(cardID, devID)
-			if err != nil {
-				hwlog.RunLog.Warnf("get board info by card %d deviceID %d failed, err: %v", cardID, devID, err)
-				continue
-			}
-			break
-		}
-		if err == nil {
-			break
-		}
+		return boardInfo.BoardId != common.A300IA2BoardId
 	}
+	return true
+}
 
-	if devType == common.Ascend910B && boardInfo.BoardId == common.A300IA2BoardId {
-		d.isTrainingCard = false
-		return nil
+// IsTrainingCard return true if the card which the chip belongs to is a training card
+func (d *DeviceManager) IsTrainingCard(logicID int32) bool {
+	cardID, _, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		hwlog.RunLog.Debugf("get cardID by logicID(%d) failed, err: %v", logicID, err)
+		return false
 	}
+	if isTraining, ok := d.trainingCards[cardID]; ok {
+		return isTraining
+	}
+	return d.isTrainingCardOf(cardID)
+}
 
-	d.isTrainingCard = true
-	return nil
+// GetHccsInfo return the HCCS lanes of the chip which have carried traffic, only Ascend910 and Ascend910B are
+// supported, the query blocks for common.HccsProfilingTime to sample the bandwidth
+func (d *DeviceManager) GetHccsInfo(logicID int32) (common.HccsInfo, error) {
+	key := callKey{call: "GetHccsInfo", id: logicID}
+	if err := d.unsupportedErr(key); err != nil {
+		return common.HccsInfo{}, err
+	}
+	cardID, deviceID, err := d.DcMgr.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		d.recordErr(key, err)
+		return common.HccsInfo{}, fmt.Errorf("failed to get cardID in get hccs info by logicID(%d), %w", logicID, err)
+	}
+	if cardType := d.GetCardType(cardID); cardType != common.Ascend910 && cardType != common.Ascend910B {
+		return common.HccsInfo{}, fmt.Errorf("hccs of %s is %w", cardType, common.ErrNotSupported)
+	}
+	laneInfo, err := d.dcMgrOfCard(cardID).DcGetHccsLaneInfo(cardID, deviceID)
+	if err != nil {
+		d.recordErr(key, err)
+		return common.HccsInfo{}, err
+	}
+	return usedHccsLanes(laneInfo), nil
 }
 
-// IsTrainingCard return true if it is a training card
-func (d *DeviceManager) IsTrainingCard() bool {
-	return d.isTrainingCard
+// usedHccsLanes returns the lanes which have carried traffic since the chip started, dcmi does not tell which lanes
+// are wired to the other chips, the lanes which are not wired keep zero counters
+func usedHccsLanes(laneInfo common.HccsLaneInfo) common.HccsInfo {
+	hccsInfo := common.HccsInfo{}
+	for i := 0; i < common.HccsMaxPcsNum; i++ {
+		if laneInfo.TxCnt[i] == 0 && laneInfo.RxCnt[i] == 0 {
+			continue
+		}
+		hccsInfo.Lanes = append(hccsInfo.Lanes, common.HccsLaneStat{
+			Lane:        int32(i),
+			TxBandwidth: laneInfo.TxBandwidth[i],
+			RxBandwidth: laneInfo.RxBandwidth[i],
+			TxCnt:       laneInfo.TxCnt[i],
+			RxCnt:       laneInfo.RxCnt[i],
+			CrcErrCnt:   laneInfo.CrcErrCnt[i],
+			RetryCnt:    laneInfo.RetryCnt[i],
+		})
+	}
+	return hccsInfo
 }
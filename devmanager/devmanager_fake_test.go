@@ -0,0 +1,114 @@
+//go:build fakedcmi
+
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package devmanager this for device driver manager
+package devmanager
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+// TestAutoInitWithFakeDriver test AutoInit with the fake dcmi driver, run by go test -tags fakedcmi
+func TestAutoInitWithFakeDriver(t *testing.T) {
+	if err := hwlog.InitRunLogger(&hwlog.LogConfig{OnlyToStdout: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(FakeTopologyEnv, "fakedcmi/testdata/topology.yaml")
+	resetDeviceManager()
+	dmgr, err := AutoInit("")
+	assert.Nil(t, err)
+	if assert.NotNil(t, dmgr) {
+		assert.Equal(t, common.Ascend910B, dmgr.GetDevType())
+		assert.Equal(t, []string{"Atlas 800T A2"}, dmgr.GetProductTypeArray())
+		count, err := dmgr.GetDeviceCount()
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), count)
+	}
+}
+
+// TestAutoInitWithMixedCards test AutoInit on a heterogeneous server, each card is handled by its own device type
+func TestAutoInitWithMixedCards(t *testing.T) {
+	if err := hwlog.InitRunLogger(&hwlog.LogConfig{OnlyToStdout: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(FakeTopologyEnv, "fakedcmi/testdata/topology_mixed.yaml")
+	resetDeviceManager()
+	dmgr, err := AutoInit("")
+	if !assert.Nil(t, err) {
+		return
+	}
+	t.Run("should recognize type of each card when cards are mixed", func(t *testing.T) {
+		assert.Equal(t, common.Ascend310P, dmgr.GetDevType())
+		assert.Equal(t, common.Ascend310P, dmgr.GetCardType(0))
+		assert.Equal(t, common.Ascend910B, dmgr.GetCardType(1))
+		assert.Equal(t, "Atlas 300V Pro", dmgr.GetCardProductType(0))
+		assert.Equal(t, "Atlas 300T A2", dmgr.GetCardProductType(1))
+	})
+	t.Run("should return dev type when card is unknown", func(t *testing.T) {
+		assert.Equal(t, common.Ascend310P, dmgr.GetCardType(common.HiAIMaxCardNum))
+		assert.Equal(t, "", dmgr.GetCardProductType(common.HiAIMaxCardNum))
+	})
+	t.Run("should query ddr by card type when cards are mixed", func(t *testing.T) {
+		memInfo, err := dmgr.GetDeviceMemoryInfo(0)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(24576), memInfo.MemorySize)
+		memInfo, err = dmgr.GetDeviceMemoryInfo(1)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(0), memInfo.MemorySize)
+	})
+	t.Run("should identify training card of each chip when cards are mixed", func(t *testing.T) {
+		assert.False(t, dmgr.IsTrainingCard(0))
+		assert.True(t, dmgr.IsTrainingCard(1))
+	})
+	t.Run("should route chip calls to the driver of its card when cards are mixed", func(t *testing.T) {
+		assert.IsType(t, &A310PManager{}, dmgr.dcMgrOfLogicID(0))
+		assert.IsType(t, &A910Manager{}, dmgr.dcMgrOfLogicID(1))
+		_, err := dmgr.GetDeviceBootStatus(1)
+		assert.Nil(t, err)
+	})
+	t.Run("should query hccs by card type when cards are mixed", func(t *testing.T) {
+		_, err := dmgr.GetHccsInfo(0)
+		assert.NotNil(t, err)
+		_, err = dmgr.GetHccsInfo(1)
+		assert.Nil(t, err)
+	})
+	t.Run("should query power of 310P by mcu when cards are mixed", func(t *testing.T) {
+		power, err := dmgr.GetDevicePowerInfo(0)
+		assert.Nil(t, err)
+		assert.Equal(t, float32(72.5), power)
+		hbmInfo, err := dmgr.GetDeviceHbmInfo(0)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(0), hbmInfo.MemorySize)
+	})
+	t.Run("should retry not supported call after chip is reset", func(t *testing.T) {
+		key := callKey{call: "GetDeviceBootStatus", id: 0}
+		dmgr.countErr(key, common.ErrNotSupported)
+		assert.Nil(t, dmgr.SetDeviceReset(0, 0))
+		assert.Nil(t, dmgr.unsupportedErr(key))
+	})
+}
+
+// resetDeviceManager drops the global device manager, so that the next AutoInit loads the current topology
+func resetDeviceManager() {
+	devManager = nil
+	devManagerOnce = sync.Once{}
+}
@@ -39,6 +39,16 @@ func (d *DeviceManagerMock) GetDevType() string {
 	return common.Ascend910
 }
 
+// GetCardType return mock type
+func (d *DeviceManagerMock) GetCardType(cardID int32) string {
+	return common.Ascend910
+}
+
+// GetCardProductType return mock product type
+func (d *DeviceManagerMock) GetCardProductType(cardID int32) string {
+	return ""
+}
+
 // GetDeviceCount get npu device count
 func (d *DeviceManagerMock) GetDeviceCount() (int32, error) {
 	return 1, nil
@@ -147,7 +157,7 @@ func (d *DeviceManagerMock) GetDeviceLogicID(cardID, deviceID int32) (int32, err
 
 // GetDeviceIPAddress get device ip address
 func (d *DeviceManagerMock) GetDeviceIPAddress(logicID, ipType int32) (string, error) {
-	if ipType == 0 {
+	if ipType == common.IPAddrTypeV4 {
 		return "127.0.0.1", nil
 	}
 	return "::1", nil
@@ -237,6 +247,12 @@ func (d *DeviceManagerMock) GetBoardInfo(logicID int32) (common.BoardInfo, error
 	return common.BoardInfo{}, nil
 }
 
+// GetHccsInfo get hccs info
+func (d *DeviceManagerMock) GetHccsInfo(logicID int32) (common.HccsInfo, error) {
+	return common.HccsInfo{Lanes: []common.HccsLaneStat{{Lane: 0, TxBandwidth: 1, RxBandwidth: 1, TxCnt: 1,
+		RxCnt: 1}}}, nil
+}
+
 // GetProductTypeArray test for get product type array
 func (d *DeviceManagerMock) GetProductTypeArray() []string {
 	return []string{common.Atlas200ISoc}
@@ -246,6 +262,6 @@ func (d *DeviceManagerMock) SetIsTrainingCard() error {
 	return nil
 }
 
-func (d *DeviceManagerMock) IsTrainingCard() bool {
+func (d *DeviceManagerMock) IsTrainingCard(logicID int32) bool {
 	return true
 }
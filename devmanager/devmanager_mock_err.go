@@ -43,6 +43,16 @@ func (d *DeviceManagerMockErr) GetDevType() string {
 	return common.Ascend910
 }
 
+// GetCardType return mock type
+func (d *DeviceManagerMockErr) GetCardType(cardID int32) string {
+	return common.Ascend910
+}
+
+// GetCardProductType return mock product type
+func (d *DeviceManagerMockErr) GetCardProductType(cardID int32) string {
+	return ""
+}
+
 // GetDeviceCount get npu device count
 func (d *DeviceManagerMockErr) GetDeviceCount() (int32, error) {
 	return 1, errors.New(errorMsg)
@@ -238,6 +248,11 @@ func (d *DeviceManagerMockErr) GetBoardInfo(logicID int32) (common.BoardInfo, er
 	return common.BoardInfo{}, errors.New(errorMsg)
 }
 
+// GetHccsInfo get hccs info
+func (d *DeviceManagerMockErr) GetHccsInfo(logicID int32) (common.HccsInfo, error) {
+	return common.HccsInfo{}, errors.New(errorMsg)
+}
+
 // GetProductTypeArray test for get empty product type array
 func (d *DeviceManagerMockErr) GetProductTypeArray() []string {
 	return nil
@@ -247,6 +262,6 @@ func (d *DeviceManagerMockErr) SetIsTrainingCard() error {
 	return errors.New(errorMsg)
 }
 
-func (d *DeviceManagerMockErr) IsTrainingCard() bool {
+func (d *DeviceManagerMockErr) IsTrainingCard(logicID int32) bool {
 	return false
 }
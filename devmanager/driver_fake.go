@@ -0,0 +1,35 @@
+//go:build fakedcmi
+
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package devmanager this for device driver manager
+package devmanager
+
+import (
+	"os"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager/dcmi"
+	"huawei.com/npu-exporter/v5/devmanager/fakedcmi"
+)
+
+// FakeTopologyEnv the environment variable of the yaml topology file which is loaded by the fake dcmi driver
+const FakeTopologyEnv = "NPU_FAKE_DCMI_TOPOLOGY"
+
+// newDcDriver creates the fake dcmi driver, the binary built with the fakedcmi tag never loads libdcmi.so
+func newDcDriver() dcmi.DcDriverInterface {
+	hwlog.RunLog.Warnf("the fake dcmi driver is used, topology file: %s", os.Getenv(FakeTopologyEnv))
+	return &fakedcmi.Driver{Path: os.Getenv(FakeTopologyEnv)}
+}
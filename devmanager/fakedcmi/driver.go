@@ -0,0 +1,636 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package fakedcmi a pure go fake of dcmi.DcDriverInterface driven by a yaml topology, for testing without npu
+package fakedcmi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"huawei.com/npu-exporter/v5/devmanager/common"
+	"huawei.com/npu-exporter/v5/devmanager/dcmi"
+)
+
+// chipRef locates a chip in the topology
+type chipRef struct {
+	card     *Card
+	deviceID int32
+	chip     *Chip
+}
+
+// Driver the fake dcmi driver, it is wrapped in A910Manager, A310PManager and A310Manager like the common dcmi
+// driver, the results of libdcmi.so which differ between chips are emulated by the chip name
+type Driver struct {
+	// Path the yaml topology file, it is loaded by DcInit if Topology is nil
+	Path string
+	// Topology the fake npu topology
+	Topology *Topology
+
+	lock      sync.RWMutex
+	byLogicID map[int32]chipRef
+	faultFunc func(common.DevFaultInfo)
+	// ResetCount the number of times each chip is reset, the key is the logic id
+	ResetCount map[int32]int
+}
+
+// NewDriver creates a fake driver with the topology
+func NewDriver(topo *Topology) *Driver {
+	d := &Driver{Topology: topo}
+	d.index()
+	return d
+}
+
+func (d *Driver) index() {
+	d.byLogicID = make(map[int32]chipRef, common.HiAIMaxDeviceNum)
+	d.ResetCount = make(map[int32]int, common.HiAIMaxDeviceNum)
+	if d.Topology == nil {
+		return
+	}
+	for i := range d.Topology.Cards {
+		card := &d.Topology.Cards[i]
+		for devID := range card.Chips {
+			d.byLogicID[card.Chips[devID].LogicID] = chipRef{card: card, deviceID: int32(devID),
+				chip: &card.Chips[devID]}
+		}
+	}
+}
+
+// fail returns the injected error of the method, the chip level failure takes precedence
+func (d *Driver) fail(method string, chip *Chip) error {
+	if chip != nil {
+		if msg, ok := chip.Failures[method]; ok {
+			return failure(method, msg)
+		}
+	}
+	if d.Topology == nil {
+		return errors.New("the fake dcmi is not initialized")
+	}
+	if msg, ok := d.Topology.Failures[method]; ok {
+		return failure(method, msg)
+	}
+	return nil
+}
+
+// failure returns the error of the failure message, the message "error code N" is returned as the dcmi error code N
+func failure(method, msg string) error {
+	var code int32
+	if _, err := fmt.Sscanf(msg, "error code %d", &code); err == nil {
+		return fmt.Errorf("%s failed, error code: %w", method, common.DriverError(code))
+	}
+	return fmt.Errorf("%s failed: %s", method, msg)
+}
+
+func (d *Driver) getCard(cardID int32) (*Card, error) {
+	if d.Topology == nil {
+		return nil, errors.New("the fake dcmi is not initialized")
+	}
+	for i := range d.Topology.Cards {
+		if d.Topology.Cards[i].ID == cardID {
+			return &d.Topology.Cards[i], nil
+		}
+	}
+	return nil, fmt.Errorf("card %d: %w", cardID, common.ErrDeviceNotFound)
+}
+
+func (d *Driver) getChip(method string, cardID, deviceID int32) (*Card, *Chip, error) {
+	if !common.IsValidCardIDAndDeviceID(cardID, deviceID) {
+		return nil, nil, common.InvalidErrorf("cardID(%d) or deviceID(%d) is invalid", cardID, deviceID)
+	}
+	card, err := d.getCard(cardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if int(deviceID) >= len(card.Chips) {
+		return nil, nil, fmt.Errorf("device %d in card %d: %w", deviceID, cardID, common.ErrDeviceNotFound)
+	}
+	chip := &card.Chips[deviceID]
+	return card, chip, d.fail(method, chip)
+}
+
+func (d *Driver) getChipByLogicID(method string, logicID int32) (chipRef, error) {
+	if !common.IsValidLogicIDOrPhyID(logicID) {
+		return chipRef{}, common.InvalidErrorf("input invalid logicID: %d", logicID)
+	}
+	ref, ok := d.byLogicID[logicID]
+	if !ok {
+		return chipRef{}, fmt.Errorf("logicID(%d): %w", logicID, common.ErrDeviceNotFound)
+	}
+	return ref, d.fail(method, ref.chip)
+}
+
+func chipType(chip *Chip) string {
+	return common.GetDeviceTypeByChipName(chip.Name)
+}
+
+// DcInit loads the topology from the path if it is not set
+func (d *Driver) DcInit() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.Topology == nil {
+		if d.Path == "" {
+			return errors.New("the fake dcmi topology is not set")
+		}
+		topo, err := LoadTopology(d.Path)
+		if err != nil {
+			return err
+		}
+		d.Topology = topo
+	}
+	d.index()
+	return d.fail("DcInit", nil)
+}
+
+// DcShutDown does nothing but failure injection
+func (d *Driver) DcShutDown() error {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.fail("DcShutDown", nil)
+}
+
+// DcGetDeviceCount get the number of chips
+func (d *Driver) DcGetDeviceCount() (int32, error) {
+	devNum, _, err := d.DcGetLogicIDList()
+	if err != nil {
+		return common.RetError, fmt.Errorf("get device count failed, error: %v", err)
+	}
+	return devNum, nil
+}
+
+// DcGetLogicIDList get the logic ids of all chips in the order of cards
+func (d *Driver) DcGetLogicIDList() (int32, []int32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if err := d.fail("DcGetLogicIDList", nil); err != nil {
+		return common.RetError, nil, err
+	}
+	var logicIDs []int32
+	for _, card := range d.Topology.Cards {
+		for _, chip := range card.Chips {
+			logicIDs = append(logicIDs, chip.LogicID)
+		}
+	}
+	return int32(len(logicIDs)), logicIDs, nil
+}
+
+// DcGetCardList get the ids of all cards
+func (d *Driver) DcGetCardList() (int32, []int32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if err := d.fail("DcGetCardList", nil); err != nil {
+		return common.RetError, nil, err
+	}
+	cardList := make([]int32, 0, len(d.Topology.Cards))
+	for _, card := range d.Topology.Cards {
+		cardList = append(cardList, card.ID)
+	}
+	return int32(len(cardList)), cardList, nil
+}
+
+// DcGetDeviceNumInCard get the number of chips in the card
+func (d *Driver) DcGetDeviceNumInCard(cardID int32) (int32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	card, err := d.getCard(cardID)
+	if err != nil {
+		return common.RetError, err
+	}
+	if err := d.fail("DcGetDeviceNumInCard", nil); err != nil {
+		return common.RetError, err
+	}
+	return int32(len(card.Chips)), nil
+}
+
+// DcGetDeviceLogicID get the logic id by card id and device id
+func (d *Driver) DcGetDeviceLogicID(cardID, deviceID int32) (int32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceLogicID", cardID, deviceID)
+	if err != nil {
+		return common.RetError, err
+	}
+	return chip.LogicID, nil
+}
+
+// DcGetCardIDDeviceID get the card id and device id by logic id
+func (d *Driver) DcGetCardIDDeviceID(logicID int32) (int32, int32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	ref, err := d.getChipByLogicID("DcGetCardIDDeviceID", logicID)
+	if err != nil {
+		return common.RetError, common.RetError, err
+	}
+	return ref.card.ID, ref.deviceID, nil
+}
+
+// DcGetPhysicIDFromLogicID get the physic id by logic id
+func (d *Driver) DcGetPhysicIDFromLogicID(logicID int32) (int32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	ref, err := d.getChipByLogicID("DcGetPhysicIDFromLogicID", logicID)
+	if err != nil {
+		return common.RetError, err
+	}
+	return ref.chip.PhyID, nil
+}
+
+// DcGetLogicIDFromPhysicID get the logic id by physic id
+func (d *Driver) DcGetLogicIDFromPhysicID(physicID int32) (int32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	for _, ref := range d.byLogicID {
+		if ref.chip.PhyID == physicID {
+			return ref.chip.LogicID, d.fail("DcGetLogicIDFromPhysicID", ref.chip)
+		}
+	}
+	return common.RetError, fmt.Errorf("physicID(%d): %w", physicID, common.ErrDeviceNotFound)
+}
+
+// DcGetDeviceHealth get the health code of the chip
+func (d *Driver) DcGetDeviceHealth(cardID, deviceID int32) (int32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceHealth", cardID, deviceID)
+	if err != nil {
+		return common.RetError, err
+	}
+	return chip.Health, nil
+}
+
+// DcGetDeviceNetWorkHealth get the network health code of the chip
+func (d *Driver) DcGetDeviceNetWorkHealth(cardID, deviceID int32) (uint32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceNetWorkHealth", cardID, deviceID)
+	if err != nil {
+		return common.UnRetError, err
+	}
+	return chip.NetworkHealth, nil
+}
+
+// DcGetDeviceUtilizationRate get the utilization of the core type, vector core is only for Ascend310P
+func (d *Driver) DcGetDeviceUtilizationRate(cardID, deviceID int32, devType common.DeviceType) (int32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceUtilizationRate", cardID, deviceID)
+	if err != nil {
+		return common.RetError, err
+	}
+	if devType == common.VectorCore && chipType(chip) != common.Ascend310P {
+		return common.RetError, fmt.Errorf("utilization type %d of %s is %w", devType, chip.Name,
+			common.ErrNotSupported)
+	}
+	for name, t := range utilizationTypes {
+		if t != devType {
+			continue
+		}
+		rate, ok := chip.Utilization[name]
+		if !ok {
+			break
+		}
+		if rate < 0 || !common.IsValidUtilizationRate(uint32(rate)) {
+			return common.RetError, fmt.Errorf("get wrong device (cardID: %d, deviceID: %d) utilization rate: %d",
+				cardID, deviceID, rate)
+		}
+		return rate, nil
+	}
+	return common.RetError, fmt.Errorf("utilization type %d of device (cardID: %d, deviceID: %d) is not set",
+		devType, cardID, deviceID)
+}
+
+// DcGetDeviceTemperature get the temperature of the chip
+func (d *Driver) DcGetDeviceTemperature(cardID, deviceID int32) (int32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceTemperature", cardID, deviceID)
+	if err != nil {
+		return common.RetError, err
+	}
+	return chip.Temperature, nil
+}
+
+// DcGetDeviceVoltage get the voltage of the chip
+func (d *Driver) DcGetDeviceVoltage(cardID, deviceID int32) (float32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceVoltage", cardID, deviceID)
+	if err != nil {
+		return common.RetError, err
+	}
+	return chip.Voltage, nil
+}
+
+// DcGetDevicePowerInfo get the power of the chip, A310PManager queries the power of Ascend310P by the mcu instead
+func (d *Driver) DcGetDevicePowerInfo(cardID, deviceID int32) (float32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDevicePowerInfo", cardID, deviceID)
+	if err != nil {
+		return common.RetError, err
+	}
+	return chip.Power, nil
+}
+
+// DcGetMcuPowerInfo get the mcu power of the card, only Ascend310P supports
+func (d *Driver) DcGetMcuPowerInfo(cardID int32) (float32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	card, err := d.getCard(cardID)
+	if err != nil {
+		return common.RetError, err
+	}
+	if len(card.Chips) == 0 || chipType(&card.Chips[0]) != common.Ascend310P {
+		return 0, nil
+	}
+	return card.McuPower, d.fail("DcGetMcuPowerInfo", &card.Chips[0])
+}
+
+// DcGetDeviceFrequency get the frequency of the type
+func (d *Driver) DcGetDeviceFrequency(cardID, deviceID int32, devType common.DeviceType) (uint32, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceFrequency", cardID, deviceID)
+	if err != nil {
+		return common.UnRetError, err
+	}
+	for name, t := range frequencyTypes {
+		if freq, ok := chip.Frequency[name]; ok && t == devType {
+			return freq, nil
+		}
+	}
+	return common.UnRetError, fmt.Errorf("frequency type %d of device (cardID: %d, deviceID: %d) is not set",
+		devType, cardID, deviceID)
+}
+
+// DcGetMemoryInfo get the ddr memory of the chip
+func (d *Driver) DcGetMemoryInfo(cardID, deviceID int32) (*common.MemoryInfo, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetMemoryInfo", cardID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if chip.Memory == nil {
+		return nil, fmt.Errorf("memory of device (cardID: %d, deviceID: %d) is not set", cardID, deviceID)
+	}
+	return &common.MemoryInfo{
+		MemorySize:      chip.Memory.Size,
+		MemoryAvailable: chip.Memory.Available,
+		Frequency:       chip.Memory.Frequency,
+		Utilization:     chip.Memory.Utilization,
+	}, nil
+}
+
+// DcGetHbmInfo get the hbm of the chip, only Ascend910 and Ascend910B have hbm
+func (d *Driver) DcGetHbmInfo(cardID, deviceID int32) (*common.HbmInfo, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetHbmInfo", cardID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	devType := chipType(chip)
+	if devType != common.Ascend910 && devType != common.Ascend910B {
+		return &common.HbmInfo{}, nil
+	}
+	if chip.Hbm == nil {
+		return nil, fmt.Errorf("hbm of device (cardID: %d, deviceID: %d) is not set", cardID, deviceID)
+	}
+	return &common.HbmInfo{
+		MemorySize:        chip.Hbm.Size,
+		Frequency:         chip.Hbm.Frequency,
+		Usage:             chip.Hbm.Usage,
+		Temp:              chip.Hbm.Temperature,
+		BandWidthUtilRate: chip.Hbm.BandwidthUtil,
+	}, nil
+}
+
+// DcGetDeviceErrorCode get the error count and the first error code of the chip
+func (d *Driver) DcGetDeviceErrorCode(cardID, deviceID int32) (int32, int64, error) {
+	errCount, errCodes, err := d.getErrorCodes("DcGetDeviceErrorCode", cardID, deviceID)
+	if err != nil || errCount == 0 {
+		return errCount, common.InvalidVal, err
+	}
+	return errCount, errCodes[0], nil
+}
+
+// DcGetDeviceAllErrorCode get the error count and all error codes of the chip
+func (d *Driver) DcGetDeviceAllErrorCode(cardID, deviceID int32) (int32, []int64, error) {
+	return d.getErrorCodes("DcGetDeviceAllErrorCode", cardID, deviceID)
+}
+
+func (d *Driver) getErrorCodes(method string, cardID, deviceID int32) (int32, []int64, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip(method, cardID, deviceID)
+	if err != nil {
+		return common.RetError, nil, err
+	}
+	if len(chip.ErrorCodes) > common.MaxErrorCodeCount {
+		return common.RetError, nil, fmt.Errorf("get wrong errorcode count, card_id(%d) and device_id(%d), "+
+			"errorcode count: %d", cardID, deviceID, len(chip.ErrorCodes))
+	}
+	errCodes := make([]int64, len(chip.ErrorCodes))
+	copy(errCodes, chip.ErrorCodes)
+	return int32(len(errCodes)), errCodes, nil
+}
+
+// DcGetChipInfo get the chip info
+func (d *Driver) DcGetChipInfo(cardID, deviceID int32) (*common.ChipInfo, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetChipInfo", cardID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	return &common.ChipInfo{Type: chip.Type, Name: chip.Name, Version: chip.Version}, nil
+}
+
+// DcGetDeviceIPAddress get the ip address of the chip
+func (d *Driver) DcGetDeviceIPAddress(cardID, deviceID, ipType int32) (string, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceIPAddress", cardID, deviceID)
+	if err != nil {
+		return "", err
+	}
+	ip := chip.IP
+	if ipType == common.IPAddrTypeV6 {
+		ip = chip.IPv6
+	}
+	if ip == "" {
+		return "", fmt.Errorf("ip address of type %d of device (cardID: %d, deviceID: %d) is not set", ipType,
+			cardID, deviceID)
+	}
+	return ip, nil
+}
+
+// DcGetDieID get the die id of the chip
+func (d *Driver) DcGetDieID(cardID, deviceID int32, dcmiDieType dcmi.DcmiDieType) (string, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDieID", cardID, deviceID)
+	if err != nil {
+		return "", err
+	}
+	switch dcmiDieType {
+	case dcmi.VDIE:
+		return chip.VDieID, nil
+	case dcmi.NDIE:
+		return chip.NDieID, nil
+	default:
+		return "", fmt.Errorf("dcmi die type can only be one of %d or %d", dcmi.VDIE, dcmi.NDIE)
+	}
+}
+
+// DcGetPCIeBusInfo get the pcie bus info of the chip
+func (d *Driver) DcGetPCIeBusInfo(cardID, deviceID int32) (string, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetPCIeBusInfo", cardID, deviceID)
+	if err != nil {
+		return "", err
+	}
+	return chip.PCIeBusInfo, nil
+}
+
+// DcGetProductType get the product type of the card
+func (d *Driver) DcGetProductType(cardID, deviceID int32) (string, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	card, _, err := d.getChip("DcGetProductType", cardID, deviceID)
+	if err != nil {
+		return "", err
+	}
+	return card.ProductType, nil
+}
+
+// DcGetNpuWorkMode get the work mode of the card
+func (d *Driver) DcGetNpuWorkMode(cardID int32) (int, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	card, err := d.getCard(cardID)
+	if err != nil {
+		return common.RetError, err
+	}
+	return card.WorkMode, d.fail("DcGetNpuWorkMode", nil)
+}
+
+// DcSetDeviceReset counts the reset of the chip
+func (d *Driver) DcSetDeviceReset(cardID, deviceID int32) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, chip, err := d.getChip("DcSetDeviceReset", cardID, deviceID)
+	if err != nil {
+		return err
+	}
+	d.ResetCount[chip.LogicID]++
+	return nil
+}
+
+// DcGetDeviceBootStatus get the boot status of the chip
+func (d *Driver) DcGetDeviceBootStatus(logicID int32) (int, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	ref, err := d.getChipByLogicID("DcGetDeviceBootStatus", logicID)
+	if err != nil {
+		return common.RetError, err
+	}
+	return ref.chip.BootStatus, nil
+}
+
+// DcSubscribeDeviceFaultEvent does nothing but failure injection, the fault events are sent by SendFaultEvent
+func (d *Driver) DcSubscribeDeviceFaultEvent(cardID, deviceID int32) error {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if d.faultFunc == nil {
+		return errors.New("callFunc is invalid, can't start subscribe")
+	}
+	if cardID == common.SubscribeAllDevice && deviceID == common.SubscribeAllDevice {
+		return d.fail("DcSubscribeDeviceFaultEvent", nil)
+	}
+	_, _, err := d.getChip("DcSubscribeDeviceFaultEvent", cardID, deviceID)
+	return err
+}
+
+// DcSetFaultEventCallFunc set the fault event callback
+func (d *Driver) DcSetFaultEventCallFunc(businessFunc func(common.DevFaultInfo)) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.faultFunc = businessFunc
+}
+
+// SendFaultEvent sends the fault event to the callback set by DcSetFaultEventCallFunc
+func (d *Driver) SendFaultEvent(event common.DevFaultInfo) {
+	d.lock.RLock()
+	faultFunc := d.faultFunc
+	d.lock.RUnlock()
+	if faultFunc != nil {
+		faultFunc(event)
+	}
+}
+
+// DcGetDevProcessInfo get the processes running on the chip
+func (d *Driver) DcGetDevProcessInfo(cardID, deviceID int32) (*common.DevProcessInfo, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDevProcessInfo", cardID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	info := &common.DevProcessInfo{ProcNum: int32(len(chip.Processes))}
+	for _, proc := range chip.Processes {
+		info.DevProcArray = append(info.DevProcArray, common.DevProcInfo{Pid: proc.Pid, MemUsage: proc.MemUsage})
+	}
+	return info, nil
+}
+
+// DcGetDeviceBoardInfo get the board info of the chip
+func (d *Driver) DcGetDeviceBoardInfo(cardID, deviceID int32) (common.BoardInfo, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceBoardInfo", cardID, deviceID)
+	if err != nil {
+		return common.BoardInfo{}, err
+	}
+	return common.BoardInfo{BoardId: chip.BoardID, SlotId: uint32(deviceID)}, nil
+}
+
+// DcGetHccsLaneInfo get the HCCS lanes of the chip, the lanes which are not in the topology are zero
+func (d *Driver) DcGetHccsLaneInfo(cardID, deviceID int32) (common.HccsLaneInfo, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetHccsLaneInfo", cardID, deviceID)
+	if err != nil {
+		return common.HccsLaneInfo{}, err
+	}
+	if len(chip.Hccs) == 0 {
+		return common.HccsLaneInfo{}, fmt.Errorf("hccs of device (cardID: %d, deviceID: %d) is not set", cardID,
+			deviceID)
+	}
+	laneInfo := common.HccsLaneInfo{}
+	for _, lane := range chip.Hccs {
+		if lane.Lane < 0 || lane.Lane >= common.HccsMaxPcsNum {
+			return common.HccsLaneInfo{}, fmt.Errorf("hccs lane %d is out of range", lane.Lane)
+		}
+		laneInfo.TxBandwidth[lane.Lane] = lane.TxBandwidth
+		laneInfo.RxBandwidth[lane.Lane] = lane.RxBandwidth
+		laneInfo.TxCnt[lane.Lane] = lane.TxCnt
+		laneInfo.RxCnt[lane.Lane] = lane.RxCnt
+		laneInfo.CrcErrCnt[lane.Lane] = lane.CrcErrCnt
+		laneInfo.RetryCnt[lane.Lane] = lane.RetryCnt
+	}
+	return laneInfo, nil
+}
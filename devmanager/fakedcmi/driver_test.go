@@ -0,0 +1,200 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package fakedcmi_test test the fake dcmi driver through the device manager
+package fakedcmi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+	"huawei.com/npu-exporter/v5/devmanager/fakedcmi"
+)
+
+func init() {
+	hwLogConfig := hwlog.LogConfig{OnlyToStdout: true}
+	if err := hwlog.InitRunLogger(&hwLogConfig, nil); err != nil {
+		panic(err)
+	}
+}
+
+func newFakeDeviceManager(t *testing.T) (*devmanager.DeviceManager, *fakedcmi.Driver) {
+	driver := &fakedcmi.Driver{Path: "testdata/topology.yaml"}
+	if err := driver.DcInit(); err != nil {
+		t.Fatal(err)
+	}
+	return &devmanager.DeviceManager{DcMgr: driver, DevType: common.Ascend910B}, driver
+}
+
+// TestFakeDriver test the device manager with the fake dcmi driver
+func TestFakeDriver(t *testing.T) {
+	dmgr, driver := newFakeDeviceManager(t)
+	t.Run("should return chips of all cards when topology is loaded", func(t *testing.T) {
+		num, logicIDs, err := dmgr.GetDeviceList()
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), num)
+		assert.Equal(t, []int32{0, 1}, logicIDs)
+		cardID, deviceID, err := dmgr.GetCardIDDeviceID(1)
+		assert.Nil(t, err)
+		assert.Equal(t, []int32{1, 0}, []int32{cardID, deviceID})
+	})
+	t.Run("should return chip values when dcmi works normally", func(t *testing.T) {
+		util, err := dmgr.GetDeviceUtilizationRate(0, common.AICpu)
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(5), util)
+		freq, err := dmgr.GetDeviceFrequency(0, common.AICoreRatedFreq)
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(1800), freq)
+		hbm, err := dmgr.GetDeviceHbmInfo(0)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(65536), hbm.MemorySize)
+		_, errCode, err := dmgr.GetDeviceErrorCode(0)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0x80E01801), errCode)
+	})
+	t.Run("should return ip address of the type when ip address is set", func(t *testing.T) {
+		ip, err := dmgr.GetDeviceIPAddress(0, common.IPAddrTypeV4)
+		assert.Nil(t, err)
+		assert.Equal(t, "192.168.100.10", ip)
+		ip, err = dmgr.GetDeviceIPAddress(0, common.IPAddrTypeV6)
+		assert.Nil(t, err)
+		assert.Equal(t, "fe80::10", ip)
+	})
+	t.Run("should return the lanes which carried traffic when hccs is set", func(t *testing.T) {
+		hccsInfo, err := dmgr.GetHccsInfo(0)
+		assert.Nil(t, err)
+		assert.Equal(t, []common.HccsLaneStat{{Lane: 1, TxBandwidth: 15.5, RxBandwidth: 14.5, TxCnt: 1000, RxCnt: 900,
+			CrcErrCnt: 2, RetryCnt: 1}}, hccsInfo.Lanes)
+		_, err = dmgr.GetHccsInfo(1)
+		assert.NotNil(t, err)
+	})
+	t.Run("should return error when failure is injected", func(t *testing.T) {
+		_, err := dmgr.GetDeviceHbmInfo(1)
+		assert.NotNil(t, err)
+	})
+	t.Run("should return error when vector core is queried on 910B", func(t *testing.T) {
+		_, err := dmgr.GetDeviceUtilizationRate(0, common.VectorCore)
+		assert.NotNil(t, err)
+	})
+	t.Run("should create and destroy vNPU when free ai core is enough", func(t *testing.T) {
+		out, err := dmgr.CreateVirtualDevice(1, common.CgoCreateVDevRes{TemplateName: "vir05_1c_16g"})
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(101), out.VDevID)
+		_, err = dmgr.CreateVirtualDevice(1, common.CgoCreateVDevRes{TemplateName: "vir10_3c_32g"})
+		assert.NotNil(t, err)
+		info, err := dmgr.GetVirtualDeviceInfo(1)
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(2), info.TotalResource.VDevNum)
+		assert.Equal(t, float32(5), info.FreeResource.Computing.Aic)
+		assert.Nil(t, dmgr.DestroyVirtualDevice(1, out.VDevID))
+	})
+	t.Run("should count reset when chip is reset", func(t *testing.T) {
+		assert.Nil(t, dmgr.SetDeviceReset(1, 0))
+		assert.Equal(t, 1, driver.ResetCount[1])
+	})
+}
+
+// TestFakeDriver310P test the chip type specific behaviors of Ascend310P
+func TestFakeDriver310P(t *testing.T) {
+	topo, err := fakedcmi.ParseTopology([]byte("cards: [{id: 0, mcuPower: 60, chips: [{logicID: 0, phyID: 0, " +
+		"name: 310P3, power: 10, utilization: {vectorcore: 20}}, {logicID: 1, phyID: 1, name: 310P3}]}]"))
+	assert.Nil(t, err)
+	dmgr := &devmanager.DeviceManager{DcMgr: &devmanager.A310PManager{DcDriverInterface: fakedcmi.NewDriver(topo)},
+		DevType: common.Ascend310P}
+	t.Run("should return mcu power of card when chip is 310P", func(t *testing.T) {
+		power, err := dmgr.GetDevicePowerInfo(1)
+		assert.Nil(t, err)
+		assert.Equal(t, float32(60), power)
+	})
+	t.Run("should return vector core utilization when chip is 310P", func(t *testing.T) {
+		util, err := dmgr.GetDeviceUtilizationRate(0, common.VectorCore)
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(20), util)
+	})
+}
+
+// TestParseTopology test ParseTopology with invalid topology
+func TestParseTopology(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "should return error when chip name is unsupported",
+			data: "cards: [{id: 0, chips: [{logicID: 0, phyID: 0, name: unknown}]}]"},
+		{name: "should return error when logic id is duplicate", data: "cards: [{id: 0, chips: " +
+			"[{logicID: 0, phyID: 0, name: 310P3}, {logicID: 0, phyID: 1, name: 310P3}]}]"},
+		{name: "should return error when utilization type is unknown",
+			data: "cards: [{id: 0, chips: [{logicID: 0, phyID: 0, name: 310P3, utilization: {gpu: 1}}]}]"},
+		{name: "should return error when vdevice id is out of range",
+			data: "cards: [{id: 0, chips: [{logicID: 0, phyID: 0, name: 310P3, vnpus: [{vdevID: 1}]}]}]"},
+		{name: "should return error when vdevice id is the upper bound",
+			data: "cards: [{id: 0, chips: [{logicID: 0, phyID: 0, name: 310P3, vnpus: [{vdevID: 1124}]}]}]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := fakedcmi.ParseTopology([]byte(tt.data))
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+// TestDcmiErrors test the typed errors of the device manager and the cache of the not supported calls
+func TestDcmiErrors(t *testing.T) {
+	topo, err := fakedcmi.ParseTopology([]byte("cards: [{id: 0, chips: [{logicID: 0, phyID: 0, name: 910B3, " +
+		"failures: {DcGetHbmInfo: error code -8005, DcGetDeviceTemperature: error code -8255, " +
+		"DcGetDeviceVoltage: error code -8018}}]}]"))
+	assert.Nil(t, err)
+	dmgr := &devmanager.DeviceManager{DcMgr: fakedcmi.NewDriver(topo), DevType: common.Ascend910B}
+	t.Run("should return driver error code when dcmi returns unclassified error code", func(t *testing.T) {
+		_, err := dmgr.GetDeviceHbmInfo(0)
+		var driverErr common.DriverError
+		assert.True(t, errors.As(err, &driverErr))
+		assert.Equal(t, int32(-8005), driverErr.Code())
+		assert.Equal(t, common.ErrClassDriver, common.ErrorClass(err))
+	})
+	t.Run("should return device busy when resource is occupied", func(t *testing.T) {
+		_, err := dmgr.GetDeviceVoltage(0)
+		assert.True(t, errors.Is(err, common.ErrDeviceBusy))
+	})
+	t.Run("should return invalid value when logic id is invalid", func(t *testing.T) {
+		_, err := dmgr.GetDeviceHealth(-1)
+		assert.True(t, errors.Is(err, common.ErrInvalidValue))
+	})
+	t.Run("should return device not found when chip does not exist", func(t *testing.T) {
+		_, err := dmgr.GetChipInfo(1)
+		assert.True(t, errors.Is(err, common.ErrDeviceNotFound))
+		assert.Equal(t, common.ErrClassNotFound, common.ErrorClass(err))
+	})
+	t.Run("should not call dcmi again when call is not supported", func(t *testing.T) {
+		key := devmanager.DcmiErrorKey{Call: "GetDeviceTemperature", Class: common.ErrClassNotSupported}
+		before := devmanager.GetDcmiErrorCounts()[key]
+		_, err := dmgr.GetDeviceTemperature(0)
+		assert.True(t, errors.Is(err, common.ErrNotSupported))
+		delete(topo.Cards[0].Chips[0].Failures, "DcGetDeviceTemperature")
+		_, err = dmgr.GetDeviceTemperature(0)
+		assert.True(t, errors.Is(err, common.ErrNotSupported))
+		assert.Equal(t, before+1, devmanager.GetDcmiErrorCounts()[key])
+	})
+	t.Run("should return not supported when vector core is queried on 910B", func(t *testing.T) {
+		_, err := dmgr.GetDeviceUtilizationRate(0, common.VectorCore)
+		assert.True(t, errors.Is(err, common.ErrNotSupported))
+		_, err = dmgr.GetDeviceUtilizationRate(0, common.AICore)
+		assert.False(t, errors.Is(err, common.ErrNotSupported))
+	})
+}
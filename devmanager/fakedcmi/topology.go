@@ -0,0 +1,219 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package fakedcmi a pure go fake of dcmi.DcDriverInterface driven by a yaml topology, for testing without npu
+package fakedcmi
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"huawei.com/npu-exporter/v5/common-utils/utils"
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+var (
+	utilizationTypes = map[string]common.DeviceType{
+		"aicore":     common.AICore,
+		"aicpu":      common.AICpu,
+		"ctrlcpu":    common.CtrlCpu,
+		"vectorcore": common.VectorCore,
+	}
+	frequencyTypes = map[string]common.DeviceType{
+		"memory":      common.MemoryFreq,
+		"ctrlcpu":     common.CtrlCpuFreq,
+		"hbm":         common.HBMFreq,
+		"aicore":      common.AICoreCurrentFreq,
+		"aicoreRated": common.AICoreRatedFreq,
+	}
+)
+
+// Topology the fake npu topology of a server
+type Topology struct {
+	Cards []Card `yaml:"cards"`
+	// Failures the error messages of the calls which fail on all chips, the key is the method name of
+	// dcmi.DcDriverInterface, such as DcGetHbmInfo, the message "error code N" is returned as the dcmi error code N,
+	// such as "error code -8255" for not supported
+	Failures map[string]string `yaml:"failures"`
+}
+
+// Card the fake npu card, the device id of a chip is its index in the card
+type Card struct {
+	ID          int32  `yaml:"id"`
+	ProductType string `yaml:"productType"`
+	// WorkMode 0 is AMP mode and 1 is SMP mode
+	WorkMode int     `yaml:"workMode"`
+	McuPower float32 `yaml:"mcuPower"`
+	Chips    []Chip  `yaml:"chips"`
+}
+
+// Chip the fake npu chip
+type Chip struct {
+	LogicID       int32   `yaml:"logicID"`
+	PhyID         int32   `yaml:"phyID"`
+	Name          string  `yaml:"name"`
+	Type          string  `yaml:"type"`
+	Version       string  `yaml:"version"`
+	Health        int32   `yaml:"health"`
+	NetworkHealth uint32  `yaml:"networkHealth"`
+	Temperature   int32   `yaml:"temperature"`
+	Voltage       float32 `yaml:"voltage"`
+	Power         float32 `yaml:"power"`
+	// Utilization the key is one of aicore, aicpu, ctrlcpu and vectorcore
+	Utilization map[string]int32 `yaml:"utilization"`
+	// Frequency the key is one of memory, ctrlcpu, hbm, aicore and aicoreRated
+	Frequency   map[string]uint32 `yaml:"frequency"`
+	Memory      *Memory           `yaml:"memory"`
+	Hbm         *Hbm              `yaml:"hbm"`
+	ErrorCodes  []int64           `yaml:"errorCodes"`
+	IP          string            `yaml:"ip"`
+	IPv6        string            `yaml:"ipv6"`
+	PCIeBusInfo string            `yaml:"pcieBusInfo"`
+	VDieID      string            `yaml:"vdieID"`
+	NDieID      string            `yaml:"ndieID"`
+	BootStatus  int               `yaml:"bootStatus"`
+	BoardID     uint32            `yaml:"boardID"`
+	Processes   []Process         `yaml:"processes"`
+	// AiCore the number of ai cores which can be split into vNPUs
+	AiCore float32 `yaml:"aiCore"`
+	VNPUs  []VNPU  `yaml:"vnpus"`
+	// Hccs the HCCS lanes which have statistics, the other lanes are zero
+	Hccs []HccsLane `yaml:"hccs"`
+	// Failures the error messages of the calls which fail on this chip, the key is the method name, the message
+	// "error code N" is returned as the dcmi error code N
+	Failures map[string]string `yaml:"failures"`
+}
+
+// HccsLane the fake HCCS lane, the lane is the index of the dcmi arrays, the unit of bandwidth is MB/s
+type HccsLane struct {
+	Lane        int     `yaml:"lane"`
+	TxBandwidth float64 `yaml:"txBandwidth"`
+	RxBandwidth float64 `yaml:"rxBandwidth"`
+	TxCnt       uint64  `yaml:"txCnt"`
+	RxCnt       uint64  `yaml:"rxCnt"`
+	CrcErrCnt   uint64  `yaml:"crcErrCnt"`
+	RetryCnt    uint64  `yaml:"retryCnt"`
+}
+
+// Memory the fake ddr memory, the unit of size is MB
+type Memory struct {
+	Size        uint64 `yaml:"size"`
+	Available   uint64 `yaml:"available"`
+	Frequency   uint32 `yaml:"frequency"`
+	Utilization uint32 `yaml:"utilization"`
+}
+
+// Hbm the fake hbm, the unit of size is MB
+type Hbm struct {
+	Size          uint64 `yaml:"size"`
+	Usage         uint64 `yaml:"usage"`
+	Frequency     uint32 `yaml:"frequency"`
+	Temperature   int32  `yaml:"temperature"`
+	BandwidthUtil uint32 `yaml:"bandwidthUtil"`
+}
+
+// Process the fake process running on the chip
+type Process struct {
+	Pid      int32   `yaml:"pid"`
+	MemUsage float64 `yaml:"memUsage"`
+}
+
+// VNPU the fake virtual npu split from the chip
+type VNPU struct {
+	VDevID     uint32  `yaml:"vdevID"`
+	Template   string  `yaml:"template"`
+	AiCore     float32 `yaml:"aiCore"`
+	MemorySize uint64  `yaml:"memorySize"`
+	AiCoreRate uint32  `yaml:"aiCoreRate"`
+	UsedMemory uint64  `yaml:"usedMemory"`
+}
+
+// LoadTopology reads the topology from the yaml file
+func LoadTopology(path string) (*Topology, error) {
+	data, err := utils.ReadLimitBytes(path, utils.Size10M)
+	if err != nil {
+		return nil, fmt.Errorf("read fake dcmi topology failed: %v", err)
+	}
+	return ParseTopology(data)
+}
+
+// ParseTopology parses and validates the yaml topology
+func ParseTopology(data []byte) (*Topology, error) {
+	topo := &Topology{}
+	if err := yaml.Unmarshal(data, topo); err != nil {
+		return nil, fmt.Errorf("unmarshal fake dcmi topology failed: %v", err)
+	}
+	if err := topo.validate(); err != nil {
+		return nil, err
+	}
+	return topo, nil
+}
+
+func (t *Topology) validate() error {
+	if len(t.Cards) > common.HiAIMaxCardNum {
+		return fmt.Errorf("the number of cards is more than %d", common.HiAIMaxCardNum)
+	}
+	cardIDs := make(map[int32]struct{}, len(t.Cards))
+	logicIDs := make(map[int32]struct{}, len(t.Cards))
+	phyIDs := make(map[int32]struct{}, len(t.Cards))
+	for _, card := range t.Cards {
+		if _, ok := cardIDs[card.ID]; ok {
+			return fmt.Errorf("duplicate card id %d", card.ID)
+		}
+		cardIDs[card.ID] = struct{}{}
+		if len(card.Chips) > common.HiAIMaxDeviceNum {
+			return fmt.Errorf("the number of chips in card %d is more than %d", card.ID, common.HiAIMaxDeviceNum)
+		}
+		for devID, chip := range card.Chips {
+			if !common.IsValidCardIDAndDeviceID(card.ID, int32(devID)) {
+				return fmt.Errorf("cardID(%d) or deviceID(%d) is invalid", card.ID, devID)
+			}
+			if _, ok := logicIDs[chip.LogicID]; ok || !common.IsValidLogicIDOrPhyID(chip.LogicID) {
+				return fmt.Errorf("invalid or duplicate logic id %d", chip.LogicID)
+			}
+			logicIDs[chip.LogicID] = struct{}{}
+			if _, ok := phyIDs[chip.PhyID]; ok || !common.IsValidLogicIDOrPhyID(chip.PhyID) {
+				return fmt.Errorf("invalid or duplicate physic id %d", chip.PhyID)
+			}
+			phyIDs[chip.PhyID] = struct{}{}
+			if err := chip.validate(); err != nil {
+				return fmt.Errorf("invalid chip %d: %v", chip.LogicID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Chip) validate() error {
+	if common.GetDeviceTypeByChipName(c.Name) == "" {
+		return fmt.Errorf("unsupported chip name %s", c.Name)
+	}
+	for name := range c.Utilization {
+		if _, ok := utilizationTypes[name]; !ok {
+			return fmt.Errorf("unknown utilization type %s", name)
+		}
+	}
+	for name := range c.Frequency {
+		if _, ok := frequencyTypes[name]; !ok {
+			return fmt.Errorf("unknown frequency type %s", name)
+		}
+	}
+	for _, vnpu := range c.VNPUs {
+		if !common.IsValidVDevID(vnpu.VDevID) {
+			return fmt.Errorf("vdevice id %d is out of range", vnpu.VDevID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package fakedcmi a pure go fake of dcmi.DcDriverInterface driven by a yaml topology, for testing without npu
+package fakedcmi
+
+import (
+	"fmt"
+
+	"huawei.com/npu-exporter/v5/devmanager/common"
+)
+
+func (d *Driver) getVNPU(chip *Chip, vDevID uint32) (*VNPU, error) {
+	for i := range chip.VNPUs {
+		if chip.VNPUs[i].VDevID == vDevID {
+			return &chip.VNPUs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("vdevice %d is not found in chip %d", vDevID, chip.LogicID)
+}
+
+// allocVDevID returns the smallest vdevice id which is not used by any chip
+func (d *Driver) allocVDevID() (uint32, error) {
+	used := make(map[uint32]struct{}, common.HiAIMaxDeviceNum)
+	for _, ref := range d.byLogicID {
+		for _, vnpu := range ref.chip.VNPUs {
+			used[vnpu.VDevID] = struct{}{}
+		}
+	}
+	for id := uint32(common.MinVDevID); id <= common.MaxVDevID; id++ {
+		if _, ok := used[id]; !ok {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("no vdevice id is available")
+}
+
+func freeAiCore(chip *Chip) float32 {
+	free := chip.AiCore
+	for _, vnpu := range chip.VNPUs {
+		free -= vnpu.AiCore
+	}
+	return free
+}
+
+// DcCreateVirtualDevice creates the vNPU by the template on the chip
+func (d *Driver) DcCreateVirtualDevice(cardID, deviceID int32, vDevInfo common.CgoCreateVDevRes) (common.
+	CgoCreateVDevOut, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, chip, err := d.getChip("DcCreateVirtualDevice", cardID, deviceID)
+	if err != nil {
+		return common.CgoCreateVDevOut{}, err
+	}
+	aiCore, err := common.GetTemplateAiCore(chipType(chip), vDevInfo.TemplateName)
+	if err != nil {
+		return common.CgoCreateVDevOut{}, err
+	}
+	if free := freeAiCore(chip); aiCore > free {
+		return common.CgoCreateVDevOut{}, fmt.Errorf("the free ai core %v of chip %d is less than %v", free,
+			chip.LogicID, aiCore)
+	}
+	vDevID, err := d.allocVDevID()
+	if err != nil {
+		return common.CgoCreateVDevOut{}, err
+	}
+	chip.VNPUs = append(chip.VNPUs, VNPU{VDevID: vDevID, Template: vDevInfo.TemplateName, AiCore: aiCore})
+	return common.CgoCreateVDevOut{VDevID: vDevID, VfgID: vDevInfo.VfgID}, nil
+}
+
+// DcSetDestroyVirtualDevice destroys the vNPU on the chip
+func (d *Driver) DcSetDestroyVirtualDevice(cardID, deviceID int32, vDevID uint32) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, chip, err := d.getChip("DcSetDestroyVirtualDevice", cardID, deviceID)
+	if err != nil {
+		return err
+	}
+	for i, vnpu := range chip.VNPUs {
+		if vnpu.VDevID == vDevID {
+			chip.VNPUs = append(chip.VNPUs[:i], chip.VNPUs[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("vdevice %d is not found in chip %d", vDevID, chip.LogicID)
+}
+
+// DcGetDeviceVDevResource get the resource of the vNPU
+func (d *Driver) DcGetDeviceVDevResource(cardID, deviceID int32, vDevID uint32) (common.CgoVDevQueryStru, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceVDevResource", cardID, deviceID)
+	if err != nil {
+		return common.CgoVDevQueryStru{}, err
+	}
+	return d.getVDevResource(chip, vDevID)
+}
+
+func (d *Driver) getVDevResource(chip *Chip, vDevID uint32) (common.CgoVDevQueryStru, error) {
+	vnpu, err := d.getVNPU(chip, vDevID)
+	if err != nil {
+		return common.CgoVDevQueryStru{}, err
+	}
+	return common.CgoVDevQueryStru{
+		VDevID: vnpu.VDevID,
+		QueryInfo: common.CgoVDevQueryInfo{
+			Name:      vnpu.Template,
+			Computing: common.CgoComputingResource{Aic: vnpu.AiCore, MemorySize: vnpu.MemorySize},
+		},
+	}, nil
+}
+
+// DcGetDeviceTotalResource get the total resource of the chip
+func (d *Driver) DcGetDeviceTotalResource(cardID, deviceID int32) (common.CgoSocTotalResource, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceTotalResource", cardID, deviceID)
+	if err != nil {
+		return common.CgoSocTotalResource{}, err
+	}
+	return getTotalResource(chip), nil
+}
+
+func getTotalResource(chip *Chip) common.CgoSocTotalResource {
+	total := common.CgoSocTotalResource{
+		VDevNum:   uint32(len(chip.VNPUs)),
+		Computing: common.CgoComputingResource{Aic: chip.AiCore},
+	}
+	for _, vnpu := range chip.VNPUs {
+		total.VDevID = append(total.VDevID, vnpu.VDevID)
+	}
+	return total
+}
+
+// DcGetDeviceFreeResource get the free resource of the chip
+func (d *Driver) DcGetDeviceFreeResource(cardID, deviceID int32) (common.CgoSocFreeResource, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetDeviceFreeResource", cardID, deviceID)
+	if err != nil {
+		return common.CgoSocFreeResource{}, err
+	}
+	return common.CgoSocFreeResource{Computing: common.CgoComputingResource{Aic: freeAiCore(chip)}}, nil
+}
+
+// DcGetVDevActivityInfo get the activity of the vNPU
+func (d *Driver) DcGetVDevActivityInfo(cardID, deviceID int32, vDevID uint32) (common.VDevActivityInfo, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcGetVDevActivityInfo", cardID, deviceID)
+	if err != nil {
+		return common.VDevActivityInfo{}, err
+	}
+	return d.getVDevActivity(chip, vDevID)
+}
+
+func (d *Driver) getVDevActivity(chip *Chip, vDevID uint32) (common.VDevActivityInfo, error) {
+	vnpu, err := d.getVNPU(chip, vDevID)
+	if err != nil {
+		return common.VDevActivityInfo{}, err
+	}
+	return common.VDevActivityInfo{
+		VDevID:         vnpu.VDevID,
+		VDevAiCoreRate: vnpu.AiCoreRate,
+		VDevTotalMem:   vnpu.MemorySize,
+		VDevUsedMem:    vnpu.UsedMemory,
+		IsVirtualDev:   true,
+	}, nil
+}
+
+// DcVGetDeviceInfo get the total, free and vNPU resources of the chip
+func (d *Driver) DcVGetDeviceInfo(cardID, deviceID int32) (common.VirtualDevInfo, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	_, chip, err := d.getChip("DcVGetDeviceInfo", cardID, deviceID)
+	if err != nil {
+		return common.VirtualDevInfo{}, err
+	}
+	info := common.VirtualDevInfo{
+		TotalResource: getTotalResource(chip),
+		FreeResource:  common.CgoSocFreeResource{Computing: common.CgoComputingResource{Aic: freeAiCore(chip)}},
+	}
+	for _, vnpu := range chip.VNPUs {
+		resource, err := d.getVDevResource(chip, vnpu.VDevID)
+		if err != nil {
+			return common.VirtualDevInfo{}, err
+		}
+		info.VDevInfo = append(info.VDevInfo, resource)
+		activity, err := d.getVDevActivity(chip, vnpu.VDevID)
+		if err != nil {
+			activity = common.VDevActivityInfo{VDevID: vnpu.VDevID, IsVirtualDev: true, ActivityUnavailable: true}
+		}
+		activity.VDevAiCore = float64(vnpu.AiCore)
+		info.VDevActivityInfo = append(info.VDevActivityInfo, activity)
+	}
+	return info, nil
+}
+
+// DcCreateVDevice creates the vNPU by logic id
+func (d *Driver) DcCreateVDevice(logicID int32, vDevInfo common.CgoCreateVDevRes) (common.CgoCreateVDevOut,
+	error) {
+	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		return common.CgoCreateVDevOut{}, fmt.Errorf("get card id and device id failed, error is: %v", err)
+	}
+	return d.DcCreateVirtualDevice(cardID, deviceID, vDevInfo)
+}
+
+// DcGetVDeviceInfo get the virtual device info by logic id
+func (d *Driver) DcGetVDeviceInfo(logicID int32) (common.VirtualDevInfo, error) {
+	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		return common.VirtualDevInfo{}, fmt.Errorf("get card id and device id failed, error is: %v", err)
+	}
+	return d.DcVGetDeviceInfo(cardID, deviceID)
+}
+
+// DcDestroyVDevice destroys the vNPU by logic id
+func (d *Driver) DcDestroyVDevice(logicID int32, vDevID uint32) error {
+	cardID, deviceID, err := d.DcGetCardIDDeviceID(logicID)
+	if err != nil {
+		return fmt.Errorf("get card id and device id failed, error is: %v", err)
+	}
+	return d.DcSetDestroyVirtualDevice(cardID, deviceID, vDevID)
+}
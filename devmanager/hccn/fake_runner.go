@@ -0,0 +1,75 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hccn this for npu hccn info
+package hccn
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FakeRunner the Runner returning the canned outputs, for testing without hccn_tool
+type FakeRunner struct {
+	// Outputs the canned outputs, the key is all the args joined by space, such as "-i 0 -link -g", or the
+	// subcommand, such as -link, the former takes precedence
+	Outputs map[string]string
+	// Errors the errors to return, the keys are the same as Outputs
+	Errors map[string]error
+
+	lock  sync.Mutex
+	calls [][]string
+}
+
+// Run returns the canned output of the args
+func (f *FakeRunner) Run(ctx context.Context, args ...string) (string, error) {
+	f.lock.Lock()
+	f.calls = append(f.calls, args)
+	f.lock.Unlock()
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	for _, key := range []string{strings.Join(args, " "), subcommand(args)} {
+		if err, ok := f.Errors[key]; ok {
+			return "", err
+		}
+		if out, ok := f.Outputs[key]; ok {
+			return out, nil
+		}
+	}
+	return "", fmt.Errorf("no canned output for hccn_tool %s", strings.Join(args, " "))
+}
+
+// Calls returns the args of all runs
+func (f *FakeRunner) Calls() [][]string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([][]string(nil), f.calls...)
+}
+
+// subcommand returns the subcommand of the args, such as -link of "-i 0 -link -g"
+func subcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-i":
+			i++
+		case "-g", "-s":
+		default:
+			return args[i]
+		}
+	}
+	return ""
+}
@@ -16,30 +16,19 @@
 package hccn
 
 import (
-	"bytes"
-	"os/exec"
+	"context"
 	"strconv"
-	"strings"
 
 	"huawei.com/npu-exporter/v5/common-utils/hwlog"
-	"huawei.com/npu-exporter/v5/common-utils/utils"
 	"huawei.com/npu-exporter/v5/devmanager/common"
+	"huawei.com/npu-exporter/v5/devmanager/hccn/parser"
 )
 
 const (
-	space   = " "
-	newLine = "\n"
-	colon   = ":"
-
 	// LinkUp npu interface up
-	LinkUp string = "UP"
+	LinkUp = parser.LinkUp
 	// LinkDown npu interface down
-	LinkDown string = "DOWN"
-
-	opticalPartLen = 2
-	secondIndex    = 2
-	linkStatusPart = 3
-	base64         = 64
+	LinkDown = parser.LinkDown
 
 	cardHealthy = 0
 
@@ -47,230 +36,148 @@ const (
 	abnormalCode = 0
 )
 
-func hccnToolGetInfo(args ...string) (string, error) {
-	const hccn_tool = "/usr/local/Ascend/driver/tools/hccn_tool"
-	if _, err := utils.CheckPath(hccn_tool); err != nil {
-		return "", err
-	}
-	var stdout, stderr bytes.Buffer
-	cmd := exec.Command(hccn_tool, args...)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	err := cmd.Run()
-	if err != nil {
-		return "", err
-	}
+// Tool gets the npu network information by running hccn_tool and parsing its output, all the queries are by the
+// physic id of the chip
+type Tool struct {
+	ctx    context.Context
+	runner Runner
+}
 
-	return string(stdout.Bytes()), nil
+// NewTool returns the Tool which runs hccn_tool by the runner for every query, the runs are canceled when ctx is done
+func NewTool(ctx context.Context, runner Runner) *Tool {
+	return &Tool{ctx: ctx, runner: runner}
 }
 
-// GetNPULinkStatus exec "hccn_tool -i * -link -g" to get link status
-func GetNPULinkStatus(phyID int32) string {
-	args := []string{"-i", strconv.Itoa(int(phyID)), "-link", "-g"}
+// GetLinkStatus exec "hccn_tool -i * -link -g" to get link status
+func (c *Tool) GetLinkStatus(phyID int32) (string, error) {
 	// command example: hccn_tool -i 0 -link -g
 	// success result example is: link status: DOWN
-	outStr, err := hccnToolGetInfo(args...)
+	outStr, err := c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-link", "-g")
 	hwlog.RunLog.Debugf("hccn_tool command exec result: %v", outStr)
 	if err != nil {
-		hwlog.RunLog.Errorf("get npu link status failed, %s", err)
-		return LinkDown
-	}
-	replacedStr := strings.ReplaceAll(outStr, newLine, "")
-	outArr := strings.Split(replacedStr, space)
-	if len(outArr) != linkStatusPart {
-		return LinkDown
+		return "", err
 	}
-
-	status := outArr[secondIndex]
-	hwlog.RunLog.Debugf("hccn_tool get npu link status: %s", status)
-	return status
+	return parser.ParseLinkStatus(outStr)
 }
 
-// GetNPULinkSpeed exec "hccn_tool -i * -speed -g" to get link speed
-func GetNPULinkSpeed(phyID int32) int {
-	args := []string{"-i", strconv.Itoa(int(phyID)), "-speed", "-g"}
+// GetLinkSpeed exec "hccn_tool -i * -speed -g" to get link speed
+func (c *Tool) GetLinkSpeed(phyID int32) (int, error) {
 	// command example: hccn_tool -i 0 -speed -g
 	// success result example is: Speed: 100000 Mb/s
-	outStr, err := hccnToolGetInfo(args...)
+	outStr, err := c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-speed", "-g")
 	if err != nil {
-		hwlog.RunLog.Errorf("get npu link speed failed, %s", err)
-		return abnormalCode
-	}
-	replacedStr := strings.ReplaceAll(outStr, newLine, "")
-	outArr := strings.Split(replacedStr, space)
-	if len(outArr) != linkStatusPart {
-		return abnormalCode
+		return abnormalCode, err
 	}
-	const midIndex = 1
-	speed, err := strconv.Atoi(outArr[midIndex])
-	if err != nil {
-		hwlog.RunLog.Errorf("covert speed from string failed: %s", err)
-		return abnormalCode
-	}
-
-	return speed
+	return parser.ParseLinkSpeed(outStr)
 }
 
-// GetNPULinkUpNum exec "hccn_tool -i * -link_stat -g" to get link up count
-func GetNPULinkUpNum(phyID int32) int {
-	args := []string{"-i", strconv.Itoa(int(phyID)), "-link_stat", "-g"}
+// GetLinkStat exec "hccn_tool -i * -link_stat -g" to get link up count
+func (c *Tool) GetLinkStat(phyID int32) (parser.LinkStat, error) {
 	// command example: hccn_tool -i 0 -link_stat -g
 	// success result include: [device x]link up count : y
-	outStr, err := hccnToolGetInfo(args...)
+	outStr, err := c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-link_stat", "-g")
 	if err != nil {
-		hwlog.RunLog.Errorf("get npu link stat failed, %s", err)
-		return 0
-	}
-
-	const (
-		linkUpArrLen = 6
-		linkUpStr    = "link up count"
-	)
-	linkUPCount := 0
-	lines := strings.Split(outStr, newLine)
-	for _, line := range lines {
-		if line == "" || !strings.Contains(line, linkUpStr) {
-			continue
-		}
-
-		linkUpArr := strings.Fields(line)
-		if len(linkUpArr) != linkUpArrLen {
-			return abnormalCode
-		}
-		if linkUPCount, err = strconv.Atoi(linkUpArr[linkUpArrLen-1]); err != nil {
-			hwlog.RunLog.Errorf("covert link up num from string failed: %s", err)
-			return abnormalCode
-		}
-		return linkUPCount
+		return parser.LinkStat{}, err
 	}
-
-	return abnormalCode
+	return parser.ParseLinkStat(outStr)
 }
 
-// GetNPUStatInfo exec "hccn_tool -i * -stat -g" to get stat info
-func GetNPUStatInfo(phyID int32) (map[string]int, error) {
-	args := []string{"-i", strconv.Itoa(int(phyID)), "-stat", "-g"}
+// GetStatInfo exec "hccn_tool -i * -stat -g" to get stat info
+func (c *Tool) GetStatInfo(phyID int32) (parser.StatInfo, error) {
 	// command example: hccn_tool -i 0 -stat -g
-	// success result include: [device x]link up count : y
-	outStr, err := hccnToolGetInfo(args...)
+	// success result include: mac_tx_mac_pause_num:0
+	outStr, err := c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-stat", "-g")
 	if err != nil {
-		hwlog.RunLog.Errorf("get npu stat inf failed, %s", err)
-		return nil, err
-	}
-	lines := strings.Split(outStr, newLine)
-	statInfoMap := make(map[string]int)
-	const statPartLen = 2
-	for _, line := range lines {
-		statParts := strings.Split(line, colon)
-		if len(statParts) != statPartLen || statParts[1] == "" {
-			continue
-		}
-		statNum, err := strconv.Atoi(statParts[1])
-		if err != nil {
-			hwlog.RunLog.Errorf("covert stat num of [%s] from string failed: %s", statParts[1], err)
-			continue
-		}
-		statInfoMap[statParts[0]] = statNum
+		return parser.StatInfo{}, err
 	}
-
-	return statInfoMap, nil
+	return parser.ParseStat(outStr)
 }
 
-// GetNPUOpticalInfo exec "hccn_tool -i * -optical -g" to get optical info
-func GetNPUOpticalInfo(phyID int32) (map[string]string, error) {
-	args := []string{"-i", strconv.Itoa(int(phyID)), "-optical", "-g"}
+// GetOpticalInfo exec "hccn_tool -i * -optical -g" to get optical info
+func (c *Tool) GetOpticalInfo(phyID int32) (parser.OpticalInfo, error) {
 	// command example: hccn_tool -i 0 -optical -g
-	// success result include: [device x]link up count : y
-	outStr, err := hccnToolGetInfo(args...)
+	// success result include: Tx_Power0 : 0.6012 mW
+	outStr, err := c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-optical", "-g")
 	if err != nil {
-		hwlog.RunLog.Errorf("get npu stat inf failed, %s", err)
-		return nil, err
+		return parser.OpticalInfo{}, err
 	}
-	lines := strings.Split(outStr, newLine)
-	opticalInfoMap := make(map[string]string)
-	for _, line := range lines {
-		opticalParts := strings.Split(line, colon)
-		if len(opticalParts) != opticalPartLen {
-			continue
-		}
-		opticalKey := strings.ReplaceAll(strings.TrimSpace(opticalParts[0]), space, "_")
-		opticalValue := strings.TrimSpace(opticalParts[1])
-		opticalInfoMap[opticalKey] = opticalValue
-	}
-
-	return opticalInfoMap, nil
+	return parser.ParseOptical(outStr)
 }
 
-// GetNPUInterfaceTraffic exec "hccn_tool -i * -bandwidth -g" to get bandwidth info
-func GetNPUInterfaceTraffic(phyID int32) (float64, float64, error) {
-	const (
-		noTraffic      = 0.00
-		trafficPartLen = 4
-		txStr          = "TX:"
-		rxStr          = "RX:"
-	)
-
-	args := []string{"-i", strconv.Itoa(int(phyID)), "-bandwidth", "-g"}
+// GetBandwidth exec "hccn_tool -i * -bandwidth -g" to get bandwidth info
+func (c *Tool) GetBandwidth(phyID int32) (parser.Bandwidth, error) {
 	// command example: hccn_tool -i 0 -bandwidth -g
 	// success result has two lines:
 	// Bandwidth TX: 0.00 MB/sec
 	// Bandwidth RX: 0.00 MB/sec
-	outStr, err := hccnToolGetInfo(args...)
+	outStr, err := c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-bandwidth", "-g")
 	hwlog.RunLog.Debugf("hccn_tool command exec result: %v", outStr)
 	if err != nil {
-		hwlog.RunLog.Errorf("get npu interface traffic failed, %s", err)
-		return noTraffic, noTraffic, err
+		return parser.Bandwidth{}, err
 	}
+	return parser.ParseBandwidth(outStr)
+}
 
-	var (
-		tx = 0.00
-		rx = 0.00
-	)
-
-	lines := strings.Split(outStr, newLine)
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
+// GetLLDP exec "hccn_tool -i * -lldp -g" to get the neighbor of the network port
+func (c *Tool) GetLLDP(phyID int32) (parser.LLDPInfo, error) {
+	// command example: hccn_tool -i 0 -lldp -g
+	// success result include the TLV sections, such as:
+	// Chassis ID TLV
+	//	MAC: 00:18:82:00:00:01
+	outStr, err := c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-lldp", "-g")
+	if err != nil {
+		return parser.LLDPInfo{}, err
+	}
+	return parser.ParseLLDP(outStr)
+}
 
-		trafficArr := strings.Split(line, space)
-		hwlog.RunLog.Debugf("npu bandwidth split as: %v", trafficArr)
-		if len(trafficArr) != trafficPartLen {
-			continue
-		}
-		if strings.Contains(line, txStr) {
-			tmpTx, err := strconv.ParseFloat(trafficArr[secondIndex], base64)
-			if err != nil {
-				hwlog.RunLog.Errorf("get float data from Bandwidth TX err: %s", err)
-				continue
-			}
-			tx = tmpTx
-		}
-		if strings.Contains(line, rxStr) {
-			tmpRx, err := strconv.ParseFloat(trafficArr[secondIndex], base64)
-			if err != nil {
-				hwlog.RunLog.Errorf("get float data from Bandwidth RX err: %s", err)
-				continue
-			}
-			rx = tmpRx
-		}
+// GetIPInfo exec "hccn_tool -i * -ip -g" and "hccn_tool -i * -gateway -g" to get ip info, the gateway is left
+// empty when it is not configured
+func (c *Tool) GetIPInfo(phyID int32) (parser.IPInfo, error) {
+	// command example: hccn_tool -i 0 -ip -g
+	// success result has two lines:
+	// ipaddr:192.168.100.101
+	// netmask:255.255.255.0
+	outStr, err := c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-ip", "-g")
+	if err != nil {
+		return parser.IPInfo{}, err
+	}
+	ipInfo, err := parser.ParseIP(outStr)
+	if err != nil {
+		return parser.IPInfo{}, err
+	}
+	// command example: hccn_tool -i 0 -gateway -g
+	// success result example is: default gateway:192.168.100.1, ifname:eth0
+	outStr, err = c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-gateway", "-g")
+	if err == nil {
+		ipInfo.Gateway, err = parser.ParseGateway(outStr)
 	}
-	return tx, rx, nil
+	if err != nil {
+		hwlog.RunLog.Debugf("get gateway of npu %d failed, %v", phyID, err)
+	}
+	return ipInfo, nil
 }
 
-// GetFloatDataFromStr get float data from string with space
-func GetFloatDataFromStr(str string) float64 {
-	dataParts := strings.Split(str, space)
-	if len(dataParts) != opticalPartLen {
-		return abnormalCode
+// GetARP exec "hccn_tool -i * -arp -g" to get the arp table
+func (c *Tool) GetARP(phyID int32) ([]parser.ARPEntry, error) {
+	// command example: hccn_tool -i 0 -arp -g
+	// success result include: ip_addr:192.168.100.102 mac_addr:78:b4:6a:01:02:03 dev_name:eth0
+	outStr, err := c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-arp", "-g")
+	if err != nil {
+		return nil, err
 	}
+	return parser.ParseARP(outStr)
+}
 
-	floatData, err := strconv.ParseFloat(dataParts[0], base64)
+// GetRoute exec "hccn_tool -i * -route -g" to get the route table
+func (c *Tool) GetRoute(phyID int32) ([]parser.Route, error) {
+	// command example: hccn_tool -i 0 -route -g
+	// success result include: 0.0.0.0 192.168.100.1 0.0.0.0 UG 0 0 0 eth0
+	outStr, err := c.runner.Run(c.ctx, "-i", strconv.Itoa(int(phyID)), "-route", "-g")
 	if err != nil {
-		hwlog.RunLog.Errorf("get float data from string err: %s", err)
-		return abnormalCode
+		return nil, err
 	}
-	return floatData
+	return parser.ParseRoute(outStr)
 }
 
 // GetHealthCode return union healthy code
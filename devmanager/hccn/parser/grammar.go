@@ -0,0 +1,173 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package parser parses the output of the hccn_tool subcommands into structured results
+package parser
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// devicePrefix the prefix of the lines printed by some driver releases, such as [device 0]
+	devicePrefix = regexp.MustCompile(`^\[\s*device\s*\d+\s*\]`)
+	// quantityPattern a number followed by an optional unit, such as 0.6012 mW, -2.21dBm, 100 Gb/s
+	quantityPattern = regexp.MustCompile(`^([-+]?(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d+)?)\s*(\S*)`)
+)
+
+// field a "key: value" line of the output
+type field struct {
+	// key the normalized key, lower case words joined by '_', such as link_up_count
+	key   string
+	value string
+}
+
+// fields splits the output into "key: value" lines, the grammar is shared by all subcommands:
+// the optional [device N] prefix is dropped, the key and value are separated by the first ':' or '=',
+// and the lines without separator are ignored
+func fields(out string) []field {
+	var result []field
+	for _, line := range lines(out) {
+		sep := strings.IndexAny(line, ":=")
+		if sep <= 0 {
+			continue
+		}
+		key := normalizeKey(line[:sep])
+		if key == "" {
+			continue
+		}
+		result = append(result, field{key: key, value: strings.TrimSpace(line[sep+1:])})
+	}
+	return result
+}
+
+// lines splits the output into the trimmed lines without the optional [device N] prefix, the empty lines are dropped
+func lines(out string) []string {
+	var result []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(devicePrefix.ReplaceAllString(strings.TrimSpace(line), ""))
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// normalizeKey lowers the key and joins its words by '_', so that "Tx Power0", "Tx_Power0" and "tx-power0" are
+// the same key
+func normalizeKey(key string) string {
+	words := strings.FieldsFunc(strings.ToLower(key), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "_")
+}
+
+// quantity a number with its unit as printed, the unit is case sensitive since MB/s and Mb/s differ
+type quantity struct {
+	value float64
+	unit  string
+}
+
+func parseQuantity(value string) (quantity, error) {
+	matches := quantityPattern.FindStringSubmatch(strings.TrimSpace(value))
+	const matchNum = 3
+	if len(matches) != matchNum {
+		return quantity{}, fmt.Errorf("%q is not a number", value)
+	}
+	number, err := strconv.ParseFloat(matches[1], bitSize)
+	if err != nil {
+		return quantity{}, fmt.Errorf("%q is not a number: %v", value, err)
+	}
+	return quantity{value: number, unit: matches[2]}, nil
+}
+
+const bitSize = 64
+
+// toMilliWatt converts the optical power to mW, the unit is mW when omitted
+func (q quantity) toMilliWatt() (float64, error) {
+	switch strings.ToLower(q.unit) {
+	case "mw", "":
+		return q.value, nil
+	case "uw":
+		return q.value / thousand, nil
+	case "w":
+		return q.value * thousand, nil
+	case "dbm":
+		return math.Pow(ten, q.value/ten), nil
+	default:
+		return 0, fmt.Errorf("unknown power unit %s", q.unit)
+	}
+}
+
+// toMilliVolt converts the voltage to mV, the unit is mV when omitted
+func (q quantity) toMilliVolt() (float64, error) {
+	switch strings.ToLower(q.unit) {
+	case "mv", "":
+		return q.value, nil
+	case "v":
+		return q.value * thousand, nil
+	default:
+		return 0, fmt.Errorf("unknown voltage unit %s", q.unit)
+	}
+}
+
+// toCelsius the temperature is in Celsius when the unit is omitted
+func (q quantity) toCelsius() (float64, error) {
+	switch strings.ToLower(q.unit) {
+	case "c", "℃", "°c", "":
+		return q.value, nil
+	default:
+		return 0, fmt.Errorf("unknown temperature unit %s", q.unit)
+	}
+}
+
+// toMbps converts the link speed to Mb/s, the unit is Mb/s when omitted
+func (q quantity) toMbps() (float64, error) {
+	switch q.unit {
+	case "Mb/s", "Mbps", "Mbit/s", "M", "":
+		return q.value, nil
+	case "Gb/s", "Gbps", "Gbit/s", "G":
+		return q.value * thousand, nil
+	default:
+		return 0, fmt.Errorf("unknown speed unit %s", q.unit)
+	}
+}
+
+// toMBps converts the bandwidth to MB/s, the unit is MB/s when omitted
+func (q quantity) toMBps() (float64, error) {
+	const bitsPerByte = 8
+	switch q.unit {
+	case "MB/sec", "MB/s", "":
+		return q.value, nil
+	case "KB/sec", "KB/s":
+		return q.value / thousand, nil
+	case "GB/sec", "GB/s":
+		return q.value * thousand, nil
+	case "Mb/s", "Mbps":
+		return q.value / bitsPerByte, nil
+	case "Gb/s", "Gbps":
+		return q.value * thousand / bitsPerByte, nil
+	default:
+		return 0, fmt.Errorf("unknown bandwidth unit %s", q.unit)
+	}
+}
+
+const (
+	ten      = 10
+	thousand = 1000
+)
@@ -0,0 +1,229 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package parser parses the output of the hccn_tool subcommands into structured results
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+const (
+	defaultRoute = "0.0.0.0/0"
+	anyAddress   = "0.0.0.0"
+	ipv4Bits     = 32
+	tlvSuffix    = "_tlv"
+)
+
+var (
+	// ipv4Pattern an ipv4 address in a line, such as 192.168.100.1
+	ipv4Pattern = regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b`)
+	// macPattern a mac address in a line, such as 78:b4:6a:01:02:03 or 78-B4-6A-01-02-03
+	macPattern = regexp.MustCompile(`\b[0-9a-fA-F]{2}(?:[:-][0-9a-fA-F]{2}){5}\b`)
+	// lldpSubtype the subtype printed before the value of a TLV, such as "MAC: 00:18:82:00:00:01",
+	// "mac 00:18:82:00:00:01" and "Ifname: 100GE1/0/1"
+	lldpSubtype = regexp.MustCompile(`^(?i)(?:mac|ifname|ifalias|local|ipv4|ipv6|ip)(?:\s*:\s*|\s+)`)
+)
+
+func lldpTargets(info *LLDPInfo) map[string]*string {
+	return map[string]*string{
+		"chassis_id":         &info.ChassisID,
+		"port_id":            &info.PortID,
+		"system_name":        &info.SystemName,
+		"port_description":   &info.PortDescription,
+		"management_address": &info.ManagementAddress,
+	}
+}
+
+// ParseLLDP parses the output of hccn_tool -lldp -g, both the TLV sections such as
+//
+//	Chassis ID TLV
+//		MAC: 00:18:82:00:00:01
+//
+// and the "key: value" lines such as "Chassis ID: mac 00:18:82:00:00:01" are recognized
+func ParseLLDP(out string) (LLDPInfo, error) {
+	info := LLDPInfo{}
+	targets := lldpTargets(&info)
+	var pending *string
+	for _, line := range lines(out) {
+		if key := normalizeKey(line); strings.HasSuffix(key, tlvSuffix) {
+			pending = targets[strings.TrimSuffix(key, tlvSuffix)]
+			continue
+		}
+		if pending != nil {
+			*pending = lldpSubtype.ReplaceAllString(line, "")
+			pending = nil
+			continue
+		}
+		sep := strings.IndexAny(line, ":=")
+		if sep <= 0 {
+			continue
+		}
+		if target, ok := targets[normalizeKey(line[:sep])]; ok && *target == "" {
+			*target = lldpSubtype.ReplaceAllString(strings.TrimSpace(line[sep+1:]), "")
+		}
+	}
+	if info.ChassisID == "" && info.PortID == "" {
+		return LLDPInfo{}, errors.New("lldp neighbor is not found")
+	}
+	return info, nil
+}
+
+func validIPv4(value string) bool {
+	ip := net.ParseIP(value)
+	return ip != nil && ip.To4() != nil
+}
+
+// ParseIP parses the output of hccn_tool -ip -g, such as "ipaddr:192.168.100.101" and "netmask:255.255.255.0",
+// the first ipv4 address of the value is taken and the gateway is filled by ParseGateway
+func ParseIP(out string) (IPInfo, error) {
+	info := IPInfo{}
+	for _, f := range fields(out) {
+		var target *string
+		switch f.key {
+		case "ipaddr", "ip_addr", "ip_address", "ip", "ipv4":
+			target = &info.IP
+		case "netmask", "net_mask", "mask", "subnet_mask":
+			target = &info.Netmask
+		default:
+			continue
+		}
+		value := ipv4Pattern.FindString(f.value)
+		if !validIPv4(value) {
+			return IPInfo{}, fmt.Errorf("invalid %s %q", f.key, f.value)
+		}
+		*target = value
+	}
+	if info.IP == "" {
+		return IPInfo{}, errors.New("ip address is not found")
+	}
+	return info, nil
+}
+
+// ParseGateway parses the output of hccn_tool -gateway -g, such as "default gateway:192.168.100.1, ifname:eth0"
+func ParseGateway(out string) (string, error) {
+	for _, f := range fields(out) {
+		if !hasWord(f.key, "gateway") {
+			continue
+		}
+		value := ipv4Pattern.FindString(f.value)
+		if !validIPv4(value) {
+			return "", fmt.Errorf("invalid gateway %q", f.value)
+		}
+		return value, nil
+	}
+	return "", errors.New("gateway is not found")
+}
+
+// ParseARP parses the output of hccn_tool -arp -g, every line with an ipv4 and a mac address is an entry, such as
+// "ip_addr:192.168.100.102 mac_addr:78:b4:6a:01:02:03 dev_name:eth0", an empty table is not an error
+func ParseARP(out string) ([]ARPEntry, error) {
+	var entries []ARPEntry
+	for _, line := range lines(out) {
+		mac := macPattern.FindString(line)
+		if mac == "" {
+			continue
+		}
+		ip := ipv4Pattern.FindString(line)
+		if !validIPv4(ip) {
+			return nil, fmt.Errorf("invalid arp entry %q", line)
+		}
+		mac = strings.ToLower(strings.ReplaceAll(mac, "-", ":"))
+		entries = append(entries, ARPEntry{IP: ip, MAC: mac})
+	}
+	return entries, nil
+}
+
+// ParseRoute parses the output of hccn_tool -route -g, both the table of the route command such as
+// "0.0.0.0 192.168.100.1 0.0.0.0 UG 0 0 0 eth0" and the lines of the ip route command such as
+// "default via 192.168.100.1 dev eth0" are recognized, an empty table is not an error
+func ParseRoute(out string) ([]Route, error) {
+	var routes []Route
+	for _, line := range lines(out) {
+		words := strings.Fields(line)
+		var route Route
+		var ok bool
+		var err error
+		if len(words) >= 3 && validIPv4(words[0]) && validIPv4(words[1]) && validIPv4(words[2]) {
+			route, err = parseRouteRow(words)
+			ok = true
+		} else {
+			route, ok = parseIPRouteLine(words)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+// parseRouteRow parses a row of the table: Destination Gateway Genmask Flags Metric Ref Use Iface
+func parseRouteRow(words []string) (Route, error) {
+	ones, bits := net.IPMask(net.ParseIP(words[2]).To4()).Size()
+	if bits != ipv4Bits {
+		return Route{}, fmt.Errorf("invalid genmask %q", words[2])
+	}
+	route := Route{Destination: fmt.Sprintf("%s/%d", words[0], ones)}
+	if words[1] != anyAddress {
+		route.Gateway = words[1]
+	}
+	const minWordsWithIface = 4
+	if last := words[len(words)-1]; len(words) >= minWordsWithIface && !isNumber(last) {
+		route.Iface = last
+	}
+	return route, nil
+}
+
+// parseIPRouteLine parses a line such as "192.168.100.0/24 dev eth0 proto kernel scope link src 192.168.100.101"
+func parseIPRouteLine(words []string) (Route, bool) {
+	if len(words) < 2 {
+		return Route{}, false
+	}
+	route := Route{}
+	switch {
+	case words[0] == "default":
+		route.Destination = defaultRoute
+	case validIPv4(words[0]) && (words[1] == "via" || words[1] == "dev"):
+		route.Destination = fmt.Sprintf("%s/%d", words[0], ipv4Bits)
+	default:
+		_, ipNet, err := net.ParseCIDR(words[0])
+		if err != nil || ipNet.IP.To4() == nil {
+			return Route{}, false
+		}
+		route.Destination = ipNet.String()
+	}
+	for i := 1; i+1 < len(words); i++ {
+		switch words[i] {
+		case "via":
+			route.Gateway = words[i+1]
+		case "dev":
+			route.Iface = words[i+1]
+		default:
+			continue
+		}
+		i++
+	}
+	return route, true
+}
+
+func isNumber(word string) bool {
+	return strings.Trim(word, "0123456789") == "" && word != ""
+}
@@ -0,0 +1,214 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package parser parses the output of the hccn_tool subcommands into structured results
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LinkUp the link of the npu network port is up
+	LinkUp = "UP"
+	// LinkDown the link of the npu network port is down
+	LinkDown = "DOWN"
+)
+
+// powerKeyPattern the key of the optical power, such as tx_power0, rx_power_3 and txpower1
+var powerKeyPattern = regexp.MustCompile(`^(tx|rx)_?power_?(\d)$`)
+
+func hasWord(key, word string) bool {
+	for _, w := range strings.Split(key, "_") {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLinkStatus parses the output of hccn_tool -link -g, such as "link status: UP"
+func ParseLinkStatus(out string) (string, error) {
+	for _, f := range fields(out) {
+		if f.key != "link_status" && f.key != "status" {
+			continue
+		}
+		status := strings.ToUpper(f.value)
+		if status != LinkUp && status != LinkDown {
+			return "", fmt.Errorf("unknown link status %q", f.value)
+		}
+		return status, nil
+	}
+	return "", errors.New("link status is not found")
+}
+
+// ParseLinkSpeed parses the output of hccn_tool -speed -g in Mb/s, such as "Speed: 100000 Mb/s" or
+// "Speed: 100 Gb/s"
+func ParseLinkSpeed(out string) (int, error) {
+	for _, f := range fields(out) {
+		if f.key != "speed" && f.key != "link_speed" {
+			continue
+		}
+		q, err := parseQuantity(f.value)
+		if err != nil {
+			return 0, err
+		}
+		speed, err := q.toMbps()
+		if err != nil {
+			return 0, err
+		}
+		return int(speed), nil
+	}
+	return 0, errors.New("link speed is not found")
+}
+
+// ParseLinkStat parses the output of hccn_tool -link_stat -g, such as "[device 0]link up count : 3"
+func ParseLinkStat(out string) (LinkStat, error) {
+	linkStat := LinkStat{}
+	found := false
+	for _, f := range fields(out) {
+		var target *int
+		switch f.key {
+		case "link_up_count":
+			target = &linkStat.LinkUpCount
+			found = true
+		case "link_down_count":
+			target = &linkStat.LinkDownCount
+		default:
+			continue
+		}
+		count, err := strconv.Atoi(f.value)
+		if err != nil {
+			return LinkStat{}, fmt.Errorf("invalid %s %q: %v", f.key, f.value, err)
+		}
+		*target = count
+	}
+	if !found {
+		return LinkStat{}, errors.New("link up count is not found")
+	}
+	return linkStat, nil
+}
+
+// ParseStat parses the output of hccn_tool -stat -g, such as "mac_tx_mac_pause_num:0", the counters which are
+// not numbers are skipped
+func ParseStat(out string) (StatInfo, error) {
+	statInfo := StatInfo{}
+	counters := statInfo.counters()
+	found := false
+	for _, f := range fields(out) {
+		count, err := strconv.ParseUint(f.value, 10, bitSize)
+		if err != nil {
+			continue
+		}
+		found = true
+		if counter, ok := counters[f.key]; ok {
+			*counter = count
+			continue
+		}
+		if statInfo.Others == nil {
+			statInfo.Others = make(map[string]uint64)
+		}
+		statInfo.Others[f.key] = count
+	}
+	if !found {
+		return StatInfo{}, errors.New("no counter is found")
+	}
+	return statInfo, nil
+}
+
+// ParseOptical parses the output of hccn_tool -optical -g, the power is converted to mW and the voltage to mV,
+// the values which can not be parsed, such as N/A, are left zero
+func ParseOptical(out string) (OpticalInfo, error) {
+	info := OpticalInfo{}
+	found := false
+	for _, f := range fields(out) {
+		if f.key == "present" {
+			value := strings.ToLower(f.value)
+			info.Present = value == "present" || value == "yes" || value == "true"
+			found = true
+			continue
+		}
+		target, convert := opticalTarget(&info, f.key)
+		if target == nil {
+			continue
+		}
+		found = true
+		q, err := parseQuantity(f.value)
+		if err != nil {
+			continue
+		}
+		if value, err := convert(q); err == nil {
+			*target = value
+		}
+	}
+	if !found {
+		return OpticalInfo{}, errors.New("no optical information is found")
+	}
+	return info, nil
+}
+
+func opticalTarget(info *OpticalInfo, key string) (*float64, func(quantity) (float64, error)) {
+	switch key {
+	case "vcc", "voltage", "supply_voltage":
+		return &info.Vcc, quantity.toMilliVolt
+	case "temperature", "temp":
+		return &info.Temperature, quantity.toCelsius
+	default:
+	}
+	const matchNum = 3
+	matches := powerKeyPattern.FindStringSubmatch(key)
+	if len(matches) != matchNum {
+		return nil, nil
+	}
+	channel, err := strconv.Atoi(matches[2])
+	if err != nil || channel >= OpticalChannelNum {
+		return nil, nil
+	}
+	if matches[1] == "tx" {
+		return &info.TxPower[channel], quantity.toMilliWatt
+	}
+	return &info.RxPower[channel], quantity.toMilliWatt
+}
+
+// ParseBandwidth parses the output of hccn_tool -bandwidth -g in MB/s, such as "Bandwidth TX: 0.00 MB/sec"
+func ParseBandwidth(out string) (Bandwidth, error) {
+	bandwidth := Bandwidth{}
+	foundTx, foundRx := false, false
+	for _, f := range fields(out) {
+		var target *float64
+		switch {
+		case hasWord(f.key, "tx"):
+			target, foundTx = &bandwidth.Tx, true
+		case hasWord(f.key, "rx"):
+			target, foundRx = &bandwidth.Rx, true
+		default:
+			continue
+		}
+		q, err := parseQuantity(f.value)
+		if err != nil {
+			return Bandwidth{}, fmt.Errorf("invalid %s: %v", f.key, err)
+		}
+		if *target, err = q.toMBps(); err != nil {
+			return Bandwidth{}, err
+		}
+	}
+	if !foundTx || !foundRx {
+		return Bandwidth{}, errors.New("tx or rx bandwidth is not found")
+	}
+	return bandwidth, nil
+}
@@ -0,0 +1,142 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package parser parses the output of the hccn_tool subcommands into structured results
+package parser
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// update regenerates the golden files by: go test ./devmanager/hccn/parser -update
+var update = flag.Bool("update", false, "update the golden files")
+
+// result the parsed outputs of all subcommands of a driver release
+type result struct {
+	LinkStatus string      `json:"link_status"`
+	Speed      int         `json:"speed"`
+	LinkStat   LinkStat    `json:"link_stat"`
+	Stat       StatInfo    `json:"stat"`
+	Optical    OpticalInfo `json:"optical"`
+	Bandwidth  Bandwidth   `json:"bandwidth"`
+	LLDP       LLDPInfo    `json:"lldp"`
+	IP         IPInfo      `json:"ip"`
+	ARP        []ARPEntry  `json:"arp"`
+	Route      []Route     `json:"route"`
+}
+
+func readFixture(t *testing.T, dir, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func parseRelease(t *testing.T, dir string) result {
+	var res result
+	var err error
+	res.LinkStatus, err = ParseLinkStatus(readFixture(t, dir, "link.txt"))
+	assert.NoError(t, err)
+	res.Speed, err = ParseLinkSpeed(readFixture(t, dir, "speed.txt"))
+	assert.NoError(t, err)
+	res.LinkStat, err = ParseLinkStat(readFixture(t, dir, "link_stat.txt"))
+	assert.NoError(t, err)
+	res.Stat, err = ParseStat(readFixture(t, dir, "stat.txt"))
+	assert.NoError(t, err)
+	res.Optical, err = ParseOptical(readFixture(t, dir, "optical.txt"))
+	assert.NoError(t, err)
+	res.Bandwidth, err = ParseBandwidth(readFixture(t, dir, "bandwidth.txt"))
+	assert.NoError(t, err)
+	res.LLDP, err = ParseLLDP(readFixture(t, dir, "lldp.txt"))
+	assert.NoError(t, err)
+	res.IP, err = ParseIP(readFixture(t, dir, "ip.txt"))
+	assert.NoError(t, err)
+	res.IP.Gateway, err = ParseGateway(readFixture(t, dir, "gateway.txt"))
+	assert.NoError(t, err)
+	res.ARP, err = ParseARP(readFixture(t, dir, "arp.txt"))
+	assert.NoError(t, err)
+	res.Route, err = ParseRoute(readFixture(t, dir, "route.txt"))
+	assert.NoError(t, err)
+	return res
+}
+
+// TestGolden test the outputs of the driver releases in testdata are parsed as the golden files
+func TestGolden(t *testing.T) {
+	releases, err := filepath.Glob(filepath.Join("testdata", "*"))
+	if err != nil || len(releases) == 0 {
+		t.Fatalf("no driver release in testdata: %v", err)
+	}
+	for _, dir := range releases {
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			got, err := json.MarshalIndent(parseRelease(t, dir), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join(dir, "golden.json")
+			if *update {
+				const mode = 0640
+				if err := os.WriteFile(golden, append(got, '\n'), mode); err != nil {
+					t.Fatal(err)
+				}
+			}
+			assert.JSONEq(t, readFixture(t, dir, "golden.json"), string(got))
+		})
+	}
+}
+
+// TestParseError test the outputs which are not recognized
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string) error
+		out   string
+	}{
+		{name: "should return error when link status is unknown", out: "link status: UNKNOWN",
+			parse: func(out string) error { _, err := ParseLinkStatus(out); return err }},
+		{name: "should return error when link status is missing", out: "command not supported",
+			parse: func(out string) error { _, err := ParseLinkStatus(out); return err }},
+		{name: "should return error when speed unit is unknown", out: "Speed: 100 furlongs",
+			parse: func(out string) error { _, err := ParseLinkSpeed(out); return err }},
+		{name: "should return error when link up count is not a number", out: "link up count : many",
+			parse: func(out string) error { _, err := ParseLinkStat(out); return err }},
+		{name: "should return error when there is no counter", out: "packet statistics:\n",
+			parse: func(out string) error { _, err := ParseStat(out); return err }},
+		{name: "should return error when there is no optical information", out: "",
+			parse: func(out string) error { _, err := ParseOptical(out); return err }},
+		{name: "should return error when rx bandwidth is missing", out: "Bandwidth TX: 0.00 MB/sec",
+			parse: func(out string) error { _, err := ParseBandwidth(out); return err }},
+		{name: "should return error when there is no lldp neighbor", out: "lldp is not enabled",
+			parse: func(out string) error { _, err := ParseLLDP(out); return err }},
+		{name: "should return error when ip address is invalid", out: "ipaddr:192.168.1",
+			parse: func(out string) error { _, err := ParseIP(out); return err }},
+		{name: "should return error when gateway is missing", out: "no gateway",
+			parse: func(out string) error { _, err := ParseGateway(out); return err }},
+		{name: "should return error when arp entry has no ip address", out: "mac_addr:78:b4:6a:01:02:03",
+			parse: func(out string) error { _, err := ParseARP(out); return err }},
+		{name: "should return error when genmask is not contiguous", out: "10.0.0.0 0.0.0.0 255.0.255.0 U 0 0 0 eth0",
+			parse: func(out string) error { _, err := ParseRoute(out); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.parse(tt.out))
+		})
+	}
+}
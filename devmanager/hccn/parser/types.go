@@ -0,0 +1,138 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package parser parses the output of the hccn_tool subcommands into structured results
+package parser
+
+// OpticalChannelNum the number of channels of the optical module
+const OpticalChannelNum = 4
+
+// LinkStat the result of hccn_tool -link_stat
+type LinkStat struct {
+	LinkUpCount   int `json:"link_up_count"`
+	LinkDownCount int `json:"link_down_count"`
+}
+
+// StatInfo the packet statistics, the result of hccn_tool -stat
+type StatInfo struct {
+	MacTxPauseNum          uint64 `json:"mac_tx_mac_pause_num"`
+	MacRxPauseNum          uint64 `json:"mac_rx_mac_pause_num"`
+	MacTxPfcPktNum         uint64 `json:"mac_tx_pfc_pkt_num"`
+	MacRxPfcPktNum         uint64 `json:"mac_rx_pfc_pkt_num"`
+	MacTxBadPktNum         uint64 `json:"mac_tx_bad_pkt_num"`
+	MacRxBadPktNum         uint64 `json:"mac_rx_bad_pkt_num"`
+	MacTxBadOctNum         uint64 `json:"mac_tx_bad_oct_num"`
+	MacRxBadOctNum         uint64 `json:"mac_rx_bad_oct_num"`
+	RoceTxAllPktNum        uint64 `json:"roce_tx_all_pkt_num"`
+	RoceRxAllPktNum        uint64 `json:"roce_rx_all_pkt_num"`
+	RoceTxErrPktNum        uint64 `json:"roce_tx_err_pkt_num"`
+	RoceRxErrPktNum        uint64 `json:"roce_rx_err_pkt_num"`
+	RoceTxCnpPktNum        uint64 `json:"roce_tx_cnp_pkt_num"`
+	RoceRxCnpPktNum        uint64 `json:"roce_rx_cnp_pkt_num"`
+	RoceUnexpectedAckNum   uint64 `json:"roce_unexpected_ack_num"`
+	RoceOutOfOrderNum      uint64 `json:"roce_out_of_order_num"`
+	RoceVerificationErrNum uint64 `json:"roce_verification_err_num"`
+	RoceQpStatusErrNum     uint64 `json:"roce_qp_status_err_num"`
+	RoceNewPktRtyNum       uint64 `json:"roce_new_pkt_rty_num"`
+	// Others the counters without field, the key is the normalized name in the output
+	Others map[string]uint64 `json:"others,omitempty"`
+}
+
+// counters returns the fields of the counters by the normalized names in the output
+func (s *StatInfo) counters() map[string]*uint64 {
+	return map[string]*uint64{
+		"mac_tx_mac_pause_num":      &s.MacTxPauseNum,
+		"mac_rx_mac_pause_num":      &s.MacRxPauseNum,
+		"mac_tx_pfc_pkt_num":        &s.MacTxPfcPktNum,
+		"mac_rx_pfc_pkt_num":        &s.MacRxPfcPktNum,
+		"mac_tx_bad_pkt_num":        &s.MacTxBadPktNum,
+		"mac_rx_bad_pkt_num":        &s.MacRxBadPktNum,
+		"mac_tx_bad_oct_num":        &s.MacTxBadOctNum,
+		"mac_rx_bad_oct_num":        &s.MacRxBadOctNum,
+		"roce_tx_all_pkt_num":       &s.RoceTxAllPktNum,
+		"roce_rx_all_pkt_num":       &s.RoceRxAllPktNum,
+		"roce_tx_err_pkt_num":       &s.RoceTxErrPktNum,
+		"roce_rx_err_pkt_num":       &s.RoceRxErrPktNum,
+		"roce_tx_cnp_pkt_num":       &s.RoceTxCnpPktNum,
+		"roce_rx_cnp_pkt_num":       &s.RoceRxCnpPktNum,
+		"roce_unexpected_ack_num":   &s.RoceUnexpectedAckNum,
+		"roce_out_of_order_num":     &s.RoceOutOfOrderNum,
+		"roce_verification_err_num": &s.RoceVerificationErrNum,
+		"roce_qp_status_err_num":    &s.RoceQpStatusErrNum,
+		"roce_new_pkt_rty_num":      &s.RoceNewPktRtyNum,
+	}
+}
+
+// Counters returns the values of all counters by the normalized names in the output, including Others
+func (s *StatInfo) Counters() map[string]uint64 {
+	fields := s.counters()
+	result := make(map[string]uint64, len(fields)+len(s.Others))
+	for name, counter := range fields {
+		result[name] = *counter
+	}
+	for name, count := range s.Others {
+		result[name] = count
+	}
+	return result
+}
+
+// OpticalInfo the optical module information, the result of hccn_tool -optical
+type OpticalInfo struct {
+	Present bool `json:"present"`
+	// TxPower the transmit power of each channel in mW
+	TxPower [OpticalChannelNum]float64 `json:"tx_power"`
+	// RxPower the receive power of each channel in mW
+	RxPower [OpticalChannelNum]float64 `json:"rx_power"`
+	// Vcc the supply voltage in mV
+	Vcc float64 `json:"vcc"`
+	// Temperature the temperature in Celsius
+	Temperature float64 `json:"temperature"`
+}
+
+// Bandwidth the result of hccn_tool -bandwidth, in MB/s
+type Bandwidth struct {
+	Tx float64 `json:"tx"`
+	Rx float64 `json:"rx"`
+}
+
+// LLDPInfo the neighbor of the npu network port, the result of hccn_tool -lldp
+type LLDPInfo struct {
+	ChassisID         string `json:"chassis_id"`
+	PortID            string `json:"port_id"`
+	SystemName        string `json:"system_name"`
+	PortDescription   string `json:"port_description"`
+	ManagementAddress string `json:"management_address"`
+}
+
+// IPInfo the address of the npu network port, the result of hccn_tool -ip and -gateway
+type IPInfo struct {
+	IP      string `json:"ip"`
+	Netmask string `json:"netmask"`
+	Gateway string `json:"gateway"`
+}
+
+// ARPEntry an entry of the arp table, the result of hccn_tool -arp
+type ARPEntry struct {
+	IP  string `json:"ip"`
+	MAC string `json:"mac"`
+}
+
+// Route an entry of the route table, the result of hccn_tool -route
+type Route struct {
+	// Destination the destination in CIDR notation, 0.0.0.0/0 is the default route
+	Destination string `json:"destination"`
+	// Gateway the next hop, empty for the directly connected routes
+	Gateway string `json:"gateway"`
+	Iface   string `json:"iface"`
+}
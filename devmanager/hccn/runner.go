@@ -0,0 +1,112 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hccn this for npu hccn info
+package hccn
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"huawei.com/npu-exporter/v5/common-utils/utils"
+)
+
+const (
+	// DriverPathEnv the environment variable of the driver install path
+	DriverPathEnv     = "ASCEND_DRIVER_PATH"
+	defaultDriverPath = "/usr/local/Ascend/driver"
+	hccnToolRelPath   = "tools/hccn_tool"
+	// DefaultTimeout the default timeout of an hccn_tool execution
+	DefaultTimeout = 5 * time.Second
+	// maxStderrLen the max length of the stderr in the error
+	maxStderrLen = 256
+)
+
+// Runner runs hccn_tool with the args and returns its stdout
+type Runner interface {
+	Run(ctx context.Context, args ...string) (string, error)
+}
+
+// DriverPath returns ASCEND_DRIVER_PATH, or the default driver install path when the environment variable is not set
+func DriverPath() string {
+	driverPath := os.Getenv(DriverPathEnv)
+	if driverPath == "" {
+		driverPath = defaultDriverPath
+	}
+	return driverPath
+}
+
+// DefaultToolPath returns the hccn_tool path under ASCEND_DRIVER_PATH, or under the default driver install path
+// when the environment variable is not set
+func DefaultToolPath() string {
+	return filepath.Join(DriverPath(), hccnToolRelPath)
+}
+
+// cmdRunner forks hccn_tool for every run
+type cmdRunner struct {
+	path    string
+	timeout time.Duration
+}
+
+// NewCmdRunner returns the Runner forking the hccn_tool of the path, DefaultToolPath and DefaultTimeout are used
+// when path is empty or timeout is not positive
+func NewCmdRunner(path string, timeout time.Duration) Runner {
+	if path == "" {
+		path = DefaultToolPath()
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &cmdRunner{path: path, timeout: timeout}
+}
+
+// Run runs hccn_tool, the process is killed when ctx is done or the timeout is reached
+func (r *cmdRunner) Run(ctx context.Context, args ...string) (string, error) {
+	toolPath, err := utils.CheckPath(r.path)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, toolPath, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		cmdLine := strings.Join(args, " ")
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("hccn_tool %s timed out after %v", cmdLine, r.timeout)
+		}
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("hccn_tool %s is canceled: %v", cmdLine, ctx.Err())
+		}
+		return "", fmt.Errorf("hccn_tool %s failed: %v, stderr: %s", cmdLine, err, truncate(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+func truncate(str string) string {
+	str = strings.TrimSpace(str)
+	if len(str) > maxStderrLen {
+		return str[:maxStderrLen] + "..."
+	}
+	return str
+}
@@ -0,0 +1,119 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hccn this for npu hccn info
+package hccn
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/devmanager/hccn/parser"
+)
+
+func writeTool(t *testing.T, script string) string {
+	path := filepath.Join(t.TempDir(), "hccn_tool")
+	const mode = 0700
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestCmdRunner test the hccn_tool process is run with timeout, cancellation and stderr capture
+func TestCmdRunner(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name    string
+		script  string
+		ctx     context.Context
+		want    string
+		wantErr string
+	}{
+		{name: "should return stdout when hccn_tool succeeds", script: `echo "link status: $4"`,
+			ctx: context.Background(), want: "link status: -g\n"},
+		{name: "should return error with stderr when hccn_tool fails", script: "echo 'device not found' >&2; exit 1",
+			ctx: context.Background(), wantErr: "stderr: device not found"},
+		{name: "should return error when hccn_tool times out", script: "exec sleep 5",
+			ctx: context.Background(), wantErr: "timed out"},
+		{name: "should return error when context is canceled", script: "exec sleep 5", ctx: canceled,
+			wantErr: "canceled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const timeout = 200 * time.Millisecond
+			out, err := NewCmdRunner(writeTool(t, tt.script), timeout).Run(tt.ctx, "-i", "0", "-link", "-g")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, out)
+		})
+	}
+}
+
+// TestDefaultToolPath test the hccn_tool path is under ASCEND_DRIVER_PATH
+func TestDefaultToolPath(t *testing.T) {
+	t.Setenv(DriverPathEnv, "")
+	assert.Equal(t, "/usr/local/Ascend/driver/tools/hccn_tool", DefaultToolPath())
+	t.Setenv(DriverPathEnv, "/opt/driver")
+	assert.Equal(t, "/opt/driver/tools/hccn_tool", DefaultToolPath())
+}
+
+// TestTool test the outputs of the runner are parsed by the tool
+func TestTool(t *testing.T) {
+	runner := &FakeRunner{
+		Outputs: map[string]string{
+			"-link":              "link status: UP\n",
+			"-speed":             "Speed: 100000 Mb/s\n",
+			"-i 1 -link_stat -g": "[device 1]link up count : 2\n",
+			"-bandwidth":         "Bandwidth TX: 1.50 MB/sec\nBandwidth RX: 2.50 MB/sec\n",
+			"-ip":                "ipaddr:192.168.100.101\nnetmask:255.255.255.0\n",
+			"-i 1 -gateway -g":   "default gateway:192.168.100.1\n",
+		},
+		Errors: map[string]error{"-stat": errors.New("not supported")},
+	}
+	hccnTool := NewTool(context.Background(), runner)
+	status, err := hccnTool.GetLinkStatus(0)
+	assert.NoError(t, err)
+	assert.Equal(t, LinkUp, status)
+	speed, err := hccnTool.GetLinkSpeed(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 100000, speed)
+	linkStat, err := hccnTool.GetLinkStat(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, linkStat.LinkUpCount)
+	_, err = hccnTool.GetLinkStat(0)
+	assert.Error(t, err)
+	bandwidth, err := hccnTool.GetBandwidth(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, bandwidth.Rx)
+	_, err = hccnTool.GetStatInfo(0)
+	assert.ErrorContains(t, err, "not supported")
+	ipInfo, err := hccnTool.GetIPInfo(1)
+	assert.NoError(t, err)
+	assert.Equal(t, parser.IPInfo{IP: "192.168.100.101", Netmask: "255.255.255.0", Gateway: "192.168.100.1"}, ipInfo)
+	ipInfo, err = hccnTool.GetIPInfo(0)
+	assert.NoError(t, err, "the gateway is optional")
+	assert.Empty(t, ipInfo.Gateway)
+	assert.Equal(t, []string{"-i", "0", "-link", "-g"}, runner.Calls()[0])
+}
@@ -0,0 +1,163 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hccn this for npu hccn info
+package hccn
+
+import (
+	"context"
+	"sync"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager/hccn/parser"
+)
+
+var (
+	tool     *Tool
+	toolOnce sync.Once
+	toolLock sync.RWMutex
+)
+
+// SetTool replaces the hccn_tool used by the package level functions
+func SetTool(t *Tool) {
+	toolOnce.Do(func() {})
+	toolLock.Lock()
+	defer toolLock.Unlock()
+	tool = t
+}
+
+// GetTool returns the hccn_tool used by the package level functions, it runs the hccn_tool of DefaultToolPath if
+// not set
+func GetTool() *Tool {
+	toolOnce.Do(func() {
+		t := NewTool(context.Background(), NewCmdRunner(DefaultToolPath(), DefaultTimeout))
+		toolLock.Lock()
+		tool = t
+		toolLock.Unlock()
+	})
+	toolLock.RLock()
+	defer toolLock.RUnlock()
+	return tool
+}
+
+// GetNPULinkStatus get the link status of the chip, LinkDown is returned when the query fails
+func GetNPULinkStatus(phyID int32) string {
+	status, err := GetTool().GetLinkStatus(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu link status failed, %s", err)
+		return LinkDown
+	}
+	hwlog.RunLog.Debugf("hccn get npu link status: %s", status)
+	return status
+}
+
+// GetNPULinkSpeed get the link speed of the chip in Mb/s
+func GetNPULinkSpeed(phyID int32) int {
+	speed, err := GetTool().GetLinkSpeed(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu link speed failed, %s", err)
+		return abnormalCode
+	}
+	return speed
+}
+
+// GetNPULinkUpNum get the link up count of the chip
+func GetNPULinkUpNum(phyID int32) int {
+	linkStat, err := GetTool().GetLinkStat(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu link stat failed, %s", err)
+		return abnormalCode
+	}
+	return linkStat.LinkUpCount
+}
+
+// GetNPULinkStat get the link up and down count of the chip
+func GetNPULinkStat(phyID int32) (parser.LinkStat, error) {
+	linkStat, err := GetTool().GetLinkStat(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu link stat failed, %s", err)
+		return parser.LinkStat{}, err
+	}
+	return linkStat, nil
+}
+
+// GetNPUStatInfo get the packet statistics of the chip
+func GetNPUStatInfo(phyID int32) (parser.StatInfo, error) {
+	statInfo, err := GetTool().GetStatInfo(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu stat info failed, %s", err)
+		return parser.StatInfo{}, err
+	}
+	return statInfo, nil
+}
+
+// GetNPUOpticalInfo get the optical module information of the chip
+func GetNPUOpticalInfo(phyID int32) (parser.OpticalInfo, error) {
+	opticalInfo, err := GetTool().GetOpticalInfo(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu optical info failed, %s", err)
+		return parser.OpticalInfo{}, err
+	}
+	return opticalInfo, nil
+}
+
+// GetNPUInterfaceTraffic get the tx and rx bandwidth of the chip in MB/s
+func GetNPUInterfaceTraffic(phyID int32) (float64, float64, error) {
+	bandwidth, err := GetTool().GetBandwidth(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu interface traffic failed, %s", err)
+		return 0, 0, err
+	}
+	return bandwidth.Tx, bandwidth.Rx, nil
+}
+
+// GetNPULLDPInfo get the neighbor of the network port of the chip
+func GetNPULLDPInfo(phyID int32) (parser.LLDPInfo, error) {
+	lldpInfo, err := GetTool().GetLLDP(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu lldp info failed, %s", err)
+		return parser.LLDPInfo{}, err
+	}
+	return lldpInfo, nil
+}
+
+// GetNPUIPInfo get the ip address, netmask and gateway of the network port of the chip
+func GetNPUIPInfo(phyID int32) (parser.IPInfo, error) {
+	ipInfo, err := GetTool().GetIPInfo(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu ip info failed, %s", err)
+		return parser.IPInfo{}, err
+	}
+	return ipInfo, nil
+}
+
+// GetNPUARPTable get the arp table of the chip
+func GetNPUARPTable(phyID int32) ([]parser.ARPEntry, error) {
+	entries, err := GetTool().GetARP(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu arp table failed, %s", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetNPURouteTable get the route table of the chip
+func GetNPURouteTable(phyID int32) ([]parser.Route, error) {
+	routes, err := GetTool().GetRoute(phyID)
+	if err != nil {
+		hwlog.RunLog.Errorf("get npu route table failed, %s", err)
+		return nil, err
+	}
+	return routes, nil
+}
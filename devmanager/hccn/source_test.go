@@ -0,0 +1,84 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hccn this for npu hccn info
+package hccn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+)
+
+// TestGetByPackageFunctions test the package level functions get the information by the hccn_tool set
+func TestGetByPackageFunctions(t *testing.T) {
+	outputs := map[string]string{
+		"-link":      "link status: UP\n",
+		"-speed":     "Speed: 100000 Mb/s\n",
+		"-link_stat": "[device 0]link up count : 1\n",
+		"-bandwidth": "Bandwidth TX: 1.00 MB/sec\nBandwidth RX: 2.00 MB/sec\n",
+		"-stat":      "mac_tx_mac_pause_num:1\n",
+		"-lldp":      "Chassis ID TLV\n\tMAC: 00:18:82:00:00:01\n",
+		"-ip":        "ipaddr:192.168.100.101\nnetmask:255.255.255.0\n",
+		"-gateway":   "default gateway:192.168.100.1, ifname:eth0\n",
+		"-arp":       "ip_addr:192.168.100.1 mac_addr:00:18:82:00:00:01 dev_name:eth0\n",
+		"-route":     "0.0.0.0 192.168.100.1 0.0.0.0 UG 0 0 0 eth0\n",
+	}
+	tests := []struct {
+		name        string
+		runner      *FakeRunner
+		wantErr     bool
+		wantStatus  string
+		wantSpeed   int
+		wantLinkUp  int
+		wantTraffic float64
+	}{
+		{name: "should return the values of hccn_tool when hccn_tool succeeds", runner: &FakeRunner{Outputs: outputs},
+			wantStatus: LinkUp, wantSpeed: 100000, wantLinkUp: 1, wantTraffic: 1},
+		{name: "should return the abnormal values when hccn_tool fails", runner: &FakeRunner{}, wantErr: true,
+			wantStatus: LinkDown},
+	}
+	defer SetTool(GetTool())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTool(NewTool(context.Background(), tt.runner))
+			assert.Equal(t, tt.wantStatus, GetNPULinkStatus(0))
+			assert.Equal(t, tt.wantSpeed, GetNPULinkSpeed(0))
+			assert.Equal(t, tt.wantLinkUp, GetNPULinkUpNum(0))
+			tx, _, err := GetNPUInterfaceTraffic(0)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantTraffic, tx)
+			statInfo, _ := GetNPUStatInfo(0)
+			assert.Equal(t, !tt.wantErr, statInfo.MacTxPauseNum == 1)
+			lldpInfo, _ := GetNPULLDPInfo(0)
+			assert.Equal(t, !tt.wantErr, lldpInfo.ChassisID != "")
+			ipInfo, _ := GetNPUIPInfo(0)
+			assert.Equal(t, !tt.wantErr, ipInfo.IP != "")
+			arpEntries, _ := GetNPUARPTable(0)
+			assert.Equal(t, !tt.wantErr, len(arpEntries) == 1)
+			routes, _ := GetNPURouteTable(0)
+			assert.Equal(t, !tt.wantErr, len(routes) == 1)
+		})
+	}
+}
+
+func init() {
+	config := hwlog.LogConfig{
+		OnlyToStdout: true,
+	}
+	hwlog.InitRunLogger(&config, nil)
+}
@@ -0,0 +1,65 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hccn this for npu hccn info
+package hccn
+
+import (
+	"time"
+)
+
+// StatSample the cumulative packet counters sampled at a time
+type StatSample struct {
+	// Counters the values of the counters by their names
+	Counters map[string]uint64
+	// Time the sampling time, zero means no sample
+	Time time.Time
+}
+
+// StatWindow the increases of the packet counters between two samples
+type StatWindow struct {
+	// Delta the increases of the counters in the window
+	Delta map[string]uint64
+	// Rate the increases per second of the counters in the window
+	Rate map[string]float64
+	// Reset whether a counter reset, such as a chip reset, is detected in the window
+	Reset bool
+}
+
+// NewStatWindow returns the increases from prev to cur, a counter lower than its previous value is taken as reset
+// and its increase is the current value; the counters which are not in prev have no increase, and the window is
+// empty when either sample is missing or cur is not later than prev
+func NewStatWindow(prev, cur StatSample) StatWindow {
+	window := StatWindow{}
+	if prev.Time.IsZero() || cur.Time.IsZero() || !cur.Time.After(prev.Time) {
+		return window
+	}
+	seconds := cur.Time.Sub(prev.Time).Seconds()
+	window.Delta = make(map[string]uint64, len(cur.Counters))
+	window.Rate = make(map[string]float64, len(cur.Counters))
+	for name, count := range cur.Counters {
+		last, ok := prev.Counters[name]
+		if !ok {
+			continue
+		}
+		delta := count - last
+		if count < last {
+			window.Reset = true
+			delta = count
+		}
+		window.Delta[name] = delta
+		window.Rate[name] = float64(delta) / seconds
+	}
+	return window
+}
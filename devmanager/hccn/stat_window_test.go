@@ -0,0 +1,56 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hccn this for npu hccn info
+package hccn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewStatWindow test the increases of the counters between two samples
+func TestNewStatWindow(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	const window = 10 * time.Second
+	prev := StatSample{Counters: map[string]uint64{"rx": 100, "tx": 50}, Time: start}
+	tests := []struct {
+		name string
+		cur  StatSample
+		want StatWindow
+	}{
+		{name: "should return increases and rates when counters grow",
+			cur: StatSample{Counters: map[string]uint64{"rx": 150, "tx": 50, "new": 7}, Time: start.Add(window)},
+			want: StatWindow{Delta: map[string]uint64{"rx": 50, "tx": 0},
+				Rate: map[string]float64{"rx": 5, "tx": 0}}},
+		{name: "should take current value as increase when counter resets",
+			cur: StatSample{Counters: map[string]uint64{"rx": 20, "tx": 60}, Time: start.Add(window)},
+			want: StatWindow{Delta: map[string]uint64{"rx": 20, "tx": 10},
+				Rate: map[string]float64{"rx": 2, "tx": 1}, Reset: true}},
+		{name: "should return empty window when current sample is missing", cur: StatSample{},
+			want: StatWindow{}},
+		{name: "should return empty window when current sample is not later",
+			cur: StatSample{Counters: map[string]uint64{"rx": 150}, Time: start}, want: StatWindow{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewStatWindow(prev, tt.cur))
+		})
+	}
+	t.Run("should return empty window when previous sample is missing", func(t *testing.T) {
+		assert.Equal(t, StatWindow{}, NewStatWindow(StatSample{}, prev))
+	})
+}
@@ -4,6 +4,7 @@ go 1.18
 
 require (
 	github.com/agiledragon/gomonkey/v2 v2.8.0
+	github.com/ebitengine/purego v0.10.0
 	github.com/fsnotify/fsnotify v1.6.0
 	github.com/golang/protobuf v1.5.3
 	github.com/influxdata/telegraf v1.26.3
@@ -12,6 +13,7 @@ require (
 	github.com/stretchr/testify v1.8.2
 	google.golang.org/grpc v1.57.2
 	google.golang.org/protobuf v1.30.0
+	gopkg.in/yaml.v3 v3.0.1
 	k8s.io/cri-api v0.25.13
 )
 
@@ -54,5 +56,4 @@ require (
 	golang.org/x/sys v0.8.0 // indirect
 	golang.org/x/text v0.9.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20230525234030-28d5490b6b19 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
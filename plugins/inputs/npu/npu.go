@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/inputs"
@@ -29,6 +30,7 @@ import (
 	"huawei.com/npu-exporter/v5/devmanager"
 	"huawei.com/npu-exporter/v5/devmanager/common"
 	"huawei.com/npu-exporter/v5/devmanager/hccn"
+	"huawei.com/npu-exporter/v5/devmanager/hccn/parser"
 )
 
 const (
@@ -43,29 +45,18 @@ const (
 	defaultLogFileSize  = 2
 )
 
-const (
-	txPower0 = "Tx_Power0"
-	txPower1 = "Tx_Power1"
-	txPower2 = "Tx_Power2"
-	txPower3 = "Tx_Power3"
-
-	rxPower0 = "Rx_Power0"
-	rxPower1 = "Rx_Power1"
-	rxPower2 = "Rx_Power2"
-	rxPower3 = "Rx_Power3"
-
-	present     = "present"
-	temperature = "temperature"
-	voltage     = "Vcc"
-)
-
 //go:embed sample.conf
 var sampleConfig string
 
 type NpuWatch struct {
-	NpuLogPath  string `toml:"npu_log_path"`
-	NpuLogLevel int    `toml:"npu_log_level"`
-	devManager  devmanager.DeviceInterface
+	NpuLogPath   string `toml:"npu_log_path"`
+	NpuLogLevel  int    `toml:"npu_log_level"`
+	HccnToolPath string `toml:"hccn_tool_path"`
+	// StatRate adds the increase and the rate of the packet counters over the gather interval
+	StatRate     bool `toml:"stat_rate"`
+	devManager   devmanager.DeviceInterface
+	statSamples  map[int32]hccn.StatSample
+	statResetNum map[int32]uint64
 }
 
 func (*NpuWatch) SampleConfig() string {
@@ -95,23 +86,24 @@ func (npu *NpuWatch) Init() error {
 		return fmt.Errorf("init dev manager failed: %v", err)
 	}
 	npu.devManager = dmgr
+	hccn.SetTool(hccn.NewTool(context.Background(), hccn.NewCmdRunner(npu.HccnToolPath, hccn.DefaultTimeout)))
 	return nil
 }
 
 // parseOptInfoForCTYun parse optical info of NPU for CT Yun
-func parseOptInfoForCTYun(opticalInfo map[string]string) map[string]interface{} {
+func parseOptInfoForCTYun(opticalInfo parser.OpticalInfo) map[string]interface{} {
 	ctYunOpticalInfo := make(map[string]interface{})
-	var ctYunFloatDataKeys = []string{
-		txPower0,
-		txPower1,
-		txPower2,
-		txPower3,
-		rxPower0,
-		rxPower1,
-		rxPower2,
-		rxPower3,
-		voltage,
-		temperature,
+	var ctYunFloatData = []float64{
+		opticalInfo.TxPower[0],
+		opticalInfo.TxPower[1],
+		opticalInfo.TxPower[2],
+		opticalInfo.TxPower[3],
+		opticalInfo.RxPower[0],
+		opticalInfo.RxPower[1],
+		opticalInfo.RxPower[2],
+		opticalInfo.RxPower[3],
+		opticalInfo.Vcc,
+		opticalInfo.Temperature,
 	}
 	var ctYunTelegrafKeys = []string{
 		"npu_chip_optical_tx_power_0",
@@ -126,13 +118,12 @@ func parseOptInfoForCTYun(opticalInfo map[string]string) map[string]interface{}
 		"npu_chip_optical_temp",
 	}
 
-	for i, ctYunOpticalKey := range ctYunFloatDataKeys {
-		floatData := hccn.GetFloatDataFromStr(opticalInfo[ctYunOpticalKey])
+	for i, floatData := range ctYunFloatData {
 		ctYunOpticalInfo[ctYunTelegrafKeys[i]] = floatData
 	}
 
 	optState := 0
-	if opticalInfo[present] == present {
+	if opticalInfo.Present {
 		optState = 1
 	}
 	ctYunOpticalInfo["npu_chip_optical_state"] = optState
@@ -141,6 +132,13 @@ func parseOptInfoForCTYun(opticalInfo map[string]string) map[string]interface{}
 }
 
 func (npu *NpuWatch) packDcmiInfo(devID int32, fields map[string]interface{}, acc telegraf.Accumulator) {
+	bootStatus, err := npu.devManager.GetDeviceBootStatus(devID)
+	if err != nil {
+		acc.AddError(fmt.Errorf("get boot status of npu failed: %v", err))
+		bootStatus = common.BootStatusUnknown
+	}
+	fields["npu_chip_boot_status"] = bootStatus
+
 	health, err := npu.devManager.GetDeviceHealth(devID)
 	if err != nil {
 		acc.AddError(fmt.Errorf("get health of npu failed: %v", err))
@@ -183,6 +181,8 @@ func (npu *NpuWatch) packDcmiInfo(devID int32, fields map[string]interface{}, ac
 		acc.AddError(fmt.Errorf("get hbm info of npu failed: %v", err))
 	} else {
 		fields["npu_chip_info_hbm_used_memory"] = hbmInfo.Usage * mega
+		fields["npu_chip_info_hbm_temperature"] = float64(hbmInfo.Temp)
+		fields["npu_chip_info_hbm_bandwidth_utilization"] = float64(hbmInfo.BandWidthUtilRate)
 	}
 
 	power, err := npu.devManager.GetDevicePowerInfo(devID)
@@ -202,6 +202,52 @@ func (npu *NpuWatch) packDcmiInfo(devID int32, fields map[string]interface{}, ac
 	}
 }
 
+// statInfoFields returns the packet counters of the chip by their field names
+func statInfoFields(statInfo parser.StatInfo) map[string]uint64 {
+	return map[string]uint64{
+		"npu_chip_mac_rx_pause_num":          statInfo.MacRxPauseNum,
+		"npu_chip_mac_tx_pause_num":          statInfo.MacTxPauseNum,
+		"npu_chip_mac_rx_pfc_pkt_num":        statInfo.MacRxPfcPktNum,
+		"npu_chip_mac_tx_pfc_pkt_num":        statInfo.MacTxPfcPktNum,
+		"npu_chip_mac_rx_bad_pkt_num":        statInfo.MacRxBadPktNum,
+		"npu_chip_mac_tx_bad_pkt_num":        statInfo.MacTxBadPktNum,
+		"npu_chip_roce_rx_all_pkt_num":       statInfo.RoceRxAllPktNum,
+		"npu_chip_roce_tx_all_pkt_num":       statInfo.RoceTxAllPktNum,
+		"npu_chip_roce_rx_err_pkt_num":       statInfo.RoceRxErrPktNum,
+		"npu_chip_roce_tx_err_pkt_num":       statInfo.RoceTxErrPktNum,
+		"npu_chip_roce_rx_cnp_pkt_num":       statInfo.RoceRxCnpPktNum,
+		"npu_chip_roce_tx_cnp_pkt_num":       statInfo.RoceTxCnpPktNum,
+		"npu_chip_mac_tx_bad_oct_num":        statInfo.MacTxBadOctNum,
+		"npu_chip_mac_rx_bad_oct_num":        statInfo.MacRxBadOctNum,
+		"npu_chip_roce_unexpected_ack_num":   statInfo.RoceUnexpectedAckNum,
+		"npu_chip_roce_out_of_order_num":     statInfo.RoceOutOfOrderNum,
+		"npu_chip_roce_verification_err_num": statInfo.RoceVerificationErrNum,
+		"npu_chip_roce_qp_status_err_num":    statInfo.RoceQpStatusErrNum,
+		"npu_chip_roce_new_pkt_rty_num":      statInfo.RoceNewPktRtyNum,
+	}
+}
+
+// packStatWindow adds the increase and the increase per second of each packet counter since the last gather as the
+// <field>_delta and <field>_rate fields, and the number of the counter resets observed as npu_chip_stat_reset_num
+func (npu *NpuWatch) packStatWindow(phyID int32, statFields map[string]uint64, fields map[string]interface{}) {
+	if npu.statSamples == nil {
+		npu.statSamples = make(map[int32]hccn.StatSample)
+		npu.statResetNum = make(map[int32]uint64)
+	}
+	cur := hccn.StatSample{Counters: statFields, Time: time.Now()}
+	window := hccn.NewStatWindow(npu.statSamples[phyID], cur)
+	npu.statSamples[phyID] = cur
+	if window.Reset {
+		hwlog.RunLog.Warnf("packet counters of npu %d are reset", phyID)
+		npu.statResetNum[phyID]++
+	}
+	for name, delta := range window.Delta {
+		fields[name+"_delta"] = delta
+		fields[name+"_rate"] = window.Rate[name]
+	}
+	fields["npu_chip_stat_reset_num"] = npu.statResetNum[phyID]
+}
+
 func (npu *NpuWatch) packHccnInfo(devID int32, fields map[string]interface{}, acc telegraf.Accumulator) error {
 	phyID, err := npu.devManager.GetPhysicIDFromLogicID(devID)
 	if err != nil {
@@ -230,29 +276,13 @@ func (npu *NpuWatch) packHccnInfo(devID int32, fields map[string]interface{}, ac
 	if err != nil {
 		acc.AddError(fmt.Errorf("get stat info of npu failed: %v", err))
 	} else {
-		fields["npu_chip_mac_rx_pause_num"] = statInfo["mac_rx_mac_pause_num"]
-		fields["npu_chip_mac_tx_pause_num"] = statInfo["mac_tx_mac_pause_num"]
-		fields["npu_chip_mac_rx_pfc_pkt_num"] = statInfo["mac_rx_pfc_pkt_num"]
-		fields["npu_chip_mac_tx_pfc_pkt_num"] = statInfo["mac_tx_pfc_pkt_num"]
-		fields["npu_chip_mac_rx_bad_pkt_num"] = statInfo["mac_rx_bad_pkt_num"]
-		fields["npu_chip_mac_tx_bad_pkt_num"] = statInfo["mac_tx_bad_pkt_num"]
-		fields["npu_chip_roce_rx_all_pkt_num"] = statInfo["roce_rx_all_pkt_num"]
-		fields["npu_chip_roce_tx_all_pkt_num"] = statInfo["roce_tx_all_pkt_num"]
-
-		fields["npu_chip_roce_rx_err_pkt_num"] = statInfo["roce_rx_err_pkt_num"]
-		fields["npu_chip_roce_tx_err_pkt_num"] = statInfo["roce_tx_err_pkt_num"]
-
-		fields["npu_chip_roce_rx_cnp_pkt_num"] = statInfo["roce_rx_cnp_pkt_num"]
-		fields["npu_chip_roce_tx_cnp_pkt_num"] = statInfo["roce_tx_cnp_pkt_num"]
-
-		fields["npu_chip_mac_tx_bad_oct_num"] = statInfo["mac_tx_bad_oct_num"]
-		fields["npu_chip_mac_rx_bad_oct_num"] = statInfo["mac_rx_bad_oct_num"]
-
-		fields["npu_chip_roce_unexpected_ack_num"] = statInfo["roce_unexpected_ack_num"]
-		fields["npu_chip_roce_out_of_order_num"] = statInfo["roce_out_of_order_num"]
-		fields["npu_chip_roce_verification_err_num"] = statInfo["roce_verification_err_num"]
-		fields["npu_chip_roce_qp_status_err_num"] = statInfo["roce_qp_status_err_num"]
-		fields["npu_chip_roce_new_pkt_rty_num"] = statInfo["roce_new_pkt_rty_num"]
+		statFields := statInfoFields(statInfo)
+		for name, count := range statFields {
+			fields[name] = count
+		}
+		if npu.StatRate {
+			npu.packStatWindow(phyID, statFields, fields)
+		}
 	}
 
 	opticalInfo, err := hccn.GetNPUOpticalInfo(phyID)
@@ -266,6 +296,24 @@ func (npu *NpuWatch) packHccnInfo(devID int32, fields map[string]interface{}, ac
 	return nil
 }
 
+// deviceTags return the tags of the chip, the card type and the product type tell the chips of a heterogeneous
+// server apart
+func (npu *NpuWatch) deviceTags(logicID int32) map[string]string {
+	cardType, productType := npu.devManager.GetDevType(), ""
+	if cardID, _, err := npu.devManager.GetCardIDDeviceID(logicID); err == nil {
+		cardType, productType = npu.devManager.GetCardType(cardID), npu.devManager.GetCardProductType(cardID)
+	}
+	devTagValue := "unsupported"
+	if cardType == common.Ascend910B || cardType == common.Ascend910 {
+		devTagValue = common.Chip910
+	}
+	return map[string]string{
+		"device":       devTagValue + "-" + strconv.Itoa(int(logicID)),
+		"card_type":    cardType,
+		"product_type": productType,
+	}
+}
+
 func (npu *NpuWatch) Gather(acc telegraf.Accumulator) error {
 	if npu.devManager == nil {
 		return errors.New("empty dev object")
@@ -277,12 +325,6 @@ func (npu *NpuWatch) Gather(acc telegraf.Accumulator) error {
 	}
 
 	const devName = "ascend"
-	devTag := make(map[string]string)
-	devTagValue := "unsupported"
-	if cardType := npu.devManager.GetDevType(); cardType == common.Ascend910B || cardType == common.Ascend910 {
-		devTagValue = common.Chip910
-	}
-
 	for i := int32(0); i < devNum; i++ {
 		fields := make(map[string]interface{})
 
@@ -291,8 +333,7 @@ func (npu *NpuWatch) Gather(acc telegraf.Accumulator) error {
 			return err
 		}
 
-		devTag["device"] = devTagValue + "-" + strconv.Itoa(int(devList[i]))
-		acc.AddFields(devName, fields, devTag)
+		acc.AddFields(devName, fields, npu.deviceTags(devList[i]))
 	}
 
 	return nil
@@ -0,0 +1,132 @@
+/* Copyright(C) 2023. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package npu this for parse and pack
+package npu
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/telegraf"
+	"github.com/stretchr/testify/assert"
+
+	"huawei.com/npu-exporter/v5/common-utils/hwlog"
+	"huawei.com/npu-exporter/v5/devmanager"
+	"huawei.com/npu-exporter/v5/devmanager/hccn"
+)
+
+func init() {
+	config := hwlog.LogConfig{
+		OnlyToStdout: true,
+	}
+	hwlog.InitRunLogger(&config, nil)
+}
+
+// errAccumulator records the errors, the other methods of telegraf.Accumulator are not used by packHccnInfo
+type errAccumulator struct {
+	telegraf.Accumulator
+	errors []error
+}
+
+func (a *errAccumulator) AddError(err error) {
+	a.errors = append(a.errors, err)
+}
+
+// TestPackHccnInfo test packHccnInfo with the canned outputs of hccn_tool
+func TestPackHccnInfo(t *testing.T) {
+	runner := &hccn.FakeRunner{Outputs: map[string]string{
+		"-i 1 -link -g": "link status: UP\n",
+		"-bandwidth":    "Bandwidth TX: 1.00 MB/sec\nBandwidth RX: 2.00 MB/sec\n",
+		"-speed":        "Speed: 100000 Mb/s\n",
+		"-link_stat":    "[device 1]link up count : 3\n",
+		"-stat":         "roce_tx_all_pkt_num:100\n",
+		"-optical":      "present : present\nTemperature : 38 C\n",
+	}}
+	defer hccn.SetTool(hccn.GetTool())
+	tests := []struct {
+		name       string
+		runner     hccn.Runner
+		devManager devmanager.DeviceInterface
+		wantErr    bool
+		want       map[string]interface{}
+		wantAccErr int
+	}{
+		{name: "should pack hccn fields when hccn_tool works normally", runner: runner,
+			devManager: &devmanager.DeviceManagerMock{},
+			want: map[string]interface{}{"npu_chip_info_link_status": 1, "npu_chip_info_bandwidth_tx": float64(mega),
+				"npu_chip_info_bandwidth_rx": float64(2 * mega), "npu_chip_link_speed": 100000 * mega,
+				"npu_chip_link_up_num": 3, "npu_chip_roce_tx_all_pkt_num": uint64(100),
+				"npu_chip_optical_state": 1, "npu_chip_optical_temp": 38.0}},
+		{name: "should add errors when hccn_tool fails", runner: &hccn.FakeRunner{},
+			devManager: &devmanager.DeviceManagerMock{},
+			want: map[string]interface{}{"npu_chip_info_link_status": 0, "npu_chip_link_speed": 0,
+				"npu_chip_link_up_num": 0, "npu_chip_optical_state": 0}, wantAccErr: 3},
+		{name: "should return error when physic id is not got", runner: runner,
+			devManager: &devmanager.DeviceManagerMockErr{}, wantErr: true, want: map[string]interface{}{},
+			wantAccErr: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hccn.SetTool(hccn.NewTool(context.Background(), tt.runner))
+			npu := &NpuWatch{devManager: tt.devManager}
+			acc := &errAccumulator{}
+			fields := make(map[string]interface{})
+			err := npu.packHccnInfo(0, fields, acc)
+			assert.Equal(t, tt.wantErr, err != nil)
+			for key, value := range tt.want {
+				assert.Equal(t, value, fields[key], key)
+			}
+			assert.Len(t, acc.errors, tt.wantAccErr)
+		})
+	}
+}
+
+// TestPackStatWindow test the delta, rate and reset fields of the packet counters
+func TestPackStatWindow(t *testing.T) {
+	npu := &NpuWatch{StatRate: true}
+	fields := make(map[string]interface{})
+	npu.packStatWindow(1, map[string]uint64{"npu_chip_roce_rx_err_pkt_num": 10}, fields)
+	t.Run("should not add delta when there is no previous sample", func(t *testing.T) {
+		assert.NotContains(t, fields, "npu_chip_roce_rx_err_pkt_num_delta")
+		assert.Equal(t, uint64(0), fields["npu_chip_stat_reset_num"])
+	})
+	t.Run("should add delta and rate when counter increases", func(t *testing.T) {
+		fields = make(map[string]interface{})
+		npu.packStatWindow(1, map[string]uint64{"npu_chip_roce_rx_err_pkt_num": 15}, fields)
+		assert.Equal(t, uint64(5), fields["npu_chip_roce_rx_err_pkt_num_delta"])
+		assert.Contains(t, fields, "npu_chip_roce_rx_err_pkt_num_rate")
+		assert.Equal(t, uint64(0), fields["npu_chip_stat_reset_num"])
+	})
+	t.Run("should count reset and take current value as delta when counter decreases", func(t *testing.T) {
+		fields = make(map[string]interface{})
+		npu.packStatWindow(1, map[string]uint64{"npu_chip_roce_rx_err_pkt_num": 2}, fields)
+		assert.Equal(t, uint64(2), fields["npu_chip_roce_rx_err_pkt_num_delta"])
+		assert.Equal(t, uint64(1), fields["npu_chip_stat_reset_num"])
+	})
+}
+
+// TestDeviceTags test deviceTags
+func TestDeviceTags(t *testing.T) {
+	t.Run("should tag chip with type of its card when card id is found", func(t *testing.T) {
+		npu := &NpuWatch{devManager: &devmanager.DeviceManagerMock{}}
+		assert.Equal(t, map[string]string{"device": "910-0", "card_type": "Ascend910", "product_type": ""},
+			npu.deviceTags(0))
+	})
+	t.Run("should tag chip with dev type when card id is not found", func(t *testing.T) {
+		npu := &NpuWatch{devManager: &devmanager.DeviceManagerMockErr{}}
+		assert.Equal(t, map[string]string{"device": "910-0", "card_type": "Ascend910", "product_type": ""},
+			npu.deviceTags(0))
+	})
+}